@@ -0,0 +1,38 @@
+package config
+
+import (
+	"jboard-go-crud/internal/mailer"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/notifier"
+	"log"
+	"os"
+)
+
+// LoadAlertChannels always emails alerts through m and also posts them to ALERT_WEBHOOK_URL
+// when it is set, signed with ALERT_WEBHOOK_SECRET.
+func LoadAlertChannels(m mailer.Mailer) []notifier.Channel {
+	channels := []notifier.Channel{notifier.NewEmailChannel(m)}
+
+	if url := os.Getenv("ALERT_WEBHOOK_URL"); url != "" {
+		secret := os.Getenv("ALERT_WEBHOOK_SECRET")
+		if secret == "" {
+			log.Printf("WARNING: ALERT_WEBHOOK_SECRET not set, alert webhooks will not be signed")
+		}
+		channels = append(channels, notifier.NewWebhookChannel(url, secret))
+	}
+	return channels
+}
+
+func LoadAlertPolicy() models.AlertPolicy {
+	policy := models.DefaultAlertPolicy()
+	policy.MinMatchScore = envInt("ALERT_MIN_MATCH_SCORE", policy.MinMatchScore)
+	if hour := envInt("ALERT_DAILY_DIGEST_HOUR", policy.DailyDigestHour); hour < 24 {
+		policy.DailyDigestHour = hour
+	} else {
+		log.Printf("Invalid ALERT_DAILY_DIGEST_HOUR '%d', using default: %d", hour, policy.DailyDigestHour)
+	}
+	policy.RetryBaseDelay = envDuration("ALERT_RETRY_BASE_DELAY", policy.RetryBaseDelay)
+	policy.MaxRetryDelay = envDuration("ALERT_RETRY_MAX_DELAY", policy.MaxRetryDelay)
+	log.Printf("Alert policy: %+v", policy)
+	return policy
+}
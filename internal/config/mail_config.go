@@ -0,0 +1,39 @@
+package config
+
+import (
+	"jboard-go-crud/internal/mailer"
+	"log"
+	"os"
+)
+
+// LoadMailer delivers through SMTP when SMTP_HOST is set. MAIL_DRIVER=file appends messages to
+// MAIL_FILE_PATH (default mail.log) instead, so local development can read the tokens. Without
+// either the application refuses to start rather than silently dropping verification and reset
+// emails into a local file.
+func LoadMailer() mailer.Mailer {
+	if os.Getenv("MAIL_DRIVER") == "file" {
+		path := os.Getenv("MAIL_FILE_PATH")
+		if path == "" {
+			path = "mail.log"
+		}
+		log.Printf("MAIL_DRIVER is file, writing emails to %s", path)
+		return mailer.NewFileMailer(path)
+	}
+
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		log.Fatal("SMTP_HOST environment variable not set (set MAIL_DRIVER=file to write emails to a local file)")
+	}
+
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@jboard.local"
+	}
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     host,
+		Port:     envInt("SMTP_PORT", 587),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     from,
+	})
+}
@@ -40,7 +40,7 @@ func InitConnection() {
 	defer cancel()
 
 	var result bson.M
-	if err := client.Database("admin").RunCommand(ctx, bson.D{{"ping", 1}}).Decode(&result); err != nil {
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "ping", Value: 1}}).Decode(&result); err != nil {
 		log.Fatalf("MongoDB ping failed: %v", err)
 	}
 
@@ -99,3 +99,91 @@ func GetSkillsCollection(dbName string) *mongo.Collection {
 	}
 	return GetCollection(dbName, skillsCollectionName)
 }
+
+func GetLoginAttemptsCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_LOGIN_ATTEMPT_COLLECTION")
+	if collectionName == "" {
+		collectionName = "login_attempts"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetLoginAuditCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_LOGIN_AUDIT_COLLECTION")
+	if collectionName == "" {
+		collectionName = "login_audit"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetUserTokensCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_USER_TOKEN_COLLECTION")
+	if collectionName == "" {
+		collectionName = "user_tokens"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetSkillTaxonomyCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_SKILL_TAXONOMY_COLLECTION")
+	if collectionName == "" {
+		collectionName = "skill_taxonomy"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetSavedSearchesCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_SAVED_SEARCH_COLLECTION")
+	if collectionName == "" {
+		collectionName = "saved_searches"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetSavedSearchCountersCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_SAVED_SEARCH_COUNTER_COLLECTION")
+	if collectionName == "" {
+		collectionName = "saved_search_counters"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetJobAlertsCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_JOB_ALERT_COLLECTION")
+	if collectionName == "" {
+		collectionName = "job_alerts"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetJobAlertQueueCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_JOB_ALERT_QUEUE_COLLECTION")
+	if collectionName == "" {
+		collectionName = "job_alert_queue"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetBookmarksCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_BOOKMARK_COLLECTION")
+	if collectionName == "" {
+		collectionName = "bookmarks"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetApplicationsCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_APPLICATION_COLLECTION")
+	if collectionName == "" {
+		collectionName = "applications"
+	}
+	return GetCollection(dbName, collectionName)
+}
+
+func GetMarketSnapshotsCollection(dbName string) *mongo.Collection {
+	collectionName := os.Getenv("MONGODB_MARKET_SNAPSHOT_COLLECTION")
+	if collectionName == "" {
+		collectionName = "market_snapshots"
+	}
+	return GetCollection(dbName, collectionName)
+}
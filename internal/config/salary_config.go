@@ -0,0 +1,20 @@
+package config
+
+import (
+	"jboard-go-crud/internal/models"
+	"log"
+)
+
+func LoadSalaryPolicy() models.SalaryPolicy {
+	policy := models.DefaultSalaryPolicy()
+	if size := envInt("SALARY_MIN_SAMPLE_SIZE", policy.MinSampleSize); size > 0 {
+		policy.MinSampleSize = size
+	} else {
+		log.Printf("Invalid SALARY_MIN_SAMPLE_SIZE '%d', using default: %d", size, policy.MinSampleSize)
+	}
+	for currency, rate := range envRates("SALARY_EXCHANGE_RATES") {
+		policy.ExchangeRates[currency] = rate
+	}
+	log.Printf("Salary policy: %+v", policy)
+	return policy
+}
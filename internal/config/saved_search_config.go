@@ -0,0 +1,14 @@
+package config
+
+import (
+	"jboard-go-crud/internal/models"
+	"log"
+)
+
+func LoadSavedSearchPolicy() models.SavedSearchPolicy {
+	policy := models.DefaultSavedSearchPolicy()
+	policy.FreeLimit = envInt("SAVED_SEARCH_LIMIT_FREE", policy.FreeLimit)
+	policy.PremiumLimit = envInt("SAVED_SEARCH_LIMIT_PREMIUM", policy.PremiumLimit)
+	log.Printf("Saved search policy: %+v", policy)
+	return policy
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"log"
+	"time"
+)
+
+// SchedulerIntervals holds how often each scheduled task runs.
+type SchedulerIntervals struct {
+	SubscriptionCheck   time.Duration
+	JobSkillRetag       time.Duration
+	AlertMatch          time.Duration
+	AlertDispatch       time.Duration
+	MarketSnapshotCheck time.Duration
+}
+
+func LoadSchedulerIntervals() SchedulerIntervals {
+	intervals := SchedulerIntervals{
+		SubscriptionCheck:   envDuration("SUBSCRIPTION_CHECK_INTERVAL", time.Hour),
+		JobSkillRetag:       envDuration("JOB_SKILL_RETAG_INTERVAL", 24*time.Hour),
+		AlertMatch:          envDuration("ALERT_MATCH_INTERVAL", time.Minute),
+		AlertDispatch:       envDuration("ALERT_DISPATCH_INTERVAL", time.Minute),
+		MarketSnapshotCheck: envDuration("MARKET_SNAPSHOT_CHECK_INTERVAL", time.Hour),
+	}
+	log.Printf("Scheduler intervals: %+v", intervals)
+	return intervals
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"jboard-go-crud/internal/models"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func LoadPasswordPolicy() models.PasswordPolicy {
+	policy := models.DefaultPasswordPolicy()
+	policy.MinLength = envInt("PASSWORD_MIN_LENGTH", policy.MinLength)
+	policy.RequireUppercase = envBool("PASSWORD_REQUIRE_UPPERCASE", policy.RequireUppercase)
+	policy.RequireLowercase = envBool("PASSWORD_REQUIRE_LOWERCASE", policy.RequireLowercase)
+	policy.RequireDigit = envBool("PASSWORD_REQUIRE_DIGIT", policy.RequireDigit)
+	policy.RequireSymbol = envBool("PASSWORD_REQUIRE_SYMBOL", policy.RequireSymbol)
+	log.Printf("Password policy: %+v", policy)
+	return policy
+}
+
+func LoadLockoutPolicy() models.LockoutPolicy {
+	policy := models.DefaultLockoutPolicy()
+	// Zero attempts would lock every account out on its first failed login.
+	if attempts := envInt("LOGIN_MAX_ATTEMPTS", policy.MaxAttempts); attempts > 0 {
+		policy.MaxAttempts = attempts
+	} else {
+		log.Printf("Invalid LOGIN_MAX_ATTEMPTS '%d', using default: %d", attempts, policy.MaxAttempts)
+	}
+	policy.Window = envDuration("LOGIN_ATTEMPT_WINDOW", policy.Window)
+	policy.BaseLockout = envDuration("LOGIN_LOCKOUT_BASE", policy.BaseLockout)
+	policy.MaxLockout = envDuration("LOGIN_LOCKOUT_MAX", policy.MaxLockout)
+	log.Printf("Login lockout policy: %+v", policy)
+	return policy
+}
+
+func LoadTokenPolicy() models.TokenPolicy {
+	policy := models.DefaultTokenPolicy()
+	policy.VerificationTTL = envDuration("EMAIL_VERIFICATION_TOKEN_TTL", policy.VerificationTTL)
+	policy.PasswordResetTTL = envDuration("PASSWORD_RESET_TOKEN_TTL", policy.PasswordResetTTL)
+	return policy
+}
+
+func envInt(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		log.Printf("Invalid %s '%s', using default: %d", name, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+func envBool(name string, fallback bool) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid %s '%s', using default: %t", name, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s '%s', using default: %v", name, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// envRates parses a comma-separated list of CODE=rate pairs, skipping malformed entries.
+func envRates(name string) map[string]float64 {
+	rates := map[string]float64{}
+	value := os.Getenv(name)
+	if value == "" {
+		return rates
+	}
+	for _, pair := range strings.Split(value, ",") {
+		code, rate, found := strings.Cut(strings.TrimSpace(pair), "=")
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if !found || err != nil || parsed <= 0 || strings.TrimSpace(code) == "" {
+			log.Printf("Invalid %s entry '%s', skipping", name, pair)
+			continue
+		}
+		rates[strings.ToUpper(strings.TrimSpace(code))] = parsed
+	}
+	return rates
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"encoding/json"
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+)
+
+type AccountHandler struct {
+	accountService services.AccountService
+}
+
+func NewAccountHandler(accountService services.AccountService) *AccountHandler {
+	log.Printf("Creating new AccountHandler")
+	return &AccountHandler{
+		accountService: accountService,
+	}
+}
+
+type SetEmailRequest struct {
+	Email string `json:"email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func (h *AccountHandler) SetMyEmail(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler SetMyEmail called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req SetEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Failed to decode set email request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.accountService.SetEmail(r.Context(), username, req.Email)
+	if err != nil {
+		log.Printf("Service error in SetEmail: %v", err)
+		writeServiceError(w, "account request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *AccountHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler ResendVerification called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.accountService.RequestEmailVerification(r.Context(), username); err != nil {
+		log.Printf("Service error in RequestEmailVerification: %v", err)
+		writeServiceError(w, "account request", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *AccountHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler VerifyEmail called")
+
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Failed to decode verify email request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.accountService.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		log.Printf("Service error in VerifyEmail: %v", err)
+		writeServiceError(w, "account request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *AccountHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler ForgotPassword called")
+
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Failed to decode forgot password request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.accountService.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		log.Printf("Service error in RequestPasswordReset: %v", err)
+		writeServiceError(w, "account request", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *AccountHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler ResetPassword called")
+
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Failed to decode reset password request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.accountService.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		log.Printf("Service error in ResetPassword: %v", err)
+		writeServiceError(w, "account request", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
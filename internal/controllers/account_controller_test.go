@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mockAccountService struct {
+	setEmailFunc                 func(ctx context.Context, username, email string) (models.User, error)
+	requestEmailVerificationFunc func(ctx context.Context, username string) error
+	verifyEmailFunc              func(ctx context.Context, token string) (models.User, error)
+	requestPasswordResetFunc     func(ctx context.Context, email string) error
+	resetPasswordFunc            func(ctx context.Context, token, newPassword string) error
+}
+
+func (m *mockAccountService) SetEmail(ctx context.Context, username, email string) (models.User, error) {
+	return m.setEmailFunc(ctx, username, email)
+}
+
+func (m *mockAccountService) RequestEmailVerification(ctx context.Context, username string) error {
+	return m.requestEmailVerificationFunc(ctx, username)
+}
+
+func (m *mockAccountService) VerifyEmail(ctx context.Context, token string) (models.User, error) {
+	return m.verifyEmailFunc(ctx, token)
+}
+
+func (m *mockAccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	return m.requestPasswordResetFunc(ctx, email)
+}
+
+func (m *mockAccountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	return m.resetPasswordFunc(ctx, token, newPassword)
+}
+
+func TestAccountHandler_SetMyEmail_Success(t *testing.T) {
+	mockService := &mockAccountService{
+		setEmailFunc: func(ctx context.Context, username, email string) (models.User, error) {
+			return models.User{Username: username, Email: email}, nil
+		},
+	}
+
+	handler := NewAccountHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/auth/email", bytes.NewBufferString(`{"email":"user@example.com"}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.SetMyEmail(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response models.User
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Errorf("Error unmarshaling response: %v", err)
+	}
+	if response.Email != "user@example.com" {
+		t.Errorf("Expected email 'user@example.com', got %s", response.Email)
+	}
+}
+
+func TestAccountHandler_SetMyEmail_Unauthenticated(t *testing.T) {
+	handler := NewAccountHandler(&mockAccountService{})
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/auth/email", bytes.NewBufferString(`{"email":"user@example.com"}`))
+	rr := httptest.NewRecorder()
+
+	handler.SetMyEmail(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestAccountHandler_SetMyEmail_Errors(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{&models.ConflictError{Field: "email", Value: "user@example.com"}, http.StatusConflict},
+		{errors.New("invalid email address"), http.StatusBadRequest},
+		{errors.New("user not found"), http.StatusNotFound},
+		{fmt.Errorf("failed to send email: %w", errors.New("connection refused")), http.StatusBadGateway},
+		{errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockService := &mockAccountService{
+			setEmailFunc: func(ctx context.Context, username, email string) (models.User, error) {
+				return models.User{}, tt.err
+			},
+		}
+
+		handler := NewAccountHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/auth/email", bytes.NewBufferString(`{"email":"user@example.com"}`))
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.SetMyEmail(rr, req)
+
+		if rr.Code != tt.expected {
+			t.Errorf("%v: expected status %d, got %d", tt.err, tt.expected, rr.Code)
+		}
+	}
+}
+
+func TestAccountHandler_ResendVerification_Success(t *testing.T) {
+	mockService := &mockAccountService{
+		requestEmailVerificationFunc: func(ctx context.Context, username string) error {
+			return nil
+		},
+	}
+
+	handler := NewAccountHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/email/verification", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ResendVerification(rr, req)
+
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("Expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+}
+
+func TestAccountHandler_VerifyEmail_InvalidToken(t *testing.T) {
+	mockService := &mockAccountService{
+		verifyEmailFunc: func(ctx context.Context, token string) (models.User, error) {
+			return models.User{}, errors.New("invalid or expired token")
+		},
+	}
+
+	handler := NewAccountHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/email/verify", bytes.NewBufferString(`{"token":"abc"}`))
+	rr := httptest.NewRecorder()
+
+	handler.VerifyEmail(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestAccountHandler_ForgotPassword_Accepted(t *testing.T) {
+	var received string
+	mockService := &mockAccountService{
+		requestPasswordResetFunc: func(ctx context.Context, email string) error {
+			received = email
+			return nil
+		},
+	}
+
+	handler := NewAccountHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/password/forgot", bytes.NewBufferString(`{"email":"user@example.com"}`))
+	rr := httptest.NewRecorder()
+
+	handler.ForgotPassword(rr, req)
+
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("Expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+	if received != "user@example.com" {
+		t.Errorf("Expected email to be passed to service, got %q", received)
+	}
+}
+
+func TestAccountHandler_ResetPassword_Success(t *testing.T) {
+	mockService := &mockAccountService{
+		resetPasswordFunc: func(ctx context.Context, token, newPassword string) error {
+			if token != "abc" || newPassword != "newpassword1" {
+				t.Errorf("Unexpected arguments: %s, %s", token, newPassword)
+			}
+			return nil
+		},
+	}
+
+	handler := NewAccountHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/password/reset", bytes.NewBufferString(`{"token":"abc","password":"newpassword1"}`))
+	rr := httptest.NewRecorder()
+
+	handler.ResetPassword(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+}
+
+func TestAccountHandler_ResetPassword_InvalidPayload(t *testing.T) {
+	handler := NewAccountHandler(&mockAccountService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/password/reset", bytes.NewBufferString("invalid json"))
+	rr := httptest.NewRecorder()
+
+	handler.ResetPassword(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
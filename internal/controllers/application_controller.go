@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"encoding/json"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+)
+
+type ApplicationHandler struct {
+	applicationService services.ApplicationService
+}
+
+func NewApplicationHandler(applicationService services.ApplicationService) *ApplicationHandler {
+	log.Printf("Creating new ApplicationHandler")
+	return &ApplicationHandler{
+		applicationService: applicationService,
+	}
+}
+
+func (h *ApplicationHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetBoard called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	board, err := h.applicationService.GetBoard(r.Context(), username)
+	if err != nil {
+		writeServiceError(w, "application request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, board)
+}
+
+func (h *ApplicationHandler) GetApplication(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetApplication called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	application, err := h.applicationService.GetApplication(r.Context(), username, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, "application request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) CreateApplication(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller CreateApplication called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	var request models.ApplicationRequest
+	if !decodeApplicationBody(w, r, &request) {
+		return
+	}
+
+	application, err := h.applicationService.CreateApplication(r.Context(), username, request)
+	if err != nil {
+		writeServiceError(w, "application request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, application)
+}
+
+func (h *ApplicationHandler) UpdateApplication(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller UpdateApplication called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	var patch models.ApplicationPatch
+	if !decodeApplicationBody(w, r, &patch) {
+		return
+	}
+
+	application, err := h.applicationService.UpdateApplication(r.Context(), username, r.PathValue("id"), patch)
+	if err != nil {
+		writeServiceError(w, "application request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, application)
+}
+
+func (h *ApplicationHandler) DeleteApplication(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller DeleteApplication called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.applicationService.DeleteApplication(r.Context(), username, r.PathValue("id")); err != nil {
+		writeServiceError(w, "application request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ApplicationHandler) GetDueReminders(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetDueReminders called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	applications, err := h.applicationService.DueReminders(r.Context(), username)
+	if err != nil {
+		writeServiceError(w, "application request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, applications)
+}
+
+func decodeApplicationBody(w http.ResponseWriter, r *http.Request, target any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		log.Printf("ERROR: Invalid JSON in application request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
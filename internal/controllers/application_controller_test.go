@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockApplicationService struct {
+	boardFunc     func(ctx context.Context, username string) (models.ApplicationBoard, error)
+	getFunc       func(ctx context.Context, username, id string) (models.Application, error)
+	createFunc    func(ctx context.Context, username string, request models.ApplicationRequest) (models.Application, error)
+	updateFunc    func(ctx context.Context, username, id string, patch models.ApplicationPatch) (models.Application, error)
+	deleteFunc    func(ctx context.Context, username, id string) error
+	remindersFunc func(ctx context.Context, username string) ([]models.Application, error)
+}
+
+func (m *mockApplicationService) GetBoard(ctx context.Context, username string) (models.ApplicationBoard, error) {
+	return m.boardFunc(ctx, username)
+}
+
+func (m *mockApplicationService) GetApplication(ctx context.Context, username, id string) (models.Application, error) {
+	return m.getFunc(ctx, username, id)
+}
+
+func (m *mockApplicationService) CreateApplication(ctx context.Context, username string, request models.ApplicationRequest) (models.Application, error) {
+	return m.createFunc(ctx, username, request)
+}
+
+func (m *mockApplicationService) UpdateApplication(ctx context.Context, username, id string, patch models.ApplicationPatch) (models.Application, error) {
+	return m.updateFunc(ctx, username, id, patch)
+}
+
+func (m *mockApplicationService) DeleteApplication(ctx context.Context, username, id string) error {
+	return m.deleteFunc(ctx, username, id)
+}
+
+func (m *mockApplicationService) DueReminders(ctx context.Context, username string) ([]models.Application, error) {
+	return m.remindersFunc(ctx, username)
+}
+
+func TestApplicationHandler_CreateApplication(t *testing.T) {
+	var gotRequest models.ApplicationRequest
+	handler := NewApplicationHandler(&mockApplicationService{
+		createFunc: func(ctx context.Context, username string, request models.ApplicationRequest) (models.Application, error) {
+			gotRequest = request
+			return models.Application{Username: username, JobID: request.JobID}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/me/applications", strings.NewReader(`{"jobId":"job-1","notes":"Referred by Ana"}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.CreateApplication(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "job-1", gotRequest.JobID)
+	assert.Equal(t, "Referred by Ana", gotRequest.Notes)
+}
+
+func TestApplicationHandler_CreateApplication_UnknownField(t *testing.T) {
+	handler := NewApplicationHandler(&mockApplicationService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/me/applications", strings.NewReader(`{"job":"job-1"}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.CreateApplication(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestApplicationHandler_UpdateApplication_IllegalTransition(t *testing.T) {
+	handler := NewApplicationHandler(&mockApplicationService{
+		updateFunc: func(ctx context.Context, username, id string, patch models.ApplicationPatch) (models.Application, error) {
+			assert.Equal(t, enums.ApplicationOffer, *patch.Status)
+			return models.Application{}, &models.ValidationError{Fields: []models.FieldError{{Field: "status", Message: "cannot change from INTERESTED to OFFER"}}}
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/users/me/applications/68e462f868efefe99e226a8b", strings.NewReader(`{"status":"OFFER"}`))
+	req.SetPathValue("id", "68e462f868efefe99e226a8b")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.UpdateApplication(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), "cannot change from INTERESTED to OFFER")
+}
+
+func TestApplicationHandler_GetApplication_Errors(t *testing.T) {
+	for message, status := range map[string]int{
+		"application not found":         http.StatusNotFound,
+		"invalid application ID format": http.StatusBadRequest,
+		"database unavailable":          http.StatusInternalServerError,
+	} {
+		handler := NewApplicationHandler(&mockApplicationService{
+			getFunc: func(ctx context.Context, username, id string) (models.Application, error) {
+				return models.Application{}, errors.New(message)
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/me/applications/x", nil)
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.GetApplication(rr, req)
+
+		assert.Equal(t, status, rr.Code, message)
+	}
+}
+
+func TestApplicationHandler_GetBoard_Unauthenticated(t *testing.T) {
+	handler := NewApplicationHandler(&mockApplicationService{})
+
+	rr := httptest.NewRecorder()
+	handler.GetBoard(rr, httptest.NewRequest(http.MethodGet, "/v1/users/me/applications", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
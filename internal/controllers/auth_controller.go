@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/services"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type AuthHandler struct {
+	authService services.AuthService
+}
+
+func NewAuthHandler(authService services.AuthService) *AuthHandler {
+	log.Printf("Creating new AuthHandler")
+	return &AuthHandler{
+		authService: authService,
+	}
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler Login called")
+
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Failed to decode login request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.authService.Login(r.Context(), models.LoginRequest{
+		Username:  req.Username,
+		Password:  req.Password,
+		IP:        clientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		log.Printf("Service error in Login: %v", err)
+		var locked *models.AccountLockedError
+		if errors.As(err, &locked) {
+			retryAfter := int(math.Ceil(time.Until(locked.Until).Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			http.Error(w, "Too many failed login attempts, try again later", http.StatusTooManyRequests)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid credentials") {
+			http.Error(w, "Invalid username or password", http.StatusUnauthorized)
+			return
+		}
+		if strings.Contains(err.Error(), "cannot be empty") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *AuthHandler) GetMyLoginHistory(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler GetMyLoginHistory called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	h.writeLoginHistory(w, r, username)
+}
+
+func (h *AuthHandler) GetUserLoginHistory(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler GetUserLoginHistory called")
+
+	username := r.URL.Query().Get("username")
+	if username == "" {
+		log.Printf("Username query parameter is missing")
+		http.Error(w, "Username query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	h.writeLoginHistory(w, r, username)
+}
+
+func (h *AuthHandler) writeLoginHistory(w http.ResponseWriter, r *http.Request, username string) {
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil {
+		http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.authService.GetLoginHistory(r.Context(), username, limit)
+	if err != nil {
+		log.Printf("Service error in GetLoginHistory: %v", err)
+		if strings.Contains(err.Error(), "cannot be empty") {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
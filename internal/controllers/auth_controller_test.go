@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type mockAuthService struct {
+	loginFunc           func(ctx context.Context, req models.LoginRequest) (models.UserSummary, error)
+	getLoginHistoryFunc func(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error)
+}
+
+func (m *mockAuthService) Login(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+	return m.loginFunc(ctx, req)
+}
+
+func (m *mockAuthService) GetLoginHistory(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error) {
+	return m.getLoginHistoryFunc(ctx, username, limit)
+}
+
+func TestAuthHandler_Login_Success(t *testing.T) {
+	var received models.LoginRequest
+	mockService := &mockAuthService{
+		loginFunc: func(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+			received = req
+			return models.UserSummary{Username: req.Username}, nil
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"username":"testuser","password":"password123"}`))
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7")
+	req.Header.Set("User-Agent", "test-agent")
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if received.IP != "203.0.113.7" {
+		t.Errorf("Expected client IP '203.0.113.7', got %q", received.IP)
+	}
+	if received.UserAgent != "test-agent" {
+		t.Errorf("Expected user agent 'test-agent', got %q", received.UserAgent)
+	}
+}
+
+func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
+	mockService := &mockAuthService{
+		loginFunc: func(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+			return models.UserSummary{}, errors.New("invalid credentials")
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"username":"testuser","password":"wrong"}`))
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestAuthHandler_Login_Locked(t *testing.T) {
+	mockService := &mockAuthService{
+		loginFunc: func(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+			return models.UserSummary{}, &models.AccountLockedError{Until: time.Now().Add(90 * time.Second)}
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"username":"testuser","password":"password123"}`))
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 89 || retryAfter > 90 {
+		t.Errorf("Expected Retry-After around 90 seconds, got %q", rr.Header().Get("Retry-After"))
+	}
+}
+
+func TestAuthHandler_Login_SpoofedForwardedForStillLocked(t *testing.T) {
+	failures := map[string]int{}
+	mockService := &mockAuthService{
+		loginFunc: func(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+			failures[req.IP]++
+			if failures[req.IP] > 2 {
+				return models.UserSummary{}, &models.AccountLockedError{Until: time.Now().Add(time.Minute)}
+			}
+			return models.UserSummary{}, errors.New("invalid credentials")
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	var rr *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"username":"victim","password":"guess"}`))
+		// The client rotates the first hop; the gateway appends the real peer address.
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("192.0.2.%d, 203.0.113.7", i+1))
+		rr = httptest.NewRecorder()
+		handler.Login(rr, req)
+	}
+
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, rr.Code)
+	}
+	if failures["203.0.113.7"] != 3 {
+		t.Errorf("Expected all attempts counted against 203.0.113.7, got %v", failures)
+	}
+}
+
+func TestAuthHandler_Login_InvalidPayload(t *testing.T) {
+	handler := NewAuthHandler(&mockAuthService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString("invalid json"))
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestAuthHandler_Login_ServiceError(t *testing.T) {
+	mockService := &mockAuthService{
+		loginFunc: func(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+			return models.UserSummary{}, errors.New("database error")
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"username":"testuser","password":"password123"}`))
+	rr := httptest.NewRecorder()
+
+	handler.Login(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
+
+func TestAuthHandler_GetMyLoginHistory_Success(t *testing.T) {
+	mockService := &mockAuthService{
+		getLoginHistoryFunc: func(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error) {
+			if username != "testuser" || limit != 5 {
+				t.Errorf("Expected testuser with limit 5, got %s with %d", username, limit)
+			}
+			return []models.LoginAuditEntry{{Username: username, Success: true}}, nil
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/logins?limit=5", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetMyLoginHistory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response []models.LoginAuditEntry
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Errorf("Error unmarshaling response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Errorf("Expected 1 entry, got %d", len(response))
+	}
+}
+
+func TestAuthHandler_GetMyLoginHistory_Unauthenticated(t *testing.T) {
+	handler := NewAuthHandler(&mockAuthService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/logins", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetMyLoginHistory(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestAuthHandler_GetMyLoginHistory_InvalidLimit(t *testing.T) {
+	handler := NewAuthHandler(&mockAuthService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/logins?limit=abc", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetMyLoginHistory(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestAuthHandler_GetUserLoginHistory_MissingUsername(t *testing.T) {
+	handler := NewAuthHandler(&mockAuthService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users/logins", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetUserLoginHistory(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestAuthHandler_GetUserLoginHistory_ServiceError(t *testing.T) {
+	mockService := &mockAuthService{
+		getLoginHistoryFunc: func(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users/logins?username=testuser", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetUserLoginHistory(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+)
+
+type BookmarkHandler struct {
+	bookmarkService services.BookmarkService
+}
+
+func NewBookmarkHandler(bookmarkService services.BookmarkService) *BookmarkHandler {
+	log.Printf("Creating new BookmarkHandler")
+	return &BookmarkHandler{
+		bookmarkService: bookmarkService,
+	}
+}
+
+func (h *BookmarkHandler) ListBookmarks(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller ListBookmarks called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	bookmarks, err := h.bookmarkService.ListBookmarks(r.Context(), username)
+	if err != nil {
+		writeServiceError(w, "bookmark request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, bookmarks)
+}
+
+// AddBookmark answers 201 for a new bookmark and 200 when an existing one was refreshed.
+func (h *BookmarkHandler) AddBookmark(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller AddBookmark called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	bookmark, created, err := h.bookmarkService.AddBookmark(r.Context(), username, r.PathValue("jobId"))
+	if err != nil {
+		writeServiceError(w, "bookmark request", err)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, bookmark)
+}
+
+func (h *BookmarkHandler) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller RemoveBookmark called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.bookmarkService.RemoveBookmark(r.Context(), username, r.PathValue("jobId")); err != nil {
+		writeServiceError(w, "bookmark request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBookmarkService struct {
+	listFunc   func(ctx context.Context, username string) ([]models.Bookmark, error)
+	addFunc    func(ctx context.Context, username, jobID string) (models.Bookmark, bool, error)
+	removeFunc func(ctx context.Context, username, jobID string) error
+}
+
+func (m *mockBookmarkService) ListBookmarks(ctx context.Context, username string) ([]models.Bookmark, error) {
+	return m.listFunc(ctx, username)
+}
+
+func (m *mockBookmarkService) AddBookmark(ctx context.Context, username, jobID string) (models.Bookmark, bool, error) {
+	return m.addFunc(ctx, username, jobID)
+}
+
+func (m *mockBookmarkService) RemoveBookmark(ctx context.Context, username, jobID string) error {
+	return m.removeFunc(ctx, username, jobID)
+}
+
+func TestBookmarkHandler_AddBookmark(t *testing.T) {
+	created := true
+	var gotJobID string
+	handler := NewBookmarkHandler(&mockBookmarkService{
+		addFunc: func(ctx context.Context, username, jobID string) (models.Bookmark, bool, error) {
+			gotJobID = jobID
+			return models.Bookmark{Username: username, JobID: jobID}, created, nil
+		},
+	})
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/me/bookmarks/job-1", nil)
+		req.SetPathValue("jobId", "job-1")
+		req.Header.Set("X-Username", "testuser")
+		return req
+	}
+
+	rr := httptest.NewRecorder()
+	handler.AddBookmark(rr, newRequest())
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "job-1", gotJobID)
+
+	created = false
+	rr = httptest.NewRecorder()
+	handler.AddBookmark(rr, newRequest())
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestBookmarkHandler_AddBookmark_JobNotFound(t *testing.T) {
+	handler := NewBookmarkHandler(&mockBookmarkService{
+		addFunc: func(ctx context.Context, username, jobID string) (models.Bookmark, bool, error) {
+			return models.Bookmark{}, false, errors.New("job not found")
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/me/bookmarks/ghost", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.AddBookmark(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestBookmarkHandler_ListBookmarks(t *testing.T) {
+	handler := NewBookmarkHandler(&mockBookmarkService{
+		listFunc: func(ctx context.Context, username string) ([]models.Bookmark, error) {
+			return []models.Bookmark{{Username: username, JobID: "job-1", Closed: true}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/bookmarks", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ListBookmarks(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"closed":true`)
+}
+
+func TestBookmarkHandler_RemoveBookmark(t *testing.T) {
+	handler := NewBookmarkHandler(&mockBookmarkService{
+		removeFunc: func(ctx context.Context, username, jobID string) error {
+			if jobID == "job-1" {
+				return nil
+			}
+			return errors.New("bookmark not found")
+		},
+	})
+
+	for jobID, status := range map[string]int{"job-1": http.StatusNoContent, "job-2": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/users/me/bookmarks/"+jobID, nil)
+		req.SetPathValue("jobId", jobID)
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.RemoveBookmark(rr, req)
+
+		assert.Equal(t, status, rr.Code)
+	}
+}
+
+func TestBookmarkHandler_Unauthenticated(t *testing.T) {
+	handler := NewBookmarkHandler(&mockBookmarkService{})
+
+	rr := httptest.NewRecorder()
+	handler.ListBookmarks(rr, httptest.NewRequest(http.MethodGet, "/v1/users/me/bookmarks", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// usernameHeader carries the authenticated caller. The API gateway sets it after validating
+// the caller's credentials and strips any value supplied by the client.
+const usernameHeader = "X-Username"
+
+func authenticatedUsername(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(usernameHeader))
+}
+
+// requireUsername returns the authenticated caller, answering 401 when there is none.
+func requireUsername(w http.ResponseWriter, r *http.Request) (string, bool) {
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return "", false
+	}
+	return username, true
+}
+
+// clientIP returns the address the API gateway appended to X-Forwarded-For, falling back to the
+// connection's remote address. Earlier entries are supplied by the client and cannot be
+// trusted, so only the right-most hop is used.
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+			return last
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// writeServiceError answers a failed service call: 422 with the field problems of a
+// ValidationError, 403 with the reason of a ForbiddenError, 409 for a ConflictError, 404 for
+// missing resources, 502 when an email could not be sent and 400 for bad input. Anything else
+// is logged and hidden behind a 500. request names the call in the logs.
+func writeServiceError(w http.ResponseWriter, request string, err error) {
+	var validation *models.ValidationError
+	var forbidden *models.ForbiddenError
+	var conflict *models.ConflictError
+	message := err.Error()
+	switch {
+	case errors.As(err, &validation):
+		log.Printf("Validation error in %s: %v", request, err)
+		writeJSON(w, http.StatusUnprocessableEntity, validation)
+	case errors.As(err, &forbidden):
+		log.Printf("Forbidden %s: %v", request, err)
+		writeJSON(w, http.StatusForbidden, forbidden)
+	case errors.As(err, &conflict):
+		log.Printf("Conflict in %s: %v", request, err)
+		http.Error(w, message, http.StatusConflict)
+	case strings.Contains(message, "not found"):
+		http.Error(w, message, http.StatusNotFound)
+	case strings.Contains(message, "failed to send email"):
+		log.Printf("ERROR: Email delivery failed in %s: %v", request, err)
+		http.Error(w, "Email delivery failed, try again later", http.StatusBadGateway)
+	case strings.Contains(message, "required") || strings.Contains(message, "invalid") || strings.Contains(message, "cannot be empty"):
+		http.Error(w, message, http.StatusBadRequest)
+	default:
+		log.Printf("ERROR: Service error in %s: %v", request, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
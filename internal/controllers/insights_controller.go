@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+)
+
+type InsightsHandler struct {
+	insightsService services.InsightsService
+}
+
+func NewInsightsHandler(insightsService services.InsightsService) *InsightsHandler {
+	log.Printf("Creating new InsightsHandler")
+	return &InsightsHandler{
+		insightsService: insightsService,
+	}
+}
+
+// GetTrends serves market trends to PREMIUM users.
+func (h *InsightsHandler) GetTrends(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetTrends called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	query, ok := trendQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.insightsService.TrendsForUser(r.Context(), username, query)
+	if err != nil {
+		writeServiceError(w, "insights request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// AdminGetTrends serves market trends to the back office, regardless of role.
+func (h *InsightsHandler) AdminGetTrends(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller AdminGetTrends called")
+
+	query, ok := trendQuery(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.insightsService.Trends(r.Context(), query)
+	if err != nil {
+		writeServiceError(w, "insights request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// RecordSnapshot takes today's market snapshot now, replacing the scheduled one.
+func (h *InsightsHandler) RecordSnapshot(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller RecordSnapshot called")
+
+	snapshot, err := h.insightsService.RecordSnapshot(r.Context())
+	if err != nil {
+		writeServiceError(w, "insights request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, snapshot)
+}
+
+// GetSalaryInsights serves pay percentiles to everyone; the per-group breakdowns are filled in
+// for PREMIUM users only.
+func (h *InsightsHandler) GetSalaryInsights(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetSalaryInsights called")
+
+	query := r.URL.Query()
+	filter := models.JobFilter{
+		Fields:          parseListParam(query["field"]),
+		SeniorityLevels: parseListParam(query["seniority"]),
+		WorkplaceTypes:  parseListParam(query["workplaceType"]),
+	}
+
+	insights, err := h.insightsService.SalaryInsights(r.Context(), authenticatedUsername(r), filter, query.Get("currency"))
+	if err != nil {
+		writeServiceError(w, "insights request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, insights)
+}
+
+func trendQuery(w http.ResponseWriter, r *http.Request) (models.TrendQuery, bool) {
+	params := r.URL.Query()
+	from, err := parseDateParam(params.Get("from"))
+	if err != nil {
+		http.Error(w, "Invalid from parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return models.TrendQuery{}, false
+	}
+	to, err := parseDateParam(params.Get("to"))
+	if err != nil {
+		http.Error(w, "Invalid to parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return models.TrendQuery{}, false
+	}
+	return models.TrendQuery{
+		Metric: params.Get("metric"),
+		Keys:   parseListParam(params["key"]),
+		From:   from,
+		To:     to,
+	}, true
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInsightsService struct {
+	recordFunc        func(ctx context.Context) (models.MarketSnapshot, error)
+	trendsFunc        func(ctx context.Context, query models.TrendQuery) (models.TrendReport, error)
+	trendsForUserFunc func(ctx context.Context, username string, query models.TrendQuery) (models.TrendReport, error)
+	salariesFunc      func(ctx context.Context, username string, filter models.JobFilter, currency string) (models.SalaryInsights, error)
+}
+
+func (m *mockInsightsService) RecordSnapshot(ctx context.Context) (models.MarketSnapshot, error) {
+	return m.recordFunc(ctx)
+}
+
+func (m *mockInsightsService) EnsureDailySnapshot(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func (m *mockInsightsService) Trends(ctx context.Context, query models.TrendQuery) (models.TrendReport, error) {
+	return m.trendsFunc(ctx, query)
+}
+
+func (m *mockInsightsService) TrendsForUser(ctx context.Context, username string, query models.TrendQuery) (models.TrendReport, error) {
+	return m.trendsForUserFunc(ctx, username, query)
+}
+
+func (m *mockInsightsService) SalaryInsights(ctx context.Context, username string, filter models.JobFilter, currency string) (models.SalaryInsights, error) {
+	return m.salariesFunc(ctx, username, filter, currency)
+}
+
+func TestInsightsHandler_GetTrends_ParsesQuery(t *testing.T) {
+	var gotUsername string
+	var gotQuery models.TrendQuery
+	handler := NewInsightsHandler(&mockInsightsService{
+		trendsForUserFunc: func(ctx context.Context, username string, query models.TrendQuery) (models.TrendReport, error) {
+			gotUsername, gotQuery = username, query
+			return models.TrendReport{Metric: query.Metric, Series: []models.TrendSeries{}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/insights/trends?metric=skill&key=Go,Rust&from=2026-05-01&to=2026-05-31", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetTrends(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "testuser", gotUsername)
+	assert.Equal(t, "skill", gotQuery.Metric)
+	assert.Equal(t, []string{"Go", "Rust"}, gotQuery.Keys)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), gotQuery.From)
+	assert.Equal(t, time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), gotQuery.To)
+}
+
+func TestInsightsHandler_GetTrends_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		username string
+		err      error
+		status   int
+	}{
+		{"missing identity", "/v1/insights/trends?metric=jobs", "", nil, http.StatusUnauthorized},
+		{"bad date", "/v1/insights/trends?metric=jobs&from=May", "testuser", nil, http.StatusBadRequest},
+		{"free user", "/v1/insights/trends?metric=jobs", "testuser", &models.ForbiddenError{Reason: "market trends are available to PREMIUM users"}, http.StatusForbidden},
+		{"unknown metric", "/v1/insights/trends?metric=salary", "testuser", errors.New("invalid metric \"salary\""), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewInsightsHandler(&mockInsightsService{
+				trendsForUserFunc: func(ctx context.Context, username string, query models.TrendQuery) (models.TrendReport, error) {
+					return models.TrendReport{}, tt.err
+				},
+			})
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.username != "" {
+				req.Header.Set("X-Username", tt.username)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.GetTrends(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestInsightsHandler_RecordSnapshot(t *testing.T) {
+	handler := NewInsightsHandler(&mockInsightsService{
+		recordFunc: func(ctx context.Context) (models.MarketSnapshot, error) {
+			return models.MarketSnapshot{Date: "2026-06-01", TotalJobs: 42}, nil
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.RecordSnapshot(rr, httptest.NewRequest(http.MethodPost, "/v1/admin/insights/snapshots", nil))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"totalJobs":42`)
+}
+
+func TestInsightsHandler_GetSalaryInsights(t *testing.T) {
+	var gotUsername, gotCurrency string
+	var gotFilter models.JobFilter
+	handler := NewInsightsHandler(&mockInsightsService{
+		salariesFunc: func(ctx context.Context, username string, filter models.JobFilter, currency string) (models.SalaryInsights, error) {
+			gotUsername, gotFilter, gotCurrency = username, filter, currency
+			if currency == "XYZ" {
+				return models.SalaryInsights{}, errors.New("invalid currency \"XYZ\"")
+			}
+			return models.SalaryInsights{Currency: currency}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/insights/salaries?field=Engineering&seniority=Senior,Mid&currency=BRL", nil)
+	rr := httptest.NewRecorder()
+	handler.GetSalaryInsights(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, gotUsername)
+	assert.Equal(t, "BRL", gotCurrency)
+	assert.Equal(t, []string{"Engineering"}, gotFilter.Fields)
+	assert.Equal(t, []string{"Senior", "Mid"}, gotFilter.SeniorityLevels)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/insights/salaries?currency=XYZ", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr = httptest.NewRecorder()
+	handler.GetSalaryInsights(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "testuser", gotUsername)
+}
@@ -58,13 +58,27 @@ func (h *JobHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetAllJobs lists jobs as a JSON array, or streams them as CSV or NDJSON when asked for
+// through ?format= or the Accept header.
 func (h *JobHandler) GetAllJobs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "HTTP Method invalid", http.StatusMethodNotAllowed)
 		return
 	}
 
-	jobs, err := h.svc.FindAll(r.Context())
+	format, ok := listingFormat(r)
+	if !ok {
+		http.Error(w, "invalid format parameter, expected json, csv or ndjson", http.StatusBadRequest)
+		return
+	}
+	if format != "json" {
+		h.exportJobs(w, r, format)
+		return
+	}
+
+	filter, useProfile := jobListingFilter(r)
+
+	jobs, err := h.svc.FindJobs(r.Context(), authenticatedUsername(r), filter, useProfile)
 	if err != nil {
 		log.Printf("FindAll failed: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -82,3 +96,48 @@ func (h *JobHandler) GetAllJobs(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Returned %d jobs", len(jobs))
 }
+
+// GetJobStats returns facet counts for the jobs GetAllJobs would list with the same query.
+func (h *JobHandler) GetJobStats(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetJobStats called")
+
+	filter, useProfile := jobListingFilter(r)
+
+	stats, err := h.svc.JobStats(r.Context(), authenticatedUsername(r), filter, useProfile)
+	if err != nil {
+		log.Printf("JobStats failed: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// jobListingFilter reads the listing filter from the query string. Logged-in users get their
+// profile preferences as defaults; ?defaults=false opts out. Only ?brazilianFriendly=true
+// narrows the listing, any other value means no restriction.
+func jobListingFilter(r *http.Request) (models.JobFilter, bool) {
+	query := r.URL.Query()
+	filter := models.JobFilter{
+		Fields:            parseListParam(query["field"]),
+		SeniorityLevels:   parseListParam(query["seniority"]),
+		WorkplaceTypes:    parseListParam(query["workplaceType"]),
+		Skills:            parseListParam(query["skills"]),
+		BrazilianFriendly: query.Get("brazilianFriendly") == "true",
+	}
+	return filter, query.Get("defaults") != "false"
+}
+
+// RetagJobs re-extracts the skill tags of every stored job from the current skill catalog.
+func (h *JobHandler) RetagJobs(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller RetagJobs called")
+
+	result, err := h.svc.RetagJobs(r.Context())
+	if err != nil {
+		log.Printf("RetagJobs failed: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
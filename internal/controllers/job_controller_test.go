@@ -9,12 +9,30 @@ import (
 	"jboard-go-crud/internal/services"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
 type mockJobService struct {
 	createOrUpdateFunc func(ctx context.Context, job models.Job) (services.UpsertOutcome, error)
 	findAllFunc        func(ctx context.Context) ([]models.Job, error)
+	findJobsFunc       func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error)
+	retagJobsFunc      func(ctx context.Context) (models.JobRetagResult, error)
+	jobStatsFunc       func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobStats, error)
+	recentJobsFunc     func(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error)
+	streamJobsFunc     func(ctx context.Context, username string, filter models.JobFilter, useProfile bool, fn func(models.Job) error) error
+}
+
+func (m *mockJobService) StreamJobs(ctx context.Context, username string, filter models.JobFilter, useProfile bool, fn func(models.Job) error) error {
+	return m.streamJobsFunc(ctx, username, filter, useProfile, fn)
+}
+
+func (m *mockJobService) RecentJobs(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error) {
+	return m.recentJobsFunc(ctx, filter, limit)
+}
+
+func (m *mockJobService) JobStats(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobStats, error) {
+	return m.jobStatsFunc(ctx, username, filter, useProfile)
 }
 
 func (m *mockJobService) CreateOrUpdate(ctx context.Context, job models.Job) (services.UpsertOutcome, error) {
@@ -25,6 +43,14 @@ func (m *mockJobService) FindAll(ctx context.Context) ([]models.Job, error) {
 	return m.findAllFunc(ctx)
 }
 
+func (m *mockJobService) FindJobs(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error) {
+	return m.findJobsFunc(ctx, username, filter, useProfile)
+}
+
+func (m *mockJobService) RetagJobs(ctx context.Context) (models.JobRetagResult, error) {
+	return m.retagJobsFunc(ctx)
+}
+
 func TestNewJobHandler(t *testing.T) {
 	mockService := &mockJobService{}
 	handler := NewJobHandler(mockService)
@@ -245,7 +271,7 @@ func TestJobHandler_GetAllJobs_Success(t *testing.T) {
 	}
 
 	mockService := &mockJobService{
-		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+		findJobsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error) {
 			return expectedJobs, nil
 		},
 	}
@@ -292,7 +318,7 @@ func TestJobHandler_GetAllJobs_InvalidMethod(t *testing.T) {
 
 func TestJobHandler_GetAllJobs_ServiceError(t *testing.T) {
 	mockService := &mockJobService{
-		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+		findJobsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error) {
 			return nil, errors.New("service error")
 		},
 	}
@@ -308,3 +334,138 @@ func TestJobHandler_GetAllJobs_ServiceError(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
 	}
 }
+
+func TestJobHandler_GetAllJobs_FiltersAndProfileDefaults(t *testing.T) {
+	var gotUsername string
+	var gotFilter models.JobFilter
+	var gotUseProfile bool
+	mockService := &mockJobService{
+		findJobsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error) {
+			gotUsername, gotFilter, gotUseProfile = username, filter, useProfile
+			return []models.Job{}, nil
+		},
+	}
+
+	handler := NewJobHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs?field=Engineering,Design&seniority=Senior&workplaceType=Remote&workplaceType=Hybrid&skills=go,kubernetes", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetAllJobs(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if gotUsername != "testuser" || !gotUseProfile {
+		t.Errorf("Expected profile defaults for testuser, got %q (%t)", gotUsername, gotUseProfile)
+	}
+	if len(gotFilter.Fields) != 2 || len(gotFilter.SeniorityLevels) != 1 || len(gotFilter.WorkplaceTypes) != 2 || len(gotFilter.Skills) != 2 {
+		t.Errorf("Unexpected filter: %+v", gotFilter)
+	}
+}
+
+func TestJobHandler_GetAllJobs_DefaultsDisabled(t *testing.T) {
+	var gotUseProfile = true
+	mockService := &mockJobService{
+		findJobsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error) {
+			gotUseProfile = useProfile
+			return []models.Job{}, nil
+		},
+	}
+
+	handler := NewJobHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs?defaults=false", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetAllJobs(rr, req)
+
+	if gotUseProfile {
+		t.Error("Expected profile defaults to be disabled")
+	}
+}
+
+func TestJobHandler_RetagJobs(t *testing.T) {
+	mockService := &mockJobService{
+		retagJobsFunc: func(ctx context.Context) (models.JobRetagResult, error) {
+			return models.JobRetagResult{Scanned: 5, Updated: 2}, nil
+		},
+	}
+
+	handler := NewJobHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/jobs/retag", nil)
+	rr := httptest.NewRecorder()
+
+	handler.RetagJobs(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	var result models.JobRetagResult
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil || result.Updated != 2 {
+		t.Errorf("Unexpected response %q: %v", rr.Body.String(), err)
+	}
+}
+
+func TestJobHandler_RetagJobs_ServiceError(t *testing.T) {
+	mockService := &mockJobService{
+		retagJobsFunc: func(ctx context.Context) (models.JobRetagResult, error) {
+			return models.JobRetagResult{}, errors.New("service error")
+		},
+	}
+
+	handler := NewJobHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/jobs/retag", nil)
+	rr := httptest.NewRecorder()
+
+	handler.RetagJobs(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
+
+func TestJobHandler_GetJobStats(t *testing.T) {
+	var gotFilter models.JobFilter
+	var gotUseProfile bool
+	handler := NewJobHandler(&mockJobService{
+		jobStatsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobStats, error) {
+			gotFilter, gotUseProfile = filter, useProfile
+			return models.JobStats{Total: 2, Fields: []models.FacetCount{{Value: "Engineering", Count: 2}}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs/stats?workplaceType=Remote,Hybrid&defaults=false", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetJobStats(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if len(gotFilter.WorkplaceTypes) != 2 || gotUseProfile {
+		t.Errorf("Expected the listing filter without profile defaults, got %+v, %t", gotFilter, gotUseProfile)
+	}
+	if !strings.Contains(rr.Body.String(), `"fields":[{"value":"Engineering","count":2}]`) {
+		t.Errorf("Unexpected body: %s", rr.Body.String())
+	}
+}
+
+func TestJobHandler_GetJobStats_ServiceError(t *testing.T) {
+	handler := NewJobHandler(&mockJobService{
+		jobStatsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobStats, error) {
+			return models.JobStats{}, errors.New("database error")
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.GetJobStats(rr, httptest.NewRequest(http.MethodGet, "/v1/jobs/stats", nil))
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
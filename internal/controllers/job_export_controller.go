@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"fmt"
+	"jboard-go-crud/internal/export"
+	"jboard-go-crud/internal/models"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// exportFlushInterval is how many rows are written between flushes to the client.
+const exportFlushInterval = 500
+
+// listingFormat picks the GetAllJobs format from ?format=, falling back to the Accept header.
+// It reports false for an unknown format parameter.
+func listingFormat(r *http.Request) (string, bool) {
+	switch format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))); format {
+	case "":
+	case "json", "csv", "ndjson":
+		return format, true
+	default:
+		return "", false
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return "csv", true
+	case strings.Contains(accept, "application/x-ndjson"), strings.Contains(accept, "application/ndjson"):
+		return "ndjson", true
+	}
+	return "json", true
+}
+
+// exportJobs streams the listing as CSV or NDJSON straight from the database cursor. Until the
+// first bytes reach the client a failure is still reported as an error status; after that the
+// response can only be cut short.
+func (h *JobHandler) exportJobs(w http.ResponseWriter, r *http.Request, format string) {
+	columns, err := export.ParseColumns(parseListParam(r.URL.Query()["columns"]))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	out := &trackingWriter{ResponseWriter: w}
+	var writer export.Writer
+	if format == "csv" {
+		writer, err = export.NewCSV(out, columns)
+		if err != nil {
+			log.Printf("Failed to start CSV export: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", export.CSVContentType)
+	} else {
+		writer = export.NewNDJSON(out, columns)
+		w.Header().Set("Content-Type", export.NDJSONContentType)
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="jobs-%s.%s"`, time.Now().UTC().Format(time.DateOnly), format))
+
+	filter, useProfile := jobListingFilter(r)
+	controller := http.NewResponseController(w)
+	rows := 0
+	err = h.svc.StreamJobs(r.Context(), authenticatedUsername(r), filter, useProfile, func(job models.Job) error {
+		if err := writer.Write(job); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushInterval == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			_ = controller.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		log.Printf("Job export failed after %d rows: %v", rows, err)
+		if !out.written {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("Exported %d jobs as %s", rows, format)
+}
+
+// trackingWriter records whether any bytes were sent, which commits the response status.
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(p)
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func streamingJobService(jobs []models.Job, err error) *mockJobService {
+	return &mockJobService{
+		streamJobsFunc: func(ctx context.Context, username string, filter models.JobFilter, useProfile bool, fn func(models.Job) error) error {
+			for _, job := range jobs {
+				if err := fn(job); err != nil {
+					return err
+				}
+			}
+			return err
+		},
+	}
+}
+
+func TestJobHandler_GetAllJobs_CSVFromAcceptHeader(t *testing.T) {
+	handler := NewJobHandler(streamingJobService([]models.Job{{ID: "job-1", Title: "Go Engineer", Skills: []string{"Go"}}}, nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs?columns=id,title,skills", nil)
+	req.Header.Set("Accept", "text/csv")
+	rr := httptest.NewRecorder()
+
+	handler.GetAllJobs(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Unexpected content type %q", got)
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Disposition"), `attachment; filename="jobs-`) {
+		t.Errorf("Expected an attachment, got %q", rr.Header().Get("Content-Disposition"))
+	}
+	if body := rr.Body.String(); body != "id,title,skills\njob-1,Go Engineer,Go\n" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+}
+
+func TestJobHandler_GetAllJobs_NDJSONFromFormat(t *testing.T) {
+	handler := NewJobHandler(streamingJobService([]models.Job{{ID: "job-1"}, {ID: "job-2"}}, nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs?format=ndjson&columns=id", nil)
+	req.Header.Set("Accept", "text/csv")
+	rr := httptest.NewRecorder()
+
+	handler.GetAllJobs(rr, req)
+
+	if got := rr.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Expected the format parameter to win, got %q", got)
+	}
+	if body := rr.Body.String(); body != "{\"id\":\"job-1\"}\n{\"id\":\"job-2\"}\n" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+}
+
+func TestJobHandler_GetAllJobs_ExportErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		err    error
+		status int
+	}{
+		{"unknown format", "/v1/jobs?format=xml", nil, http.StatusBadRequest},
+		{"unknown column", "/v1/jobs?format=csv&columns=salary", nil, http.StatusBadRequest},
+		{"database error before any row", "/v1/jobs?format=csv", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewJobHandler(streamingJobService(nil, tt.err))
+			rr := httptest.NewRecorder()
+
+			handler.GetAllJobs(rr, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if rr.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rr.Code)
+			}
+			if rr.Header().Get("Content-Disposition") != "" {
+				t.Errorf("Expected no attachment on error")
+			}
+		})
+	}
+}
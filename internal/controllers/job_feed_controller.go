@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"jboard-go-crud/internal/feed"
+	"jboard-go-crud/internal/models"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFeedLimit = 50
+	maxFeedLimit     = 200
+)
+
+// GetJobsRSS serves the newest jobs matching the listing filters as an RSS 2.0 feed.
+func (h *JobHandler) GetJobsRSS(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetJobsRSS called")
+	h.writeJobFeed(w, r, feed.RSSContentType, feed.RSS)
+}
+
+// GetJobsAtom serves the newest jobs matching the listing filters as an Atom 1.0 feed.
+func (h *JobHandler) GetJobsAtom(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetJobsAtom called")
+	h.writeJobFeed(w, r, feed.AtomContentType, feed.Atom)
+}
+
+// writeJobFeed builds the feed from the listing filters. Feed readers poll anonymously, so
+// profile defaults never apply and the URL alone decides the contents.
+func (h *JobHandler) writeJobFeed(w http.ResponseWriter, r *http.Request, contentType string, render func(feed.Feed) ([]byte, error)) {
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil || limit < 0 {
+		http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	filter, _ := jobListingFilter(r)
+	jobs, err := h.svc.RecentJobs(r.Context(), filter, limit)
+	if err != nil {
+		log.Printf("RecentJobs failed: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	updated := time.Now()
+	if len(jobs) > 0 && !jobs[0].FirstSeenAt.IsZero() {
+		updated = jobs[0].FirstSeenAt
+	}
+	body, err := render(feed.Feed{
+		Title:       feedTitle(filter),
+		Description: "The newest jobs posted on JBoard",
+		SelfURL:     requestURL(r),
+		Updated:     updated,
+		Jobs:        jobs,
+	})
+	if err != nil {
+		log.Printf("Failed to render job feed: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("Job feed write error: %v", err)
+	}
+}
+
+// feedTitle names the feed after its filters, e.g. "JBoard jobs: Go, Brazilian-friendly".
+func feedTitle(filter models.JobFilter) string {
+	parts := []string{}
+	for _, values := range [][]string{filter.Skills, filter.Fields, filter.SeniorityLevels, filter.WorkplaceTypes} {
+		parts = append(parts, values...)
+	}
+	if filter.BrazilianFriendly {
+		parts = append(parts, "Brazilian-friendly")
+	}
+	if len(parts) == 0 {
+		return "JBoard jobs"
+	}
+	return "JBoard jobs: " + strings.Join(parts, ", ")
+}
+
+// requestURL rebuilds the public URL of the request, honouring the scheme forwarded by the API
+// gateway.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJobHandler_GetJobsRSS(t *testing.T) {
+	var gotFilter models.JobFilter
+	var gotLimit int
+	handler := NewJobHandler(&mockJobService{
+		recentJobsFunc: func(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error) {
+			gotFilter, gotLimit = filter, limit
+			return []models.Job{{ID: "job-1", Title: "Go Engineer", Company: "Acme", FirstSeenAt: time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs/feed.rss?skills=Go&brazilianFriendly=true", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rr := httptest.NewRecorder()
+
+	handler.GetJobsRSS(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "application/rss+xml; charset=utf-8" {
+		t.Errorf("Unexpected content type %q", got)
+	}
+	if !gotFilter.BrazilianFriendly || len(gotFilter.Skills) != 1 || gotLimit != defaultFeedLimit {
+		t.Errorf("Expected the listing filter and default limit, got %+v, %d", gotFilter, gotLimit)
+	}
+	body := rr.Body.String()
+	for _, want := range []string{
+		"<title>JBoard jobs: Go, Brazilian-friendly</title>",
+		`href="https://example.com/v1/jobs/feed.rss?skills=Go&amp;brazilianFriendly=true"`,
+		"<title>Go Engineer at Acme</title>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected body to contain %s, got %s", want, body)
+		}
+	}
+}
+
+func TestJobHandler_GetJobsAtom(t *testing.T) {
+	var gotLimit int
+	handler := NewJobHandler(&mockJobService{
+		recentJobsFunc: func(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error) {
+			gotLimit = limit
+			return []models.Job{}, nil
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.GetJobsAtom(rr, httptest.NewRequest(http.MethodGet, "/v1/jobs/feed.atom?limit=1000", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "application/atom+xml; charset=utf-8" {
+		t.Errorf("Unexpected content type %q", got)
+	}
+	if gotLimit != maxFeedLimit {
+		t.Errorf("Expected limit capped at %d, got %d", maxFeedLimit, gotLimit)
+	}
+	if !strings.Contains(rr.Body.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`) {
+		t.Errorf("Unexpected body: %s", rr.Body.String())
+	}
+}
+
+func TestJobHandler_GetJobsRSS_Errors(t *testing.T) {
+	handler := NewJobHandler(&mockJobService{
+		recentJobsFunc: func(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error) {
+			return nil, errors.New("database error")
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.GetJobsRSS(rr, httptest.NewRequest(http.MethodGet, "/v1/jobs/feed.rss?limit=abc", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.GetJobsRSS(rr, httptest.NewRequest(http.MethodGet, "/v1/jobs/feed.rss", nil))
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type MatchHandler struct {
+	matchService services.MatchService
+}
+
+func NewMatchHandler(matchService services.MatchService) *MatchHandler {
+	log.Printf("Creating new MatchHandler")
+	return &MatchHandler{
+		matchService: matchService,
+	}
+}
+
+func (h *MatchHandler) GetJobMatches(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetJobMatches called")
+
+	// Matches are built from the user's skills and profile, so only the owner may read them.
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(r.PathValue("username"), username) {
+		log.Printf("User %s asked for the job matches of %s", username, r.PathValue("username"))
+		writeJSON(w, http.StatusForbidden, &models.ForbiddenError{Reason: "job matches are only available for your own account"})
+		return
+	}
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil {
+		log.Printf("Invalid limit parameter in GetJobMatches: %v", err)
+		http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+
+	matches, err := h.matchService.MatchJobs(r.Context(), username, limit)
+	if err != nil {
+		writeServiceError(w, "match request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(matches)
+}
+
+func (h *MatchHandler) GetSkillGaps(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetSkillGaps called")
+
+	// The report is PREMIUM-gated on the caller, so it is only served for the caller's own account.
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(r.PathValue("username"), username) {
+		log.Printf("User %s asked for the skill gaps of %s", username, r.PathValue("username"))
+		writeJSON(w, http.StatusForbidden, &models.ForbiddenError{Reason: "skill-gap reports are only available for your own account"})
+		return
+	}
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil {
+		log.Printf("Invalid limit parameter in GetSkillGaps: %v", err)
+		http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.matchService.SkillGaps(r.Context(), username, limit)
+	if err != nil {
+		writeServiceError(w, "match request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMatchService struct {
+	matchJobsFunc func(ctx context.Context, username string, limit int) ([]models.JobMatch, error)
+	skillGapsFunc func(ctx context.Context, username string, limit int) (models.SkillGapReport, error)
+}
+
+func (m *mockMatchService) MatchJobs(ctx context.Context, username string, limit int) ([]models.JobMatch, error) {
+	return m.matchJobsFunc(ctx, username, limit)
+}
+
+func (m *mockMatchService) SkillGaps(ctx context.Context, username string, limit int) (models.SkillGapReport, error) {
+	return m.skillGapsFunc(ctx, username, limit)
+}
+
+func newMatchRequest(target string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.SetPathValue("username", "testuser")
+	req.Header.Set("X-Username", "testuser")
+	return req
+}
+
+func TestMatchHandler_GetJobMatches_Success(t *testing.T) {
+	var gotUsername string
+	var gotLimit int
+	handler := NewMatchHandler(&mockMatchService{
+		matchJobsFunc: func(ctx context.Context, username string, limit int) ([]models.JobMatch, error) {
+			gotUsername, gotLimit = username, limit
+			return []models.JobMatch{{Job: models.Job{ID: "a"}, Score: 90, MatchedSkills: []string{"Go"}, MissingSkills: []string{}}}, nil
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.GetJobMatches(rr, newMatchRequest("/v1/users/testuser/matches?limit=5"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "testuser", gotUsername)
+	assert.Equal(t, 5, gotLimit)
+	assert.Contains(t, rr.Body.String(), `"matchedSkills":["Go"]`)
+}
+
+func TestMatchHandler_GetJobMatches_InvalidLimit(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{})
+
+	rr := httptest.NewRecorder()
+	handler.GetJobMatches(rr, newMatchRequest("/v1/users/testuser/matches?limit=abc"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestMatchHandler_GetJobMatches_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		status int
+	}{
+		{errors.New("user not found"), http.StatusNotFound},
+		{errors.New("username is required"), http.StatusBadRequest},
+		{errors.New("database error"), http.StatusInternalServerError},
+	} {
+		handler := NewMatchHandler(&mockMatchService{
+			matchJobsFunc: func(ctx context.Context, username string, limit int) ([]models.JobMatch, error) {
+				return nil, tc.err
+			},
+		})
+
+		rr := httptest.NewRecorder()
+		handler.GetJobMatches(rr, newMatchRequest("/v1/users/testuser/matches"))
+
+		assert.Equal(t, tc.status, rr.Code, tc.err.Error())
+	}
+}
+
+func TestMatchHandler_GetJobMatches_OtherUser(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{
+		matchJobsFunc: func(ctx context.Context, username string, limit int) ([]models.JobMatch, error) {
+			t.Errorf("Expected no matches for another user, got a request for %s", username)
+			return nil, nil
+		},
+	})
+
+	req := newMatchRequest("/v1/users/testuser/matches")
+	req.Header.Set("X-Username", "otheruser")
+	rr := httptest.NewRecorder()
+	handler.GetJobMatches(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestMatchHandler_GetJobMatches_Unauthenticated(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{})
+
+	req := newMatchRequest("/v1/users/testuser/matches")
+	req.Header.Del("X-Username")
+	rr := httptest.NewRecorder()
+	handler.GetJobMatches(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestMatchHandler_GetSkillGaps_Success(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{
+		skillGapsFunc: func(ctx context.Context, username string, limit int) (models.SkillGapReport, error) {
+			return models.SkillGapReport{JobsInScope: 3, Gaps: []models.SkillGap{{Name: "Kubernetes", Demand: 2, Unlocks: 1}}}, nil
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.GetSkillGaps(rr, newMatchRequest("/v1/users/testuser/skill-gaps"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{"name":"Kubernetes","demand":2,"unlocks":1}`)
+}
+
+func TestMatchHandler_GetSkillGaps_Forbidden(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{
+		skillGapsFunc: func(ctx context.Context, username string, limit int) (models.SkillGapReport, error) {
+			return models.SkillGapReport{}, &models.ForbiddenError{Reason: "skill-gap analysis is available to PREMIUM users"}
+		},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.GetSkillGaps(rr, newMatchRequest("/v1/users/testuser/skill-gaps"))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "PREMIUM")
+}
+
+func TestMatchHandler_GetSkillGaps_OtherUser(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{
+		skillGapsFunc: func(ctx context.Context, username string, limit int) (models.SkillGapReport, error) {
+			t.Errorf("Expected no report for another user, got a request for %s", username)
+			return models.SkillGapReport{}, nil
+		},
+	})
+
+	req := newMatchRequest("/v1/users/testuser/skill-gaps")
+	req.Header.Set("X-Username", "premiumuser")
+	rr := httptest.NewRecorder()
+	handler.GetSkillGaps(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestMatchHandler_GetSkillGaps_Unauthenticated(t *testing.T) {
+	handler := NewMatchHandler(&mockMatchService{})
+
+	req := newMatchRequest("/v1/users/testuser/skill-gaps")
+	req.Header.Del("X-Username")
+	rr := httptest.NewRecorder()
+	handler.GetSkillGaps(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
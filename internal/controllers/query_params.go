@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseIntParam parses an optional integer query parameter; empty means zero.
+func parseIntParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// parseDateParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date; empty means zero time.
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// parseListParam collects a query parameter given repeatedly and/or comma-separated.
+func parseListParam(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"encoding/json"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+)
+
+type SavedSearchHandler struct {
+	searchService services.SavedSearchService
+}
+
+func NewSavedSearchHandler(searchService services.SavedSearchService) *SavedSearchHandler {
+	log.Printf("Creating new SavedSearchHandler")
+	return &SavedSearchHandler{
+		searchService: searchService,
+	}
+}
+
+func (h *SavedSearchHandler) ListSearches(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller ListSearches called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	searches, err := h.searchService.ListSearches(r.Context(), username)
+	if err != nil {
+		writeServiceError(w, "saved search request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, searches)
+}
+
+func (h *SavedSearchHandler) GetSearch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetSearch called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	search, err := h.searchService.GetSearch(r.Context(), username, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, "saved search request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, search)
+}
+
+func (h *SavedSearchHandler) CreateSearch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller CreateSearch called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	request, ok := decodeSavedSearchRequest(w, r)
+	if !ok {
+		return
+	}
+
+	search, err := h.searchService.CreateSearch(r.Context(), username, request)
+	if err != nil {
+		writeServiceError(w, "saved search request", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, search)
+}
+
+func (h *SavedSearchHandler) UpdateSearch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller UpdateSearch called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	request, ok := decodeSavedSearchRequest(w, r)
+	if !ok {
+		return
+	}
+
+	search, err := h.searchService.UpdateSearch(r.Context(), username, r.PathValue("id"), request)
+	if err != nil {
+		writeServiceError(w, "saved search request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, search)
+}
+
+func (h *SavedSearchHandler) DeleteSearch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller DeleteSearch called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.searchService.DeleteSearch(r.Context(), username, r.PathValue("id")); err != nil {
+		writeServiceError(w, "saved search request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SavedSearchHandler) RunSearch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller RunSearch called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := h.searchService.RunSearch(r.Context(), username, r.PathValue("id"))
+	if err != nil {
+		writeServiceError(w, "saved search request", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func decodeSavedSearchRequest(w http.ResponseWriter, r *http.Request) (models.SavedSearchRequest, bool) {
+	var request models.SavedSearchRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		log.Printf("ERROR: Invalid JSON in saved search request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return models.SavedSearchRequest{}, false
+	}
+	return request, true
+}
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSavedSearchService struct {
+	listFunc   func(ctx context.Context, username string) ([]models.SavedSearch, error)
+	getFunc    func(ctx context.Context, username, id string) (models.SavedSearch, error)
+	createFunc func(ctx context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error)
+	updateFunc func(ctx context.Context, username, id string, request models.SavedSearchRequest) (models.SavedSearch, error)
+	deleteFunc func(ctx context.Context, username, id string) error
+	runFunc    func(ctx context.Context, username, id string) (models.SavedSearchResults, error)
+}
+
+func (m *mockSavedSearchService) ListSearches(ctx context.Context, username string) ([]models.SavedSearch, error) {
+	return m.listFunc(ctx, username)
+}
+
+func (m *mockSavedSearchService) GetSearch(ctx context.Context, username, id string) (models.SavedSearch, error) {
+	return m.getFunc(ctx, username, id)
+}
+
+func (m *mockSavedSearchService) CreateSearch(ctx context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+	return m.createFunc(ctx, username, request)
+}
+
+func (m *mockSavedSearchService) UpdateSearch(ctx context.Context, username, id string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+	return m.updateFunc(ctx, username, id, request)
+}
+
+func (m *mockSavedSearchService) DeleteSearch(ctx context.Context, username, id string) error {
+	return m.deleteFunc(ctx, username, id)
+}
+
+func (m *mockSavedSearchService) RunSearch(ctx context.Context, username, id string) (models.SavedSearchResults, error) {
+	return m.runFunc(ctx, username, id)
+}
+
+func TestSavedSearchHandler_CreateSearch_Success(t *testing.T) {
+	var gotUsername string
+	var gotRequest models.SavedSearchRequest
+	handler := NewSavedSearchHandler(&mockSavedSearchService{
+		createFunc: func(ctx context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+			gotUsername, gotRequest = username, request
+			return models.SavedSearch{Username: username, Name: request.Name, Filter: request.Filter}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/me/searches", strings.NewReader(`{"name":"Go remote","filter":{"skills":["Go"],"workplaceTypes":["Remote"]}}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.CreateSearch(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "testuser", gotUsername)
+	assert.Equal(t, []string{"Go"}, gotRequest.Filter.Skills)
+	assert.Equal(t, []string{"Remote"}, gotRequest.Filter.WorkplaceTypes)
+}
+
+func TestSavedSearchHandler_CreateSearch_Unauthenticated(t *testing.T) {
+	handler := NewSavedSearchHandler(&mockSavedSearchService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/me/searches", strings.NewReader(`{}`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateSearch(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestSavedSearchHandler_CreateSearch_UnknownField(t *testing.T) {
+	handler := NewSavedSearchHandler(&mockSavedSearchService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/me/searches", strings.NewReader(`{"name":"Go","query":"go"}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.CreateSearch(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSavedSearchHandler_CreateSearch_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		status int
+	}{
+		{&models.ValidationError{Fields: []models.FieldError{{Field: "name", Message: "is required"}}}, http.StatusUnprocessableEntity},
+		{&models.ForbiddenError{Reason: "FREE users may keep at most 3 saved searches"}, http.StatusForbidden},
+		{&models.ConflictError{Field: "name", Value: "Go"}, http.StatusConflict},
+		{errors.New("user not found"), http.StatusNotFound},
+		{errors.New("database error"), http.StatusInternalServerError},
+	} {
+		handler := NewSavedSearchHandler(&mockSavedSearchService{
+			createFunc: func(ctx context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+				return models.SavedSearch{}, tc.err
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/me/searches", strings.NewReader(`{"name":"Go"}`))
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.CreateSearch(rr, req)
+
+		assert.Equal(t, tc.status, rr.Code, tc.err.Error())
+	}
+}
+
+func TestSavedSearchHandler_GetSearch_InvalidID(t *testing.T) {
+	handler := NewSavedSearchHandler(&mockSavedSearchService{
+		getFunc: func(ctx context.Context, username, id string) (models.SavedSearch, error) {
+			return models.SavedSearch{}, errors.New("invalid saved search ID format")
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/searches/bad", nil)
+	req.SetPathValue("id", "bad")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetSearch(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSavedSearchHandler_DeleteSearch(t *testing.T) {
+	var gotID string
+	handler := NewSavedSearchHandler(&mockSavedSearchService{
+		deleteFunc: func(ctx context.Context, username, id string) error {
+			gotID = id
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/users/me/searches/68e462f868efefe99e226a8b", nil)
+	req.SetPathValue("id", "68e462f868efefe99e226a8b")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.DeleteSearch(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "68e462f868efefe99e226a8b", gotID)
+}
+
+func TestSavedSearchHandler_RunSearch(t *testing.T) {
+	handler := NewSavedSearchHandler(&mockSavedSearchService{
+		runFunc: func(ctx context.Context, username, id string) (models.SavedSearchResults, error) {
+			return models.SavedSearchResults{
+				NewCount: 1,
+				Jobs:     []models.SavedSearchJob{{Job: models.Job{ID: "a", Title: "Go Developer"}, New: true}},
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/searches/68e462f868efefe99e226a8b/results", nil)
+	req.SetPathValue("id", "68e462f868efefe99e226a8b")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.RunSearch(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"title":"Go Developer"`)
+	assert.Contains(t, rr.Body.String(), `"new":true`)
+	assert.Contains(t, rr.Body.String(), `"newCount":1`)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"jboard-go-crud/internal/models"
 	"jboard-go-crud/internal/services"
@@ -33,13 +34,8 @@ func (h *SkillHandler) GetAllSkills(w http.ResponseWriter, r *http.Request) {
 
 	skill, err := h.skillService.GetAllSkills(r.Context(), username)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			log.Printf("User not found in GetAllSkills for username: %s", username)
-			http.Error(w, err.Error(), http.StatusNotFound)
-		} else {
-			log.Printf("ERROR: Service error in GetAllSkills for username %s: %v", username, err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		log.Printf("Service error in GetAllSkills for username %s: %v", username, err)
+		writeServiceError(w, "skill request", err)
 		return
 	}
 
@@ -60,13 +56,8 @@ func (h *SkillHandler) AddSkill(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Controller AddSkill processing request for username: %s, skill: %s", skillRequest.Username, skillRequest.Skill)
 
 	if err := h.skillService.AddSkill(r.Context(), skillRequest); err != nil {
-		if strings.Contains(err.Error(), "required") {
-			log.Printf("Validation error in AddSkill: %v", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		} else {
-			log.Printf("ERROR: Service error in AddSkill for username %s: %v", skillRequest.Username, err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		log.Printf("Service error in AddSkill for username %s: %v", skillRequest.Username, err)
+		writeServiceError(w, "skill request", err)
 		return
 	}
 
@@ -87,13 +78,13 @@ func (h *SkillHandler) RemoveSkill(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Controller RemoveSkill processing request for username: %s, skill: %s", skillRequest.Username, skillRequest.Skill)
 
 	if err := h.skillService.RemoveSkill(r.Context(), skillRequest); err != nil {
-		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "not found") {
-			log.Printf("Validation or not found error in RemoveSkill: %v", err)
+		log.Printf("Service error in RemoveSkill for username %s: %v", skillRequest.Username, err)
+		// Removing from a missing user or skill has always been reported as a bad request.
+		if strings.Contains(err.Error(), "not found") {
 			http.Error(w, err.Error(), http.StatusBadRequest)
-		} else {
-			log.Printf("ERROR: Service error in RemoveSkill for username %s: %v", skillRequest.Username, err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
+		writeServiceError(w, "skill request", err)
 		return
 	}
 
@@ -114,16 +105,8 @@ func (h *SkillHandler) DeleteUserSkills(w http.ResponseWriter, r *http.Request)
 	log.Printf("Controller DeleteUserSkills processing request for username: %s", username)
 
 	if err := h.skillService.DeleteUserSkills(r.Context(), username); err != nil {
-		if strings.Contains(err.Error(), "user not found") {
-			log.Printf("User not found error in DeleteUserSkills: %v", err)
-			http.Error(w, err.Error(), http.StatusNotFound)
-		} else if strings.Contains(err.Error(), "required") {
-			log.Printf("Validation error in DeleteUserSkills: %v", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		} else {
-			log.Printf("ERROR: Service error in DeleteUserSkills for username %s: %v", username, err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		log.Printf("Service error in DeleteUserSkills for username %s: %v", username, err)
+		writeServiceError(w, "skill request", err)
 		return
 	}
 
@@ -131,3 +114,127 @@ func (h *SkillHandler) DeleteUserSkills(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "User skills deleted successfully"})
 }
+
+func (h *SkillHandler) GetCatalog(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller GetCatalog called")
+
+	skills, err := h.skillService.GetCatalog(r.Context(), r.URL.Query().Get("category"))
+	if err != nil {
+		writeServiceError(w, "skill catalog", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(skills)
+}
+
+func (h *SkillHandler) UpsertCatalogSkill(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller UpsertCatalogSkill called")
+
+	var skill models.CanonicalSkill
+	if err := json.NewDecoder(r.Body).Decode(&skill); err != nil {
+		log.Printf("ERROR: Invalid JSON in UpsertCatalogSkill request: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	saved, err := h.skillService.UpsertCatalogSkill(r.Context(), skill)
+	if err != nil {
+		writeServiceError(w, "skill catalog", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved)
+}
+
+func (h *SkillHandler) MergeSkills(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller MergeSkills called")
+
+	var request models.SkillMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Printf("ERROR: Invalid JSON in MergeSkills request: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.skillService.MergeSkills(r.Context(), request)
+	if err != nil {
+		writeServiceError(w, "skill catalog", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *SkillHandler) SuggestSkills(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller SuggestSkills called")
+
+	prefix := r.URL.Query().Get("prefix")
+	if strings.TrimSpace(prefix) == "" {
+		log.Printf("ERROR: Missing prefix query parameter in SuggestSkills")
+		http.Error(w, "prefix query parameter is required", http.StatusBadRequest)
+		return
+	}
+	limit, err := parseIntParam(r.URL.Query().Get("limit"))
+	if err != nil {
+		log.Printf("Invalid limit parameter in SuggestSkills: %v", err)
+		http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+
+	suggestions, err := h.skillService.SuggestSkills(r.Context(), prefix, limit)
+	if err != nil {
+		writeServiceError(w, "skill suggestions", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
+}
+
+func (h *SkillHandler) ReplaceUserSkills(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller ReplaceUserSkills called")
+	h.writeSkillSet(w, r, h.skillService.ReplaceUserSkills)
+}
+
+func (h *SkillHandler) AddUserSkillsBatch(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Controller AddUserSkillsBatch called")
+	h.writeSkillSet(w, r, h.skillService.AddUserSkills)
+}
+
+func (h *SkillHandler) writeSkillSet(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error)) {
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(r.PathValue("username"), username) {
+		log.Printf("User %s tried to change the skills of %s", username, r.PathValue("username"))
+		writeJSON(w, http.StatusForbidden, &models.ForbiddenError{Reason: "skills can only be changed on your own account"})
+		return
+	}
+
+	var request models.SkillSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Printf("ERROR: Invalid JSON in skill set request: %v", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if request.Skills == nil {
+		log.Printf("Validation error in skill set request: skills is required")
+		http.Error(w, "skills is required", http.StatusBadRequest)
+		return
+	}
+
+	changes, err := apply(r.Context(), username, request.Skills)
+	if err != nil {
+		log.Printf("Service error in skill set request for username %s: %v", username, err)
+		writeServiceError(w, "skill set request", err)
+		return
+	}
+
+	log.Printf("Successfully stored skill set for username: %s", username)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(changes)
+}
@@ -39,6 +39,36 @@ func (m *MockSkillService) DeleteUserSkills(ctx context.Context, username string
 	return args.Error(0)
 }
 
+func (m *MockSkillService) GetCatalog(ctx context.Context, category string) ([]models.CanonicalSkill, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).([]models.CanonicalSkill), args.Error(1)
+}
+
+func (m *MockSkillService) UpsertCatalogSkill(ctx context.Context, skill models.CanonicalSkill) (models.CanonicalSkill, error) {
+	args := m.Called(ctx, skill)
+	return args.Get(0).(models.CanonicalSkill), args.Error(1)
+}
+
+func (m *MockSkillService) MergeSkills(ctx context.Context, request models.SkillMergeRequest) (models.SkillMergeResult, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(models.SkillMergeResult), args.Error(1)
+}
+
+func (m *MockSkillService) SuggestSkills(ctx context.Context, prefix string, limit int) ([]models.SkillSuggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]models.SkillSuggestion), args.Error(1)
+}
+
+func (m *MockSkillService) ReplaceUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error) {
+	args := m.Called(ctx, username, skills)
+	return args.Get(0).(models.SkillSetChanges), args.Error(1)
+}
+
+func (m *MockSkillService) AddUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error) {
+	args := m.Called(ctx, username, skills)
+	return args.Get(0).(models.SkillSetChanges), args.Error(1)
+}
+
 func TestSkillHandler_GetAllSkills_Success(t *testing.T) {
 	mockService := new(MockSkillService)
 	handler := NewSkillHandler(mockService)
@@ -47,7 +77,7 @@ func TestSkillHandler_GetAllSkills_Success(t *testing.T) {
 	expectedSkill := models.Skill{
 		ID:       testID,
 		Username: "testuser",
-		Skills:   []string{"java", "python"},
+		Skills:   []models.SkillEntry{{Name: "java"}, {Name: "python"}},
 	}
 
 	mockService.On("GetAllSkills", mock.Anything, "testuser").Return(expectedSkill, nil)
@@ -359,3 +389,263 @@ func TestSkillHandler_RemoveSkill_InternalError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestSkillHandler_AddSkill_InvalidDepth(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	skillRequest := models.SkillRequest{
+		Username:    "testuser",
+		Skill:       "java",
+		Proficiency: "GURU",
+	}
+
+	mockService.On("AddSkill", mock.Anything, skillRequest).Return(errors.New("invalid skill: proficiency must be one of BEGINNER, INTERMEDIATE, ADVANCED, EXPERT"))
+
+	body, _ := json.Marshal(skillRequest)
+	req, _ := http.NewRequest("POST", "/v1/skills", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.AddSkill(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillHandler_GetCatalog_Success(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	catalog := []models.CanonicalSkill{{Key: "go", Name: "Go", Aliases: []string{"go", "golang"}}}
+	mockService.On("GetCatalog", mock.Anything, "language").Return(catalog, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/skills/catalog?category=language", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetCatalog(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response []models.CanonicalSkill
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, catalog, response)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillHandler_GetCatalog_InvalidCategory(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	mockService.On("GetCatalog", mock.Anything, "TOOLS").Return([]models.CanonicalSkill(nil), errors.New("invalid category: must be one of LANGUAGE, FRAMEWORK, CLOUD, DATABASE"))
+
+	req, _ := http.NewRequest("GET", "/v1/skills/catalog?category=TOOLS", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetCatalog(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSkillHandler_UpsertCatalogSkill_Conflict(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	skill := models.CanonicalSkill{Name: "Golang", Category: "LANGUAGE"}
+	mockService.On("UpsertCatalogSkill", mock.Anything, skill).Return(models.CanonicalSkill{}, &models.ConflictError{Field: "alias", Value: "golang"})
+
+	body, _ := json.Marshal(skill)
+	req, _ := http.NewRequest("PUT", "/v1/admin/skills/catalog", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	handler.UpsertCatalogSkill(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSkillHandler_MergeSkills_Success(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	request := models.SkillMergeRequest{Sources: []string{"golang", "go lang"}, Target: "Go"}
+	result := models.SkillMergeResult{Target: models.CanonicalSkill{Key: "go", Name: "Go"}, UsersUpdated: 3}
+	mockService.On("MergeSkills", mock.Anything, request).Return(result, nil)
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/v1/admin/skills/merge", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	handler.MergeSkills(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response models.SkillMergeResult
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.UsersUpdated)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillHandler_MergeSkills_TargetNotFound(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	request := models.SkillMergeRequest{Sources: []string{"golang"}, Target: "Gopher"}
+	mockService.On("MergeSkills", mock.Anything, request).Return(models.SkillMergeResult{}, errors.New("canonical skill not found"))
+
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest("POST", "/v1/admin/skills/merge", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	handler.MergeSkills(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestSkillHandler_SuggestSkills_Success(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	suggestions := []models.SkillSuggestion{{Name: "Go", Category: "LANGUAGE", Users: 12, Jobs: 4}}
+	mockService.On("SuggestSkills", mock.Anything, "gol", 5).Return(suggestions, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/skills/suggest?prefix=gol&limit=5", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SuggestSkills(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response []models.SkillSuggestion
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, suggestions, response)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillHandler_SuggestSkills_MissingPrefix(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	req, _ := http.NewRequest("GET", "/v1/skills/suggest", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SuggestSkills(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "SuggestSkills", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSkillHandler_SuggestSkills_InvalidLimit(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	req, _ := http.NewRequest("GET", "/v1/skills/suggest?prefix=go&limit=ten", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SuggestSkills(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSkillHandler_ReplaceUserSkills_Success(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	skills := []models.SkillEntry{{Name: "Go", Proficiency: "EXPERT"}, {Name: "java"}}
+	changes := models.SkillSetChanges{Added: []string{"Go"}, Removed: []string{"python"}, Updated: []string{}, Skills: skills}
+	mockService.On("ReplaceUserSkills", mock.Anything, "testuser", skills).Return(changes, nil)
+
+	body, _ := json.Marshal(models.SkillSetRequest{Skills: skills})
+	req, _ := http.NewRequest("PUT", "/v1/skills/testuser", bytes.NewBuffer(body))
+	req.SetPathValue("username", "testuser")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ReplaceUserSkills(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response models.SkillSetChanges
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, changes, response)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillHandler_ReplaceUserSkills_OtherUser(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	req, _ := http.NewRequest("PUT", "/v1/skills/testuser", bytes.NewBufferString(`{"skills":[]}`))
+	req.SetPathValue("username", "testuser")
+	req.Header.Set("X-Username", "otheruser")
+	rr := httptest.NewRecorder()
+
+	handler.ReplaceUserSkills(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	mockService.AssertNotCalled(t, "ReplaceUserSkills", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSkillHandler_AddUserSkillsBatch_Unauthenticated(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	req, _ := http.NewRequest("POST", "/v1/skills/testuser/batch", bytes.NewBufferString(`{"skills":[]}`))
+	req.SetPathValue("username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.AddUserSkillsBatch(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockService.AssertNotCalled(t, "AddUserSkills", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSkillHandler_ReplaceUserSkills_MissingSkills(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	req, _ := http.NewRequest("PUT", "/v1/skills/testuser", bytes.NewBufferString(`{}`))
+	req.SetPathValue("username", "testuser")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ReplaceUserSkills(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSkillHandler_AddUserSkillsBatch_ValidationError(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	skills := []models.SkillEntry{{Name: "go", Proficiency: "GURU"}}
+	mockService.On("AddUserSkills", mock.Anything, "testuser", skills).Return(models.SkillSetChanges{}, &models.ValidationError{Fields: []models.FieldError{
+		{Field: "skills[0].proficiency", Message: "must be one of BEGINNER, INTERMEDIATE, ADVANCED, EXPERT"},
+	}})
+
+	body, _ := json.Marshal(models.SkillSetRequest{Skills: skills})
+	req, _ := http.NewRequest("POST", "/v1/skills/testuser/batch", bytes.NewBuffer(body))
+	req.SetPathValue("username", "testuser")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.AddUserSkillsBatch(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	var response models.ValidationError
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "skills[0].proficiency", response.Fields[0].Field)
+}
+
+func TestSkillHandler_AddUserSkillsBatch_Conflict(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := NewSkillHandler(mockService)
+
+	skills := []models.SkillEntry{{Name: "go"}}
+	mockService.On("AddUserSkills", mock.Anything, "testuser", skills).Return(models.SkillSetChanges{}, &models.ConflictError{Field: "skills", Value: "testuser"})
+
+	body, _ := json.Marshal(models.SkillSetRequest{Skills: skills})
+	req, _ := http.NewRequest("POST", "/v1/skills/testuser/batch", bytes.NewBuffer(body))
+	req.SetPathValue("username", "testuser")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.AddUserSkillsBatch(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
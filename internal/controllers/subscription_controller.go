@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/services"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const paymentSignatureHeader = "X-Webhook-Signature"
+
+type SubscriptionHandler struct {
+	subscriptionService services.SubscriptionService
+	webhookSecret       []byte
+}
+
+func NewSubscriptionHandler(subscriptionService services.SubscriptionService, webhookSecret string) *SubscriptionHandler {
+	log.Printf("Creating new SubscriptionHandler")
+	if webhookSecret == "" {
+		log.Printf("WARNING: payment webhook secret not set, webhook requests will be rejected")
+	}
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+		webhookSecret:       []byte(webhookSecret),
+	}
+}
+
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler GetSubscription called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.subscriptionService.GetSubscription(r.Context(), username)
+	if err != nil {
+		log.Printf("Service error in GetSubscription: %v", err)
+		writeServiceError(w, "subscription request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *SubscriptionHandler) CancelSubscription(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler CancelSubscription called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.subscriptionService.CancelSubscription(r.Context(), username)
+	if err != nil {
+		log.Printf("Service error in CancelSubscription: %v", err)
+		writeServiceError(w, "subscription request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *SubscriptionHandler) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler PaymentWebhook called")
+
+	if len(h.webhookSecret) == 0 {
+		http.Error(w, "Payment webhook is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		log.Printf("Failed to read webhook body: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !h.validSignature(body, r.Header.Get(paymentSignatureHeader)) {
+		log.Printf("Rejected payment webhook with invalid signature")
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event models.PaymentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("Failed to decode payment event: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.subscriptionService.HandlePaymentEvent(r.Context(), event); err != nil {
+		log.Printf("Service error in PaymentWebhook: %v", err)
+		if strings.Contains(err.Error(), "unsupported event type") {
+			// Acknowledge so the provider does not keep retrying events we do not handle.
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		writeServiceError(w, "subscription request", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validSignature checks a "sha256=<hex>" HMAC of the raw body against the shared secret.
+func (h *SubscriptionHandler) validSignature(body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.webhookSecret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
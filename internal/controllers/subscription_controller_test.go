@@ -0,0 +1,258 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "test-secret"
+
+type mockSubscriptionService struct {
+	getSubscriptionFunc    func(ctx context.Context, username string) (models.Subscription, error)
+	cancelSubscriptionFunc func(ctx context.Context, username string) (models.Subscription, error)
+	handlePaymentEventFunc func(ctx context.Context, event models.PaymentEvent) error
+	downgradeExpiredFunc   func(ctx context.Context) (int, error)
+}
+
+func (m *mockSubscriptionService) GetSubscription(ctx context.Context, username string) (models.Subscription, error) {
+	return m.getSubscriptionFunc(ctx, username)
+}
+
+func (m *mockSubscriptionService) CancelSubscription(ctx context.Context, username string) (models.Subscription, error) {
+	return m.cancelSubscriptionFunc(ctx, username)
+}
+
+func (m *mockSubscriptionService) HandlePaymentEvent(ctx context.Context, event models.PaymentEvent) error {
+	return m.handlePaymentEventFunc(ctx, event)
+}
+
+func (m *mockSubscriptionService) DowngradeExpired(ctx context.Context) (int, error) {
+	return m.downgradeExpiredFunc(ctx)
+}
+
+// fakePaymentProvider signs events the same way the real provider does.
+type fakePaymentProvider struct {
+	secret string
+}
+
+func (p fakePaymentProvider) request(t *testing.T, event models.PaymentEvent) *http.Request {
+	body, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/payments", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(paymentSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestSubscriptionHandler_GetSubscription_Success(t *testing.T) {
+	mockService := &mockSubscriptionService{
+		getSubscriptionFunc: func(ctx context.Context, username string) (models.Subscription, error) {
+			return models.Subscription{Plan: enums.Premium, Status: enums.Active}, nil
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/subscriptions", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetSubscription(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response models.Subscription
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if response.Status != enums.Active {
+		t.Errorf("Expected status ACTIVE, got %s", response.Status)
+	}
+}
+
+func TestSubscriptionHandler_GetSubscription_Unauthenticated(t *testing.T) {
+	handler := NewSubscriptionHandler(&mockSubscriptionService{}, testWebhookSecret)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/subscriptions?username=testuser", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetSubscription(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_GetSubscription_NotFound(t *testing.T) {
+	mockService := &mockSubscriptionService{
+		getSubscriptionFunc: func(ctx context.Context, username string) (models.Subscription, error) {
+			return models.Subscription{}, errors.New("subscription not found")
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/subscriptions", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetSubscription(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_CancelSubscription_Success(t *testing.T) {
+	var cancelled string
+	mockService := &mockSubscriptionService{
+		cancelSubscriptionFunc: func(ctx context.Context, username string) (models.Subscription, error) {
+			cancelled = username
+			return models.Subscription{Status: enums.Cancelled}, nil
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/subscriptions?username=victim", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.CancelSubscription(rr, req)
+
+	if cancelled != "testuser" {
+		t.Errorf("Expected the authenticated user's subscription to be cancelled, got %q", cancelled)
+	}
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_PaymentWebhook_Success(t *testing.T) {
+	var received models.PaymentEvent
+	mockService := &mockSubscriptionService{
+		handlePaymentEventFunc: func(ctx context.Context, event models.PaymentEvent) error {
+			received = event
+			return nil
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+	provider := fakePaymentProvider{secret: testWebhookSecret}
+
+	req := provider.request(t, models.PaymentEvent{
+		ID:        "evt_1",
+		Type:      models.PaymentEventInvoicePaid,
+		Username:  "testuser",
+		PeriodEnd: time.Now().Add(30 * 24 * time.Hour),
+	})
+	rr := httptest.NewRecorder()
+
+	handler.PaymentWebhook(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+	if received.ID != "evt_1" || received.Username != "testuser" {
+		t.Errorf("Expected event to be passed to service, got %+v", received)
+	}
+}
+
+func TestSubscriptionHandler_PaymentWebhook_InvalidSignature(t *testing.T) {
+	mockService := &mockSubscriptionService{
+		handlePaymentEventFunc: func(ctx context.Context, event models.PaymentEvent) error {
+			t.Error("Expected service not to be called")
+			return nil
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+	provider := fakePaymentProvider{secret: "wrong-secret"}
+
+	req := provider.request(t, models.PaymentEvent{ID: "evt_1", Type: models.PaymentEventInvoicePaid, Username: "testuser"})
+	rr := httptest.NewRecorder()
+
+	handler.PaymentWebhook(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_PaymentWebhook_MissingSignature(t *testing.T) {
+	handler := NewSubscriptionHandler(&mockSubscriptionService{}, testWebhookSecret)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/payments", bytes.NewBufferString(`{"id":"evt_1"}`))
+	rr := httptest.NewRecorder()
+
+	handler.PaymentWebhook(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_PaymentWebhook_NotConfigured(t *testing.T) {
+	handler := NewSubscriptionHandler(&mockSubscriptionService{}, "")
+	provider := fakePaymentProvider{secret: ""}
+
+	req := provider.request(t, models.PaymentEvent{ID: "evt_1"})
+	rr := httptest.NewRecorder()
+
+	handler.PaymentWebhook(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_PaymentWebhook_UnsupportedEventAcknowledged(t *testing.T) {
+	mockService := &mockSubscriptionService{
+		handlePaymentEventFunc: func(ctx context.Context, event models.PaymentEvent) error {
+			return errors.New("unsupported event type")
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+	provider := fakePaymentProvider{secret: testWebhookSecret}
+
+	req := provider.request(t, models.PaymentEvent{ID: "evt_1", Type: "customer.updated", Username: "testuser"})
+	rr := httptest.NewRecorder()
+
+	handler.PaymentWebhook(rr, req)
+
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("Expected status %d, got %d", http.StatusAccepted, rr.Code)
+	}
+}
+
+func TestSubscriptionHandler_PaymentWebhook_UserNotFound(t *testing.T) {
+	mockService := &mockSubscriptionService{
+		handlePaymentEventFunc: func(ctx context.Context, event models.PaymentEvent) error {
+			return errors.New("user not found")
+		},
+	}
+	handler := NewSubscriptionHandler(mockService, testWebhookSecret)
+	provider := fakePaymentProvider{secret: testWebhookSecret}
+
+	req := provider.request(t, models.PaymentEvent{ID: "evt_1", Type: models.PaymentEventInvoicePaid, Username: "ghost"})
+	rr := httptest.NewRecorder()
+
+	handler.PaymentWebhook(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
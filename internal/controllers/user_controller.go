@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"jboard-go-crud/internal/models"
 	"jboard-go-crud/internal/models/enums"
 	"jboard-go-crud/internal/services"
 	"log"
@@ -32,6 +34,11 @@ type UpdateUserRequest struct {
 	Role     string `json:"role"`
 }
 
+type RenameUserRequest struct {
+	Username    string `json:"username"`
+	NewUsername string `json:"newUsername"`
+}
+
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Handler CreateUser called")
 
@@ -52,15 +59,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	err := h.userService.CreateUser(r.Context(), req.Username, req.Password, role)
 	if err != nil {
 		log.Printf("Service error in CreateUser: %v", err)
-		if strings.Contains(err.Error(), "already exists") {
-			http.Error(w, err.Error(), http.StatusConflict)
-			return
-		}
-		if strings.Contains(err.Error(), "cannot be empty") || strings.Contains(err.Error(), "invalid") {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeServiceError(w, "user request", err)
 		return
 	}
 
@@ -70,6 +69,11 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Handler GetUser called")
 
+	caller, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
 	id := r.URL.Query().Get("id")
 	if id == "" {
 		log.Printf("ID query parameter is missing")
@@ -80,20 +84,12 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	user, err := h.userService.GetUserByID(r.Context(), id)
 	if err != nil {
 		log.Printf("Service error in GetUser: %v", err)
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "User not found", http.StatusNotFound)
-			return
-		}
-		if strings.Contains(err.Error(), "cannot be empty") {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeServiceError(w, "user request", err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(user); err != nil {
+	if err := json.NewEncoder(w).Encode(userView(user, caller)); err != nil {
 		log.Printf("Failed to encode response: %v", err)
 	}
 }
@@ -101,6 +97,11 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Handler GetUserByUsername called")
 
+	caller, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
 	username := r.URL.Query().Get("username")
 	if username == "" {
 		log.Printf("Username query parameter is missing")
@@ -111,24 +112,29 @@ func (h *UserHandler) GetUserByUsername(w http.ResponseWriter, r *http.Request)
 	user, err := h.userService.GetUserByUsername(r.Context(), username)
 	if err != nil {
 		log.Printf("Service error in GetUserByUsername: %v", err)
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "User not found", http.StatusNotFound)
-			return
-		}
-		if strings.Contains(err.Error(), "cannot be empty") {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeServiceError(w, "user request", err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(user); err != nil {
+	if err := json.NewEncoder(w).Encode(userView(user, caller)); err != nil {
 		log.Printf("Failed to encode response: %v", err)
 	}
 }
 
+// userView returns what caller may see of user. The password never leaves the API, and the
+// email, profile and subscription are only shown to their owner.
+func userView(user models.User, caller string) models.User {
+	user.Password = ""
+	if !strings.EqualFold(user.Username, caller) {
+		user.Email = ""
+		user.EmailVerified = false
+		user.Profile = nil
+		user.Subscription = nil
+	}
+	return user
+}
+
 func (h *UserHandler) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 	if username := r.URL.Query().Get("username"); username != "" {
 		h.GetUserByUsername(w, r)
@@ -147,12 +153,23 @@ func (h *UserHandler) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Handler UpdateUser called")
 
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
 	var req UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("Failed to decode update user request: %v", err)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	// The body username is optional and only accepted when it names the caller.
+	if req.Username != "" && !strings.EqualFold(req.Username, username) {
+		log.Printf("User %s tried to update user %s", username, req.Username)
+		http.Error(w, "Users may only update their own account", http.StatusForbidden)
+		return
+	}
 
 	role := enums.RoleEnum(req.Role)
 	if !role.IsValid() {
@@ -161,22 +178,10 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userService.UpdateUser(r.Context(), req.Username, req.Password, role)
+	user, err := h.userService.UpdateUser(r.Context(), username, req.Password, role)
 	if err != nil {
 		log.Printf("Service error in UpdateUser: %v", err)
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "User not found", http.StatusNotFound)
-			return
-		}
-		if strings.Contains(err.Error(), "already exists") {
-			http.Error(w, err.Error(), http.StatusConflict)
-			return
-		}
-		if strings.Contains(err.Error(), "cannot be empty") || strings.Contains(err.Error(), "invalid") {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeServiceError(w, "user request", err)
 		return
 	}
 
@@ -189,27 +194,242 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Handler DeleteUser called")
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		log.Printf("Username query parameter is missing")
-		http.Error(w, "Username query parameter is required", http.StatusBadRequest)
+	username, ok := requireUsername(w, r)
+	if !ok {
 		return
 	}
+	// The query username is optional and only accepted when it names the caller.
+	if target := r.URL.Query().Get("username"); target != "" && !strings.EqualFold(target, username) {
+		log.Printf("User %s tried to delete user %s", username, target)
+		http.Error(w, "Users may only delete their own account", http.StatusForbidden)
+		return
+	}
+
+	h.deleteUser(w, r, username)
+}
+
+// AdminDeleteUser deletes any account, including everything it owns.
+func (h *UserHandler) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler AdminDeleteUser called")
 
+	h.deleteUser(w, r, r.PathValue("username"))
+}
+
+func (h *UserHandler) deleteUser(w http.ResponseWriter, r *http.Request, username string) {
 	err := h.userService.DeleteUser(r.Context(), username)
 	if err != nil {
 		log.Printf("Service error in DeleteUser: %v", err)
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "User not found", http.StatusNotFound)
-			return
-		}
-		if strings.Contains(err.Error(), "cannot be empty") {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeServiceError(w, "user request", err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *UserHandler) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler ExportUserData called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	export, err := h.userService.ExportUserData(r.Context(), username)
+	if err != nil {
+		log.Printf("Service error in ExportUserData: %v", err)
+		writeServiceError(w, "user export", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="user-data.json"`)
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *UserHandler) RenameUser(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler RenameUser called")
+
+	username, ok := requireUsername(w, r)
+	if !ok {
+		return
+	}
+
+	var req RenameUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Failed to decode rename user request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Username != "" && !strings.EqualFold(req.Username, username) {
+		log.Printf("User %s tried to rename user %s", username, req.Username)
+		http.Error(w, "Users may only rename their own account", http.StatusForbidden)
+		return
+	}
+
+	user, err := h.userService.RenameUser(r.Context(), username, req.NewUsername)
+	if err != nil {
+		log.Printf("Service error in RenameUser: %v", err)
+		writeServiceError(w, "user rename", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler ListUsers called")
+
+	filter, err := parseUserFilter(r)
+	if err != nil {
+		log.Printf("Invalid user listing query: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.userService.ListUsers(r.Context(), filter)
+	if err != nil {
+		log.Printf("Service error in ListUsers: %v", err)
+		writeServiceError(w, "user listing", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func parseUserFilter(r *http.Request) (models.UserFilter, error) {
+	query := r.URL.Query()
+	filter := models.UserFilter{
+		Role:           enums.RoleEnum(strings.ToUpper(query.Get("role"))),
+		UsernamePrefix: query.Get("usernamePrefix"),
+	}
+
+	var err error
+	if filter.Page, err = parseIntParam(query.Get("page")); err != nil {
+		return models.UserFilter{}, errors.New("invalid page parameter")
+	}
+	if filter.PageSize, err = parseIntParam(query.Get("pageSize")); err != nil {
+		return models.UserFilter{}, errors.New("invalid pageSize parameter")
+	}
+	if filter.CreatedFrom, err = parseDateParam(query.Get("createdFrom")); err != nil {
+		return models.UserFilter{}, errors.New("invalid createdFrom parameter, use RFC 3339 or YYYY-MM-DD")
+	}
+	if filter.CreatedTo, err = parseDateParam(query.Get("createdTo")); err != nil {
+		return models.UserFilter{}, errors.New("invalid createdTo parameter, use RFC 3339 or YYYY-MM-DD")
+	}
+	return filter, nil
+}
+
+func (h *UserHandler) GetMyProfile(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler GetMyProfile called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	profile, err := h.userService.GetProfile(r.Context(), username)
+	if err != nil {
+		log.Printf("Service error in GetProfile: %v", err)
+		writeServiceError(w, "profile request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profile); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (h *UserHandler) PatchMyProfile(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler PatchMyProfile called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var patch models.UserProfilePatch
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		log.Printf("Failed to decode profile patch: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.userService.UpdateProfile(r.Context(), username, patch)
+	if err != nil {
+		log.Printf("Service error in UpdateProfile: %v", err)
+		writeServiceError(w, "profile request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profile); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// PatchUser applies a JSON Merge Patch to the authenticated user's own account.
+func (h *UserHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler PatchUser called")
+
+	username := authenticatedUsername(r)
+	if username == "" {
+		log.Printf("Authenticated username header is missing")
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	h.patchUser(w, r, models.UserPatchActor{Username: username})
+}
+
+// AdminPatchUser applies a JSON Merge Patch to any account, including its role.
+func (h *UserHandler) AdminPatchUser(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Handler AdminPatchUser called")
+
+	h.patchUser(w, r, models.UserPatchActor{Username: authenticatedUsername(r), Admin: true})
+}
+
+func (h *UserHandler) patchUser(w http.ResponseWriter, r *http.Request, actor models.UserPatchActor) {
+	contentType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+	if contentType != "application/merge-patch+json" && contentType != "application/json" {
+		log.Printf("Unsupported content type for patch: %s", contentType)
+		w.Header().Set("Accept-Patch", "application/merge-patch+json")
+		http.Error(w, "Content-Type must be application/merge-patch+json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var patch map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		log.Printf("Failed to decode user patch: %v", err)
+		http.Error(w, "Invalid request body, expected a JSON object", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.PatchUser(r.Context(), actor, r.PathValue("id"), patch)
+	if err != nil {
+		log.Printf("Service error in PatchUser: %v", err)
+		writeServiceError(w, "user patch", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
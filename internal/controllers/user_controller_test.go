@@ -19,6 +19,12 @@ type mockUserService struct {
 	getUserByUsernameFunc func(ctx context.Context, username string) (models.User, error)
 	updateUserFunc        func(ctx context.Context, username string, password string, role enums.RoleEnum) (models.User, error)
 	deleteUserFunc        func(ctx context.Context, username string) error
+	exportUserDataFunc    func(ctx context.Context, username string) (models.UserDataExport, error)
+	renameUserFunc        func(ctx context.Context, username, newUsername string) (models.User, error)
+	listUsersFunc         func(ctx context.Context, filter models.UserFilter) (models.UserPage, error)
+	getProfileFunc        func(ctx context.Context, username string) (models.UserProfile, error)
+	updateProfileFunc     func(ctx context.Context, username string, patch models.UserProfilePatch) (models.UserProfile, error)
+	patchUserFunc         func(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error)
 }
 
 func (m *mockUserService) CreateUser(ctx context.Context, username, password string, role enums.RoleEnum) error {
@@ -41,6 +47,30 @@ func (m *mockUserService) DeleteUser(ctx context.Context, username string) error
 	return m.deleteUserFunc(ctx, username)
 }
 
+func (m *mockUserService) ExportUserData(ctx context.Context, username string) (models.UserDataExport, error) {
+	return m.exportUserDataFunc(ctx, username)
+}
+
+func (m *mockUserService) RenameUser(ctx context.Context, username, newUsername string) (models.User, error) {
+	return m.renameUserFunc(ctx, username, newUsername)
+}
+
+func (m *mockUserService) ListUsers(ctx context.Context, filter models.UserFilter) (models.UserPage, error) {
+	return m.listUsersFunc(ctx, filter)
+}
+
+func (m *mockUserService) GetProfile(ctx context.Context, username string) (models.UserProfile, error) {
+	return m.getProfileFunc(ctx, username)
+}
+
+func (m *mockUserService) UpdateProfile(ctx context.Context, username string, patch models.UserProfilePatch) (models.UserProfile, error) {
+	return m.updateProfileFunc(ctx, username, patch)
+}
+
+func (m *mockUserService) PatchUser(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error) {
+	return m.patchUserFunc(ctx, actor, id, patch)
+}
+
 func TestNewUserHandler(t *testing.T) {
 	mockService := &mockUserService{}
 	handler := NewUserHandler(mockService)
@@ -118,7 +148,7 @@ func TestUserHandler_CreateUser_InvalidRole(t *testing.T) {
 func TestUserHandler_CreateUser_UserAlreadyExists(t *testing.T) {
 	mockService := &mockUserService{
 		createUserFunc: func(ctx context.Context, username, password string, role enums.RoleEnum) error {
-			return errors.New("user already exists")
+			return &models.ConflictError{Field: "username", Value: username}
 		},
 	}
 
@@ -214,6 +244,7 @@ func TestUserHandler_GetUser_Success(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?id="+testID.Hex(), nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUser(rr, req)
@@ -231,6 +262,28 @@ func TestUserHandler_GetUser_Success(t *testing.T) {
 	if responseUser.ID != expectedUser.ID {
 		t.Errorf("Expected user ID %s, got %s", expectedUser.ID.Hex(), responseUser.ID.Hex())
 	}
+	if responseUser.Password != "" {
+		t.Error("Expected password to be omitted from the response")
+	}
+}
+
+func TestUserHandler_GetUser_MissingCaller(t *testing.T) {
+	mockService := &mockUserService{
+		getUserByIDFunc: func(ctx context.Context, id string) (models.User, error) {
+			t.Error("Expected GetUserByID not to be called without a caller")
+			return models.User{}, nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/users?id=test-id", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetUser(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
 }
 
 func TestUserHandler_GetUser_MissingIDParameter(t *testing.T) {
@@ -238,6 +291,7 @@ func TestUserHandler_GetUser_MissingIDParameter(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUser(rr, req)
@@ -257,6 +311,7 @@ func TestUserHandler_GetUser_UserNotFound(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?id=nonexistent", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUser(rr, req)
@@ -276,6 +331,7 @@ func TestUserHandler_GetUser_EmptyIDValidationError(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?id=test-id", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUser(rr, req)
@@ -295,6 +351,7 @@ func TestUserHandler_GetUser_InternalServerError(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?id=test-id", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUser(rr, req)
@@ -322,6 +379,7 @@ func TestUserHandler_GetUserByUsername_Success(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?username=testuser", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUserByUsername(rr, req)
@@ -341,11 +399,75 @@ func TestUserHandler_GetUserByUsername_Success(t *testing.T) {
 	}
 }
 
+func TestUserHandler_GetUserByUsername_OwnerSeesPrivateFields(t *testing.T) {
+	mockService := &mockUserService{
+		getUserByUsernameFunc: func(ctx context.Context, username string) (models.User, error) {
+			return models.User{Username: "testuser", Password: "secret", Email: "test@example.com", Profile: &models.UserProfile{}}, nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/users?username=testuser", nil)
+	req.Header.Set("X-Username", "TestUser")
+	rr := httptest.NewRecorder()
+
+	handler.GetUserByUsername(rr, req)
+
+	var responseUser models.User
+	if err := json.Unmarshal(rr.Body.Bytes(), &responseUser); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if responseUser.Password != "" {
+		t.Error("Expected password to be omitted from the response")
+	}
+	if responseUser.Email != "test@example.com" || responseUser.Profile == nil {
+		t.Errorf("Expected the owner to see email and profile, got %+v", responseUser)
+	}
+}
+
+func TestUserHandler_GetUserByUsername_OtherUserSeesPublicFields(t *testing.T) {
+	mockService := &mockUserService{
+		getUserByUsernameFunc: func(ctx context.Context, username string) (models.User, error) {
+			return models.User{
+				Username:      "otheruser",
+				Password:      "secret",
+				Role:          enums.Premium,
+				Email:         "other@example.com",
+				EmailVerified: true,
+				Profile:       &models.UserProfile{},
+				Subscription:  &models.Subscription{},
+			}, nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/users?username=otheruser", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetUserByUsername(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	var responseUser models.User
+	if err := json.Unmarshal(rr.Body.Bytes(), &responseUser); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if responseUser.Username != "otheruser" || responseUser.Role != enums.Premium {
+		t.Errorf("Expected username and role to stay public, got %+v", responseUser)
+	}
+	if responseUser.Password != "" || responseUser.Email != "" || responseUser.EmailVerified || responseUser.Profile != nil || responseUser.Subscription != nil {
+		t.Errorf("Expected private fields to be hidden from other users, got %+v", responseUser)
+	}
+}
+
 func TestUserHandler_GetUserByUsername_MissingUsernameParameter(t *testing.T) {
 	mockService := &mockUserService{}
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUserByUsername(rr, req)
@@ -365,6 +487,7 @@ func TestUserHandler_GetUserByUsername_UserNotFound(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?username=nonexistent", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUserByUsername(rr, req)
@@ -392,6 +515,7 @@ func TestUserHandler_GetUserHandler_WithUsernameParameter(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?username=testuser", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUserHandler(rr, req)
@@ -419,6 +543,7 @@ func TestUserHandler_GetUserHandler_WithIDParameter(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?id="+testID.Hex(), nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUserHandler(rr, req)
@@ -433,6 +558,7 @@ func TestUserHandler_GetUserHandler_MissingBothParameters(t *testing.T) {
 	handler := NewUserHandler(mockService)
 
 	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.GetUserHandler(rr, req)
@@ -467,6 +593,7 @@ func TestUserHandler_UpdateUser_Success(t *testing.T) {
 	reqJSON, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPut, "/users", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Username", "testuser")
 
 	rr := httptest.NewRecorder()
 
@@ -563,15 +690,18 @@ func TestUserHandler_UpdateUser_Success(t *testing.T) {
 // }
 
 func TestUserHandler_DeleteUser_Success(t *testing.T) {
+	var deleted string
 	mockService := &mockUserService{
 		deleteUserFunc: func(ctx context.Context, username string) error {
+			deleted = username
 			return nil
 		},
 	}
 
 	handler := NewUserHandler(mockService)
 
-	req := httptest.NewRequest(http.MethodDelete, "/users?username=testuser", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/users", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.DeleteUser(rr, req)
@@ -579,23 +709,47 @@ func TestUserHandler_DeleteUser_Success(t *testing.T) {
 	if rr.Code != http.StatusNoContent {
 		t.Errorf("Expected status %d, got %d", http.StatusNoContent, rr.Code)
 	}
+	if deleted != "testuser" {
+		t.Errorf("Expected the caller to be deleted, got %q", deleted)
+	}
 }
 
-func TestUserHandler_DeleteUser_MissingUsernameParameter(t *testing.T) {
+func TestUserHandler_DeleteUser_MissingCaller(t *testing.T) {
 	mockService := &mockUserService{
 		deleteUserFunc: func(ctx context.Context, username string) error {
-			return nil // This won't be called since the handler should return before calling the service
+			t.Error("Expected DeleteUser not to be called without a caller")
+			return nil
 		},
 	}
 	handler := NewUserHandler(mockService)
 
-	req := httptest.NewRequest(http.MethodDelete, "/users", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/users?username=testuser", nil)
 	rr := httptest.NewRecorder()
 
 	handler.DeleteUser(rr, req)
 
-	if rr.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestUserHandler_DeleteUser_OtherUserForbidden(t *testing.T) {
+	mockService := &mockUserService{
+		deleteUserFunc: func(ctx context.Context, username string) error {
+			t.Error("Expected DeleteUser not to be called for another user")
+			return nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/users?username=victim", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.DeleteUser(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, rr.Code)
 	}
 }
 
@@ -608,7 +762,8 @@ func TestUserHandler_DeleteUser_UserNotFound(t *testing.T) {
 
 	handler := NewUserHandler(mockService)
 
-	req := httptest.NewRequest(http.MethodDelete, "/users?username=nonexistent", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/users?username=TestUser", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.DeleteUser(rr, req)
@@ -627,7 +782,8 @@ func TestUserHandler_DeleteUser_InternalServerError(t *testing.T) {
 
 	handler := NewUserHandler(mockService)
 
-	req := httptest.NewRequest(http.MethodDelete, "/users?username=testuser", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/users", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.DeleteUser(rr, req)
@@ -636,3 +792,574 @@ func TestUserHandler_DeleteUser_InternalServerError(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
 	}
 }
+
+func TestUserHandler_ExportUserData_Success(t *testing.T) {
+	mockService := &mockUserService{
+		exportUserDataFunc: func(ctx context.Context, username string) (models.UserDataExport, error) {
+			return models.UserDataExport{
+				User: models.User{Username: username},
+				Data: map[string]any{"skills": []string{"go"}},
+			}, nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/export", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ExportUserData(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response models.UserDataExport
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if response.User.Username != "testuser" {
+		t.Errorf("Expected username 'testuser', got %s", response.User.Username)
+	}
+}
+
+func TestUserHandler_ExportUserData_Unauthenticated(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/export", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportUserData(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestUserHandler_ExportUserData_UserNotFound(t *testing.T) {
+	mockService := &mockUserService{
+		exportUserDataFunc: func(ctx context.Context, username string) (models.UserDataExport, error) {
+			return models.UserDataExport{}, errors.New("user not found")
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/export", nil)
+	req.Header.Set("X-Username", "ghost")
+	rr := httptest.NewRecorder()
+
+	handler.ExportUserData(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestUserHandler_UpdateUser_Conflict(t *testing.T) {
+	mockService := &mockUserService{
+		updateUserFunc: func(ctx context.Context, username string, password string, role enums.RoleEnum) (models.User, error) {
+			return models.User{}, &models.ConflictError{Field: "username", Value: username}
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	reqJSON, _ := json.Marshal(UpdateUserRequest{Username: "testuser", Role: "FREE"})
+	req := httptest.NewRequest(http.MethodPut, "/users", bytes.NewBuffer(reqJSON))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.UpdateUser(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestUserHandler_UpdateUser_Unauthenticated(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	reqJSON, _ := json.Marshal(UpdateUserRequest{Username: "testuser", Password: "newpass"})
+	req := httptest.NewRequest(http.MethodPut, "/users", bytes.NewBuffer(reqJSON))
+	rr := httptest.NewRecorder()
+
+	handler.UpdateUser(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestUserHandler_UpdateUser_OtherUser(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	reqJSON, _ := json.Marshal(UpdateUserRequest{Username: "victim", Password: "newpass"})
+	req := httptest.NewRequest(http.MethodPut, "/users", bytes.NewBuffer(reqJSON))
+	req.Header.Set("X-Username", "attacker")
+	rr := httptest.NewRecorder()
+
+	handler.UpdateUser(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestUserHandler_RenameUser_Success(t *testing.T) {
+	mockService := &mockUserService{
+		renameUserFunc: func(ctx context.Context, username, newUsername string) (models.User, error) {
+			return models.User{Username: newUsername, Role: enums.Free}, nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	reqJSON, _ := json.Marshal(RenameUserRequest{Username: "typo", NewUsername: "fixed"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBuffer(reqJSON))
+	req.Header.Set("X-Username", "typo")
+	rr := httptest.NewRecorder()
+
+	handler.RenameUser(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response models.User
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if response.Username != "fixed" {
+		t.Errorf("Expected username 'fixed', got %s", response.Username)
+	}
+}
+
+func TestUserHandler_RenameUser_Conflict(t *testing.T) {
+	mockService := &mockUserService{
+		renameUserFunc: func(ctx context.Context, username, newUsername string) (models.User, error) {
+			return models.User{}, &models.ConflictError{Field: "username", Value: newUsername}
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	reqJSON, _ := json.Marshal(RenameUserRequest{Username: "alice", NewUsername: "bob"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBuffer(reqJSON))
+	req.Header.Set("X-Username", "alice")
+	rr := httptest.NewRecorder()
+
+	handler.RenameUser(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestUserHandler_RenameUser_NotFound(t *testing.T) {
+	mockService := &mockUserService{
+		renameUserFunc: func(ctx context.Context, username, newUsername string) (models.User, error) {
+			return models.User{}, errors.New("user not found")
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	reqJSON, _ := json.Marshal(RenameUserRequest{Username: "ghost", NewUsername: "bob"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBuffer(reqJSON))
+	req.Header.Set("X-Username", "ghost")
+	rr := httptest.NewRecorder()
+
+	handler.RenameUser(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestUserHandler_RenameUser_InvalidBody(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBufferString("{"))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.RenameUser(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestUserHandler_RenameUser_Unauthenticated(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	reqJSON, _ := json.Marshal(RenameUserRequest{Username: "alice", NewUsername: "bob"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBuffer(reqJSON))
+	rr := httptest.NewRecorder()
+
+	handler.RenameUser(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestUserHandler_RenameUser_OtherUser(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	reqJSON, _ := json.Marshal(RenameUserRequest{Username: "victim", NewUsername: "taken"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBuffer(reqJSON))
+	req.Header.Set("X-Username", "attacker")
+	rr := httptest.NewRecorder()
+
+	handler.RenameUser(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestUserHandler_ListUsers_ParsesFilters(t *testing.T) {
+	var receivedFilter models.UserFilter
+	mockService := &mockUserService{
+		listUsersFunc: func(ctx context.Context, filter models.UserFilter) (models.UserPage, error) {
+			receivedFilter = filter
+			return models.UserPage{Items: []models.UserSummary{{Username: "alice"}}, Page: 2, PageSize: 10, Total: 11}, nil
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?role=premium&usernamePrefix=al&page=2&pageSize=10&createdFrom=2025-01-01&createdTo=2025-02-01T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListUsers(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if receivedFilter.Role != enums.Premium || receivedFilter.UsernamePrefix != "al" {
+		t.Errorf("Expected role and prefix filters, got %+v", receivedFilter)
+	}
+	if receivedFilter.Page != 2 || receivedFilter.PageSize != 10 {
+		t.Errorf("Expected page 2 size 10, got %+v", receivedFilter)
+	}
+	if receivedFilter.CreatedFrom.Year() != 2025 || receivedFilter.CreatedTo.Month() != 2 {
+		t.Errorf("Expected creation dates to be parsed, got %+v", receivedFilter)
+	}
+
+	var response models.UserPage
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if response.Total != 11 || len(response.Items) != 1 {
+		t.Errorf("Expected page to be returned, got %+v", response)
+	}
+}
+
+func TestUserHandler_ListUsers_InvalidPage(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?page=abc", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListUsers(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestUserHandler_ListUsers_InvalidDate(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?createdFrom=yesterday", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListUsers(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestUserHandler_ListUsers_InvalidRole(t *testing.T) {
+	mockService := &mockUserService{
+		listUsersFunc: func(ctx context.Context, filter models.UserFilter) (models.UserPage, error) {
+			return models.UserPage{}, errors.New("invalid role")
+		},
+	}
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?role=ADMIN", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListUsers(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestUserHandler_GetMyProfile_Success(t *testing.T) {
+	mockService := &mockUserService{
+		getProfileFunc: func(ctx context.Context, username string) (models.UserProfile, error) {
+			return models.UserProfile{DisplayName: "Test User", Timezone: "America/Sao_Paulo"}, nil
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/profile", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.GetMyProfile(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response models.UserProfile
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Errorf("Error unmarshaling response: %v", err)
+	}
+	if response.DisplayName != "Test User" {
+		t.Errorf("Expected display name 'Test User', got %s", response.DisplayName)
+	}
+}
+
+func TestUserHandler_GetMyProfile_Unauthenticated(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/profile", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetMyProfile(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestUserHandler_PatchMyProfile_Success(t *testing.T) {
+	var received models.UserProfilePatch
+	mockService := &mockUserService{
+		updateProfileFunc: func(ctx context.Context, username string, patch models.UserProfilePatch) (models.UserProfile, error) {
+			received = patch
+			return patch.Apply(models.UserProfile{}), nil
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/users/me/profile", bytes.NewBufferString(`{"location":"Recife","preferredFields":["Engineering"]}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchMyProfile(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if received.Location == nil || *received.Location != "Recife" {
+		t.Error("Expected location to be patched")
+	}
+	if received.DisplayName != nil {
+		t.Error("Expected absent display name to stay nil")
+	}
+}
+
+func TestUserHandler_PatchMyProfile_UnknownField(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/users/me/profile", bytes.NewBufferString(`{"role":"PREMIUM"}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchMyProfile(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestUserHandler_PatchMyProfile_ValidationError(t *testing.T) {
+	mockService := &mockUserService{
+		updateProfileFunc: func(ctx context.Context, username string, patch models.UserProfilePatch) (models.UserProfile, error) {
+			return models.UserProfile{}, errors.New("invalid profile: timezone must be an IANA time zone such as America/Sao_Paulo")
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/users/me/profile", bytes.NewBufferString(`{"timezone":"Nowhere"}`))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchMyProfile(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func newPatchRequest(t *testing.T, path, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPatch, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.SetPathValue("id", "68e462f868efefe99e226a8b")
+	return req
+}
+
+func TestUserHandler_PatchUser_Success(t *testing.T) {
+	var gotActor models.UserPatchActor
+	var gotID string
+	var gotPatch map[string]any
+	mockService := &mockUserService{
+		patchUserFunc: func(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error) {
+			gotActor, gotID, gotPatch = actor, id, patch
+			return models.User{Username: "testuser", Role: enums.Free}, nil
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := newPatchRequest(t, "/v1/users/68e462f868efefe99e226a8b", `{"profile":{"location":null}}`)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchUser(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if gotActor.Username != "testuser" || gotActor.Admin {
+		t.Errorf("Expected non-admin actor testuser, got %+v", gotActor)
+	}
+	if gotID != "68e462f868efefe99e226a8b" {
+		t.Errorf("Expected path ID to be passed, got %s", gotID)
+	}
+	profile, ok := gotPatch["profile"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected profile object in patch, got %v", gotPatch)
+	}
+	if value, present := profile["location"]; !present || value != nil {
+		t.Error("Expected explicit null to reach the service")
+	}
+}
+
+func TestUserHandler_PatchUser_Unauthenticated(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := newPatchRequest(t, "/v1/users/68e462f868efefe99e226a8b", `{}`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchUser(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestUserHandler_PatchUser_UnsupportedContentType(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := newPatchRequest(t, "/v1/users/68e462f868efefe99e226a8b", `{}`)
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchUser(rr, req)
+
+	if rr.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected status %d, got %d", http.StatusUnsupportedMediaType, rr.Code)
+	}
+	if rr.Header().Get("Accept-Patch") != "application/merge-patch+json" {
+		t.Error("Expected Accept-Patch header")
+	}
+}
+
+func TestUserHandler_PatchUser_NotAnObject(t *testing.T) {
+	handler := NewUserHandler(&mockUserService{})
+
+	req := newPatchRequest(t, "/v1/users/68e462f868efefe99e226a8b", `["role"]`)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchUser(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestUserHandler_PatchUser_ValidationError(t *testing.T) {
+	mockService := &mockUserService{
+		patchUserFunc: func(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error) {
+			return models.User{}, &models.ValidationError{Fields: []models.FieldError{
+				{Field: "password", Message: "must be at least 8 characters long"},
+				{Field: "username", Message: "is read-only, use POST /v1/users/rename"},
+			}}
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := newPatchRequest(t, "/v1/users/68e462f868efefe99e226a8b", `{"password":"x","username":"y"}`)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchUser(rr, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+
+	var response models.ValidationError
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Error unmarshaling response: %v", err)
+	}
+	if len(response.Fields) != 2 || response.Fields[1].Field != "username" {
+		t.Errorf("Expected offending fields in body, got %+v", response.Fields)
+	}
+}
+
+func TestUserHandler_PatchUser_Forbidden(t *testing.T) {
+	mockService := &mockUserService{
+		patchUserFunc: func(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error) {
+			return models.User{}, &models.ForbiddenError{Reason: "users may not change these fields on their own account", Fields: []string{"role"}}
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := newPatchRequest(t, "/v1/users/68e462f868efefe99e226a8b", `{"role":"PREMIUM"}`)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.PatchUser(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestUserHandler_AdminPatchUser_ActsAsAdmin(t *testing.T) {
+	var gotActor models.UserPatchActor
+	mockService := &mockUserService{
+		patchUserFunc: func(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error) {
+			gotActor = actor
+			return models.User{Username: "testuser", Role: enums.Premium}, nil
+		},
+	}
+
+	handler := NewUserHandler(mockService)
+
+	req := newPatchRequest(t, "/v1/admin/users/68e462f868efefe99e226a8b", `{"role":"PREMIUM"}`)
+	rr := httptest.NewRecorder()
+
+	handler.AdminPatchUser(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !gotActor.Admin {
+		t.Error("Expected admin actor")
+	}
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"jboard-go-crud/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formulaPrefixes start a cell that spreadsheet applications evaluate as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []Column
+	row     []string
+}
+
+// NewCSV writes the header row right away. Lists such as skills are joined with "; ". Text
+// cells that a spreadsheet would run as a formula are prefixed with a single quote.
+func NewCSV(w io.Writer, columns []Column) (Writer, error) {
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer, columns: columns, row: make([]string, len(columns))}, nil
+}
+
+func (c *csvWriter) Write(job models.Job) error {
+	for i, column := range c.columns {
+		switch value := column.value(job).(type) {
+		case string:
+			c.row[i] = escapeFormula(value)
+		case bool:
+			c.row[i] = strconv.FormatBool(value)
+		case []string:
+			c.row[i] = escapeFormula(strings.Join(value, "; "))
+		case time.Time:
+			c.row[i] = formatTime(value)
+		}
+	}
+	return c.writer.Write(c.row)
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// escapeFormula keeps job data scraped from third parties from being run as a formula when
+// the export is opened in a spreadsheet.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
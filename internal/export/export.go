@@ -0,0 +1,103 @@
+// Package export writes jobs row by row as CSV or newline-delimited JSON, so a listing can be
+// streamed to the client without holding it in memory.
+package export
+
+import (
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"strings"
+	"time"
+)
+
+const (
+	CSVContentType    = "text/csv; charset=utf-8"
+	NDJSONContentType = "application/x-ndjson"
+)
+
+// Column is one exportable job attribute.
+type Column struct {
+	Name  string
+	value func(models.Job) any
+}
+
+var columns = []Column{
+	{"id", func(job models.Job) any { return job.ID }},
+	{"title", func(job models.Job) any { return job.Title }},
+	{"company", func(job models.Job) any { return job.Company }},
+	{"url", func(job models.Job) any { return job.Url }},
+	{"field", func(job models.Job) any { return job.Field }},
+	{"seniorityLevel", func(job models.Job) any { return job.SeniorityLevel }},
+	{"workplaceType", func(job models.Job) any { return job.WorkplaceType }},
+	{"employmentType", func(job models.Job) any { return job.EmploymentType }},
+	{"officeLocation", func(job models.Job) any { return job.OfficeLocation }},
+	{"compensationTierSummary", func(job models.Job) any { return job.CompensationTierSummary }},
+	{"isBrazilianFriendly", func(job models.Job) any { return job.IsBrazilianFriendly.IsFriendly }},
+	{"brazilianFriendlyReason", func(job models.Job) any { return job.IsBrazilianFriendly.Reason }},
+	{"skills", func(job models.Job) any { return job.Skills }},
+	{"publishedDate", func(job models.Job) any { return job.PublishedDate }},
+	{"applicationDeadline", func(job models.Job) any { return job.ApplicationDeadline }},
+	{"updatedAt", func(job models.Job) any { return job.UpdatedAt }},
+	{"firstSeenAt", func(job models.Job) any { return job.FirstSeenAt }},
+	{"expiresAt", func(job models.Job) any { return job.ExpiresAt }},
+	{"description", func(job models.Job) any { return job.Description }},
+}
+
+// defaultColumns leaves out the description, which is long and rarely wanted in a spreadsheet.
+var defaultColumns = []string{
+	"id", "title", "company", "url", "field", "seniorityLevel", "workplaceType", "employmentType",
+	"officeLocation", "compensationTierSummary", "isBrazilianFriendly", "skills", "firstSeenAt", "expiresAt",
+}
+
+// ColumnNames lists every exportable column.
+func ColumnNames() []string {
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// ParseColumns resolves column names, ignoring case and repeats, in the order given. No names
+// selects the default columns.
+func ParseColumns(names []string) ([]Column, error) {
+	if len(names) == 0 {
+		names = defaultColumns
+	}
+	selected := make([]Column, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		column, found := findColumn(name)
+		if !found {
+			return nil, fmt.Errorf("invalid column %q, expected any of: %s", name, strings.Join(ColumnNames(), ", "))
+		}
+		if seen[column.Name] {
+			continue
+		}
+		seen[column.Name] = true
+		selected = append(selected, column)
+	}
+	return selected, nil
+}
+
+func findColumn(name string) (Column, bool) {
+	for _, column := range columns {
+		if strings.EqualFold(column.Name, strings.TrimSpace(name)) {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// Writer writes jobs one at a time. Flush must be called once all jobs are written.
+type Writer interface {
+	Write(job models.Job) error
+	Flush() error
+}
+
+// formatTime leaves unset times blank instead of printing the zero date.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"jboard-go-crud/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportTestJobs() []models.Job {
+	return []models.Job{
+		{
+			ID: "job-1", Title: `Backend Engineer, "Platform"`, Company: "Acme", Skills: []string{"Go", "Kubernetes"},
+			IsBrazilianFriendly: models.BrazilianFriendly{IsFriendly: true}, FirstSeenAt: time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC),
+		},
+		{ID: "job-2", Title: "Designer"},
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	defaults, err := ParseColumns(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "id", defaults[0].Name)
+	assert.Len(t, defaults, len(defaultColumns))
+
+	selected, err := ParseColumns([]string{"Title", "id", "title"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title", "id"}, []string{selected[0].Name, selected[1].Name})
+
+	_, err = ParseColumns([]string{"salary"})
+	assert.ErrorContains(t, err, `invalid column "salary"`)
+}
+
+func TestCSV(t *testing.T) {
+	columns, _ := ParseColumns([]string{"id", "title", "skills", "isBrazilianFriendly", "firstSeenAt"})
+	var out bytes.Buffer
+	writer, err := NewCSV(&out, columns)
+	assert.NoError(t, err)
+
+	for _, job := range exportTestJobs() {
+		assert.NoError(t, writer.Write(job))
+	}
+	assert.NoError(t, writer.Flush())
+
+	assert.Equal(t, "id,title,skills,isBrazilianFriendly,firstSeenAt\n"+
+		"job-1,\"Backend Engineer, \"\"Platform\"\"\",Go; Kubernetes,true,2026-06-10T09:30:00Z\n"+
+		"job-2,Designer,,false,\n", out.String())
+}
+
+func TestCSV_EscapesFormulas(t *testing.T) {
+	columns, _ := ParseColumns([]string{"title", "company", "skills", "field"})
+	var out bytes.Buffer
+	writer, err := NewCSV(&out, columns)
+	assert.NoError(t, err)
+
+	assert.NoError(t, writer.Write(models.Job{Title: "=1+1", Company: "+1 Corp", Skills: []string{"@SUM(A1)"}, Field: "-2"}))
+	assert.NoError(t, writer.Write(models.Job{Title: "\tTab", Company: "\rReturn", Field: "Go = fun"}))
+	assert.NoError(t, writer.Flush())
+
+	assert.Equal(t, "title,company,skills,field\n"+
+		"'=1+1,'+1 Corp,'@SUM(A1),'-2\n"+
+		"'\tTab,\"'\rReturn\",,Go = fun\n", out.String())
+}
+
+func TestNDJSON(t *testing.T) {
+	columns, _ := ParseColumns([]string{"id", "skills", "isBrazilianFriendly", "firstSeenAt"})
+	var out bytes.Buffer
+	writer := NewNDJSON(&out, columns)
+
+	for _, job := range exportTestJobs() {
+		assert.NoError(t, writer.Write(job))
+	}
+	assert.NoError(t, writer.Flush())
+
+	assert.Equal(t, `{"id":"job-1","skills":["Go","Kubernetes"],"isBrazilianFriendly":true,"firstSeenAt":"2026-06-10T09:30:00Z"}`+"\n"+
+		`{"id":"job-2","skills":[],"isBrazilianFriendly":false,"firstSeenAt":null}`+"\n", out.String())
+
+	var decoded map[string]any
+	first, _, _ := bytes.Cut(out.Bytes(), []byte("\n"))
+	assert.NoError(t, json.Unmarshal(first, &decoded))
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"jboard-go-crud/internal/models"
+	"time"
+)
+
+type ndjsonWriter struct {
+	writer  *bufio.Writer
+	columns []Column
+	line    bytes.Buffer
+}
+
+// NewNDJSON writes one JSON object per line holding the selected columns in order. Lists stay
+// arrays and flags stay booleans; unset times are null.
+func NewNDJSON(w io.Writer, columns []Column) Writer {
+	return &ndjsonWriter{writer: bufio.NewWriter(w), columns: columns}
+}
+
+func (n *ndjsonWriter) Write(job models.Job) error {
+	n.line.Reset()
+	n.line.WriteByte('{')
+	for i, column := range n.columns {
+		if i > 0 {
+			n.line.WriteByte(',')
+		}
+		key, _ := json.Marshal(column.Name)
+		n.line.Write(key)
+		n.line.WriteByte(':')
+
+		var value any = column.value(job)
+		switch typed := value.(type) {
+		case time.Time:
+			if typed.IsZero() {
+				value = nil
+			} else {
+				value = formatTime(typed)
+			}
+		case []string:
+			if typed == nil {
+				value = []string{}
+			}
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		n.line.Write(encoded)
+	}
+	n.line.WriteString("}\n")
+	_, err := n.writer.Write(n.line.Bytes())
+	return err
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.writer.Flush()
+}
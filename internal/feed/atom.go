@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type atomDocument struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomAuthor  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []atomLink     `xml:"link"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// Atom renders the feed as an Atom 1.0 document.
+func Atom(f Feed) ([]byte, error) {
+	document := atomDocument{
+		ID:       f.SelfURL,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.Updated.UTC().Format(time.RFC3339),
+		Links:    []atomLink{{Href: f.SelfURL, Rel: "self", Type: "application/atom+xml"}},
+		Author:   atomAuthor{Name: "JBoard"},
+		Entries:  make([]atomEntry, 0, len(f.Jobs)),
+	}
+	for _, job := range f.Jobs {
+		seen := entryTime(job, f.Updated).Format(time.RFC3339)
+		entry := atomEntry{
+			ID:        entryID(job),
+			Title:     entryTitle(job),
+			Updated:   seen,
+			Published: seen,
+			Summary:   entrySummary(job),
+		}
+		if job.Url != "" {
+			entry.Links = []atomLink{{Href: job.Url, Rel: "alternate"}}
+		}
+		for _, category := range entryCategories(job) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		document.Entries = append(document.Entries, entry)
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
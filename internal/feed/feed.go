@@ -0,0 +1,69 @@
+// Package feed renders job listings as RSS 2.0 and Atom 1.0 documents.
+package feed
+
+import (
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Feed describes a feed of jobs, newest first. SelfURL is the address the feed is served
+// from, which Atom also uses as the feed ID; Updated is used for jobs without a first-seen time.
+type Feed struct {
+	Title       string
+	Description string
+	SelfURL     string
+	Updated     time.Time
+	Jobs        []models.Job
+}
+
+func entryTitle(job models.Job) string {
+	if job.Company == "" {
+		return job.Title
+	}
+	return fmt.Sprintf("%s at %s", job.Title, job.Company)
+}
+
+// entrySummary lists the job attributes a reader scans for, skipping blank ones.
+func entrySummary(job models.Job) string {
+	parts := []string{}
+	for _, part := range []string{job.SeniorityLevel, job.WorkplaceType, job.OfficeLocation, job.EmploymentType, job.CompensationTierSummary} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if job.IsBrazilianFriendly.IsFriendly {
+		parts = append(parts, "Brazilian-friendly")
+	}
+	if len(job.Skills) > 0 {
+		parts = append(parts, "Skills: "+strings.Join(job.Skills, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func entryCategories(job models.Job) []string {
+	categories := []string{}
+	if job.Field != "" {
+		categories = append(categories, job.Field)
+	}
+	return append(categories, job.Skills...)
+}
+
+// entryID is a stable identifier for the job that does not change when its URL does.
+func entryID(job models.Job) string {
+	return "urn:jboard:job:" + url.PathEscape(job.ID)
+}
+
+func entryTime(job models.Job, fallback time.Time) time.Time {
+	if job.FirstSeenAt.IsZero() {
+		return fallback.UTC()
+	}
+	return job.FirstSeenAt.UTC()
+}
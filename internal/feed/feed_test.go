@@ -0,0 +1,94 @@
+package feed
+
+import (
+	"encoding/xml"
+	"jboard-go-crud/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFeed() Feed {
+	seen := time.Date(2026, 6, 10, 9, 30, 0, 0, time.UTC)
+	return Feed{
+		Title:       "JBoard jobs: Go",
+		Description: "The newest jobs posted on JBoard",
+		SelfURL:     "https://jboard.example/v1/jobs/feed.rss?skills=Go&brazilianFriendly=true",
+		Updated:     seen,
+		Jobs: []models.Job{
+			{
+				ID: "job/1", Title: "Backend Engineer", Company: "Acme & Co", Url: "https://jobs.example/1",
+				Field: "Engineering", SeniorityLevel: "Senior", WorkplaceType: "Remote", CompensationTierSummary: "$120K – $160K",
+				Skills: []string{"Go", "Kubernetes"}, IsBrazilianFriendly: models.BrazilianFriendly{IsFriendly: true}, FirstSeenAt: seen,
+			},
+			{ID: "job-2", Title: "Platform Engineer"},
+		},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	body, err := RSS(testFeed())
+	assert.NoError(t, err)
+
+	output := string(body)
+	assert.True(t, strings.HasPrefix(output, xml.Header))
+	assert.Contains(t, output, `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, output, `<atom:link href="https://jboard.example/v1/jobs/feed.rss?skills=Go&amp;brazilianFriendly=true" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, output, `<lastBuildDate>Wed, 10 Jun 2026 09:30:00 +0000</lastBuildDate>`)
+
+	var document struct {
+		Items []struct {
+			Title       string   `xml:"title"`
+			Link        string   `xml:"link"`
+			Description string   `xml:"description"`
+			GUID        string   `xml:"guid"`
+			PubDate     string   `xml:"pubDate"`
+			Categories  []string `xml:"category"`
+		} `xml:"channel>item"`
+	}
+	assert.NoError(t, xml.Unmarshal(body, &document))
+	assert.Len(t, document.Items, 2)
+	item := document.Items[0]
+	assert.Equal(t, "Backend Engineer at Acme & Co", item.Title)
+	assert.Equal(t, "https://jobs.example/1", item.Link)
+	assert.Equal(t, "urn:jboard:job:job%2F1", item.GUID)
+	assert.Equal(t, "Senior · Remote · $120K – $160K · Brazilian-friendly · Skills: Go, Kubernetes", item.Description)
+	assert.Equal(t, []string{"Engineering", "Go", "Kubernetes"}, item.Categories)
+	assert.Equal(t, "Platform Engineer", document.Items[1].Title)
+	assert.Empty(t, document.Items[1].Link)
+}
+
+func TestAtom(t *testing.T) {
+	body, err := Atom(testFeed())
+	assert.NoError(t, err)
+
+	var document struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Author  string   `xml:"author>name"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Title   string `xml:"title"`
+			Updated string `xml:"updated"`
+			Link    struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+		} `xml:"entry"`
+	}
+	assert.NoError(t, xml.Unmarshal(body, &document))
+	assert.Equal(t, testFeed().SelfURL, document.ID)
+	assert.Equal(t, "2026-06-10T09:30:00Z", document.Updated)
+	assert.Equal(t, "JBoard", document.Author)
+	assert.Len(t, document.Entries, 2)
+	assert.Equal(t, "urn:jboard:job:job%2F1", document.Entries[0].ID)
+	assert.Equal(t, "https://jobs.example/1", document.Entries[0].Link.Href)
+	assert.Len(t, document.Entries[0].Categories, 3)
+	// Jobs without a first-seen time fall back to the feed's update time.
+	assert.Equal(t, "2026-06-10T09:30:00Z", document.Entries[1].Updated)
+}
@@ -0,0 +1,83 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMemoryMailer_Send(t *testing.T) {
+	m := NewMemoryMailer()
+
+	_ = m.Send(context.Background(), Message{To: "a@example.com", Subject: "Hi"})
+	_ = m.Send(context.Background(), Message{To: "b@example.com", Subject: "Hello"})
+
+	messages := m.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+	if messages[1].To != "b@example.com" {
+		t.Errorf("Expected second message to b@example.com, got %s", messages[1].To)
+	}
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	m := NewFileMailer(path)
+
+	if err := m.Send(context.Background(), Message{To: "a@example.com", Subject: "Verify", Body: "token: abc"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := m.Send(context.Background(), Message{To: "b@example.com", Subject: "Reset", Body: "token: def"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read mail file: %v", err)
+	}
+	for _, expected := range []string{"To: a@example.com", "Subject: Verify", "token: abc", "To: b@example.com", "token: def"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected mail file to contain %q", expected)
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+
+	m := NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", Port: 587, From: "noreply@example.com"}).(*smtpMailer)
+	m.send = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		return nil
+	}
+
+	err := m.Send(context.Background(), Message{To: "a@example.com", Subject: "Verify", Body: "line1\nline2"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" || gotFrom != "noreply@example.com" || len(gotTo) != 1 || gotTo[0] != "a@example.com" {
+		t.Errorf("Unexpected envelope: addr=%s from=%s to=%v", gotAddr, gotFrom, gotTo)
+	}
+	if !strings.Contains(string(gotMsg), "Subject: Verify\r\n") || !strings.Contains(string(gotMsg), "line1\r\nline2") {
+		t.Errorf("Unexpected message: %q", gotMsg)
+	}
+}
+
+func TestSMTPMailer_SendError(t *testing.T) {
+	m := NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", Port: 25}).(*smtpMailer)
+	m.send = func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+
+	if err := m.Send(context.Background(), Message{To: "a@example.com"}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
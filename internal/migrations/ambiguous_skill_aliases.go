@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"slices"
+)
+
+// retiredSkillAliases are two-letter aliases the default catalog used to carry. They read as
+// plain abbreviations in job text ("TS/SCI clearance"), so they are dropped from the catalog.
+var retiredSkillAliases = map[string][]string{
+	"typescript": {"ts"},
+	"javascript": {"js"},
+	"python":     {"py"},
+}
+
+// ambiguousSkillAliases flags the ambiguous spellings of the default skills on catalogs seeded
+// before the flag existed and drops the retired two-letter aliases. Only entries without any
+// ambiguous alias are flagged, and only spellings that still resolve to the entry.
+type ambiguousSkillAliases struct {
+	taxonomyRepo repositories.SkillTaxonomyRepository
+}
+
+func NewAmbiguousSkillAliases(taxonomyRepo repositories.SkillTaxonomyRepository) Migration {
+	return &ambiguousSkillAliases{
+		taxonomyRepo: taxonomyRepo,
+	}
+}
+
+func (m *ambiguousSkillAliases) Name() string {
+	return "flag-ambiguous-skill-aliases"
+}
+
+func (m *ambiguousSkillAliases) Up(ctx context.Context) error {
+	updated := 0
+	for _, defaults := range models.DefaultSkillTaxonomy() {
+		retired := retiredSkillAliases[defaults.Key]
+		if len(defaults.AmbiguousAliases) == 0 && len(retired) == 0 {
+			continue
+		}
+		entry, found, err := m.taxonomyRepo.FindByAlias(ctx, defaults.Key)
+		if err != nil {
+			return err
+		}
+		if !found || entry.Key != defaults.Key {
+			continue
+		}
+
+		changed := false
+		for _, alias := range retired {
+			if i := slices.Index(entry.Aliases, alias); i >= 0 {
+				entry.Aliases = slices.Delete(entry.Aliases, i, i+1)
+				changed = true
+			}
+			if i := slices.Index(entry.AmbiguousAliases, alias); i >= 0 {
+				entry.AmbiguousAliases = slices.Delete(entry.AmbiguousAliases, i, i+1)
+				changed = true
+			}
+		}
+		if len(entry.AmbiguousAliases) == 0 {
+			for _, spelling := range defaults.AmbiguousAliases {
+				if entry.HasAlias(spelling) {
+					entry.AmbiguousAliases = append(entry.AmbiguousAliases, spelling)
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := m.taxonomyRepo.Upsert(ctx, entry.Normalized()); err != nil {
+			return err
+		}
+		updated++
+	}
+	if updated > 0 {
+		log.Printf("Flagged ambiguous aliases or dropped retired aliases on %d canonical skills", updated)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"log"
+)
+
+// duplicateUsernamesReport lists usernames that differ only by letter case. They block the
+// unique, case-insensitive username indexes and have to be merged or renamed by hand.
+type duplicateUsernamesReport struct {
+	userRepo  repositories.UserRepository
+	skillRepo repositories.SkillRepository
+}
+
+func NewDuplicateUsernamesReport(userRepo repositories.UserRepository, skillRepo repositories.SkillRepository) Migration {
+	return &duplicateUsernamesReport{
+		userRepo:  userRepo,
+		skillRepo: skillRepo,
+	}
+}
+
+func (m *duplicateUsernamesReport) Name() string {
+	return "report-duplicate-usernames"
+}
+
+func (m *duplicateUsernamesReport) Up(ctx context.Context) error {
+	userDuplicates, err := m.userRepo.FindDuplicateUsernames(ctx)
+	if err != nil {
+		return err
+	}
+	skillDuplicates, err := m.skillRepo.FindDuplicateUsernames(ctx)
+	if err != nil {
+		return err
+	}
+
+	report("users", userDuplicates)
+	report("skills", skillDuplicates)
+
+	total := len(userDuplicates) + len(skillDuplicates)
+	if total > 0 {
+		return fmt.Errorf("found %d duplicate usernames, unique username indexes cannot be created until they are resolved", total)
+	}
+	return nil
+}
+
+func report(collection string, duplicates []models.DuplicateUsername) {
+	for _, duplicate := range duplicates {
+		ids := make([]string, 0, len(duplicate.IDs))
+		for _, id := range duplicate.IDs {
+			ids = append(ids, id.Hex())
+		}
+		log.Printf("WARNING: Duplicate username '%s' in %s: %d documents %v", duplicate.Username, collection, duplicate.Count, ids)
+	}
+}
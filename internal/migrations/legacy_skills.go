@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"jboard-go-crud/internal/repositories"
+	"log"
+)
+
+// legacySkills converts skill lists stored as plain strings into entries with a name, so
+// proficiency and experience can be recorded for them.
+type legacySkills struct {
+	skillRepo repositories.SkillRepository
+}
+
+func NewLegacySkills(skillRepo repositories.SkillRepository) Migration {
+	return &legacySkills{
+		skillRepo: skillRepo,
+	}
+}
+
+func (m *legacySkills) Name() string {
+	return "convert-legacy-skills"
+}
+
+func (m *legacySkills) Up(ctx context.Context) error {
+	migrated, err := m.skillRepo.MigrateLegacySkills(ctx)
+	if err != nil {
+		return err
+	}
+	if migrated > 0 {
+		log.Printf("Converted legacy skill lists of %d users", migrated)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Migration is a data fix-up run at startup. Migrations must be idempotent because they run
+// on every boot.
+type Migration interface {
+	Name() string
+	Up(ctx context.Context) error
+}
+
+type Runner struct {
+	migrations []Migration
+}
+
+func NewRunner(migrations ...Migration) *Runner {
+	log.Printf("Creating new migrations Runner with %d migrations", len(migrations))
+	return &Runner{
+		migrations: migrations,
+	}
+}
+
+// Run applies every migration in order. A failing migration is logged and does not stop the
+// ones after it; all failures are returned together.
+func (r *Runner) Run(ctx context.Context) error {
+	var errs []error
+	for _, migration := range r.migrations {
+		log.Printf("Running migration '%s'...", migration.Name())
+		if err := migration.Up(ctx); err != nil {
+			log.Printf("ERROR: Migration '%s' failed: %v", migration.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", migration.Name(), err))
+			continue
+		}
+		log.Printf("Migration '%s' finished", migration.Name())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,274 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeMigration struct {
+	name string
+	err  error
+	runs *[]string
+}
+
+func (f fakeMigration) Name() string {
+	return f.name
+}
+
+func (f fakeMigration) Up(_ context.Context) error {
+	*f.runs = append(*f.runs, f.name)
+	return f.err
+}
+
+type fakeUserRepository struct {
+	repositories.UserRepository
+	duplicates []models.DuplicateUsername
+	err        error
+	indexErr   error
+	indexed    bool
+}
+
+func (f *fakeUserRepository) FindDuplicateUsernames(_ context.Context) ([]models.DuplicateUsername, error) {
+	return f.duplicates, f.err
+}
+
+func (f *fakeUserRepository) EnsureUsernameIndex(_ context.Context) error {
+	f.indexed = true
+	return f.indexErr
+}
+
+type fakeSkillRepository struct {
+	repositories.SkillRepository
+	duplicates []models.DuplicateUsername
+	migrated   int64
+	migrateErr error
+	migrations int
+	indexed    bool
+}
+
+func (f *fakeSkillRepository) FindDuplicateUsernames(_ context.Context) ([]models.DuplicateUsername, error) {
+	return f.duplicates, nil
+}
+
+func (f *fakeSkillRepository) EnsureUsernameIndex(_ context.Context) error {
+	f.indexed = true
+	return nil
+}
+
+func (f *fakeSkillRepository) MigrateLegacySkills(_ context.Context) (int64, error) {
+	f.migrations++
+	return f.migrated, f.migrateErr
+}
+
+type fakeSkillTaxonomyRepository struct {
+	repositories.SkillTaxonomyRepository
+	seeded  []models.CanonicalSkill
+	entries map[string]models.CanonicalSkill
+}
+
+func (f *fakeSkillTaxonomyRepository) FindByAlias(_ context.Context, name string) (models.CanonicalSkill, bool, error) {
+	for _, entry := range f.entries {
+		if entry.HasAlias(name) {
+			return entry, true, nil
+		}
+	}
+	return models.CanonicalSkill{}, false, nil
+}
+
+func (f *fakeSkillTaxonomyRepository) Upsert(_ context.Context, skill models.CanonicalSkill) error {
+	f.entries[skill.Key] = skill
+	return nil
+}
+
+func (f *fakeSkillTaxonomyRepository) SeedDefaults(_ context.Context, skills []models.CanonicalSkill) (int64, error) {
+	f.seeded = skills
+	return int64(len(skills)), nil
+}
+
+func TestRunner_RunsInOrderAndContinuesAfterFailure(t *testing.T) {
+	var runs []string
+	runner := NewRunner(
+		fakeMigration{name: "first", runs: &runs},
+		fakeMigration{name: "second", err: errors.New("boom"), runs: &runs},
+		fakeMigration{name: "third", runs: &runs},
+	)
+
+	err := runner.Run(context.Background())
+
+	if strings.Join(runs, ",") != "first,second,third" {
+		t.Errorf("Expected migrations to run in order, got %v", runs)
+	}
+	if err == nil || !strings.Contains(err.Error(), "second: boom") {
+		t.Errorf("Expected failure of 'second' to be reported, got %v", err)
+	}
+}
+
+func TestRunner_NoMigrations(t *testing.T) {
+	if err := NewRunner().Run(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestDuplicateUsernamesReport_NoDuplicates(t *testing.T) {
+	migration := NewDuplicateUsernamesReport(&fakeUserRepository{}, &fakeSkillRepository{})
+
+	if err := migration.Up(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestDuplicateUsernamesReport_ReportsDuplicates(t *testing.T) {
+	userRepo := &fakeUserRepository{duplicates: []models.DuplicateUsername{
+		{Username: "bob", IDs: []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}, Count: 2},
+	}}
+	skillRepo := &fakeSkillRepository{duplicates: []models.DuplicateUsername{
+		{Username: "bob", IDs: []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}, Count: 2},
+	}}
+	migration := NewDuplicateUsernamesReport(userRepo, skillRepo)
+
+	err := migration.Up(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "found 2 duplicate usernames") {
+		t.Errorf("Expected duplicates to be reported, got %v", err)
+	}
+}
+
+func TestDuplicateUsernamesReport_RepositoryError(t *testing.T) {
+	migration := NewDuplicateUsernamesReport(&fakeUserRepository{err: errors.New("database error")}, &fakeSkillRepository{})
+
+	err := migration.Up(context.Background())
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func TestUsernameIndexes_CreatesIndexes(t *testing.T) {
+	userRepo := &fakeUserRepository{}
+	skillRepo := &fakeSkillRepository{}
+	migration := NewUsernameIndexes(userRepo, skillRepo)
+
+	if err := migration.Up(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if !userRepo.indexed || !skillRepo.indexed {
+		t.Errorf("Expected both username indexes to be created")
+	}
+}
+
+func TestUsernameIndexes_RefusesWhileDuplicatesRemain(t *testing.T) {
+	userRepo := &fakeUserRepository{duplicates: []models.DuplicateUsername{
+		{Username: "bob", IDs: []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}, Count: 2},
+	}}
+	skillRepo := &fakeSkillRepository{}
+	migration := NewUsernameIndexes(userRepo, skillRepo)
+
+	err := migration.Up(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "1 duplicate usernames must be resolved") {
+		t.Errorf("Expected duplicates to block the indexes, got %v", err)
+	}
+	if userRepo.indexed || skillRepo.indexed {
+		t.Errorf("Expected no index to be built while duplicates remain")
+	}
+}
+
+func TestUsernameIndexes_IndexError(t *testing.T) {
+	migration := NewUsernameIndexes(&fakeUserRepository{indexErr: errors.New("index build failed")}, &fakeSkillRepository{})
+
+	err := migration.Up(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "users: index build failed") {
+		t.Errorf("Expected index error to be returned, got %v", err)
+	}
+}
+
+func TestLegacySkills_Converts(t *testing.T) {
+	skillRepo := &fakeSkillRepository{migrated: 3}
+	migration := NewLegacySkills(skillRepo)
+
+	if err := migration.Up(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if skillRepo.migrations != 1 {
+		t.Errorf("Expected one conversion run, got %d", skillRepo.migrations)
+	}
+}
+
+func TestLegacySkills_RepositoryError(t *testing.T) {
+	migration := NewLegacySkills(&fakeSkillRepository{migrateErr: errors.New("database error")})
+
+	err := migration.Up(context.Background())
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func TestSkillTaxonomySeed_SeedsDefaults(t *testing.T) {
+	taxonomyRepo := &fakeSkillTaxonomyRepository{}
+
+	if err := NewSkillTaxonomySeed(taxonomyRepo).Up(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(taxonomyRepo.seeded) == 0 {
+		t.Error("Expected the default taxonomy to be seeded")
+	}
+	owners := map[string]string{}
+	for _, skill := range taxonomyRepo.seeded {
+		if skill.Key == "" || !skill.Category.IsValid() {
+			t.Errorf("Expected normalized default skill, got %+v", skill)
+		}
+		for _, alias := range skill.Aliases {
+			if owner, taken := owners[alias]; taken {
+				t.Errorf("Alias %q belongs to both %s and %s", alias, owner, skill.Key)
+			}
+			owners[alias] = skill.Key
+		}
+	}
+}
+
+func TestAmbiguousSkillAliases_FlagsUnflaggedDefaults(t *testing.T) {
+	taxonomyRepo := &fakeSkillTaxonomyRepository{entries: map[string]models.CanonicalSkill{
+		"go":         {Key: "go", Name: "Go", Aliases: []string{"go", "golang"}},
+		"springboot": {Key: "springboot", Name: "Spring Boot", Aliases: []string{"springboot"}},
+		"nodejs":     {Key: "nodejs", Name: "Node.js", Aliases: []string{"node", "nodejs"}, AmbiguousAliases: []string{"NODE"}},
+	}}
+
+	if err := NewAmbiguousSkillAliases(taxonomyRepo).Up(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := taxonomyRepo.entries["go"].AmbiguousAliases; len(got) != 1 || got[0] != "Go" {
+		t.Errorf("Expected Go to be flagged, got %v", got)
+	}
+	if got := taxonomyRepo.entries["springboot"].AmbiguousAliases; len(got) != 0 {
+		t.Errorf("Expected an alias removed by an admin to stay unflagged, got %v", got)
+	}
+	if got := taxonomyRepo.entries["nodejs"].AmbiguousAliases; len(got) != 1 || got[0] != "NODE" {
+		t.Errorf("Expected entries already flagged to be left alone, got %v", got)
+	}
+}
+
+func TestAmbiguousSkillAliases_DropsRetiredAliases(t *testing.T) {
+	taxonomyRepo := &fakeSkillTaxonomyRepository{entries: map[string]models.CanonicalSkill{
+		"typescript": {Key: "typescript", Name: "TypeScript", Aliases: []string{"ts", "typescript"}},
+		"python":     {Key: "python", Name: "Python", Aliases: []string{"py", "python", "python3"}, AmbiguousAliases: []string{"py"}},
+	}}
+
+	if err := NewAmbiguousSkillAliases(taxonomyRepo).Up(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := taxonomyRepo.entries["typescript"].Aliases; len(got) != 1 || got[0] != "typescript" {
+		t.Errorf("Expected ts to be dropped, got %v", got)
+	}
+	python := taxonomyRepo.entries["python"]
+	if python.HasAlias("py") || len(python.AmbiguousAliases) != 0 {
+		t.Errorf("Expected py to be dropped, got %v and %v", python.Aliases, python.AmbiguousAliases)
+	}
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"log"
+)
+
+// skillTaxonomySeed inserts the default canonical skills that are missing from the catalog.
+type skillTaxonomySeed struct {
+	taxonomyRepo repositories.SkillTaxonomyRepository
+}
+
+func NewSkillTaxonomySeed(taxonomyRepo repositories.SkillTaxonomyRepository) Migration {
+	return &skillTaxonomySeed{
+		taxonomyRepo: taxonomyRepo,
+	}
+}
+
+func (m *skillTaxonomySeed) Name() string {
+	return "seed-skill-taxonomy"
+}
+
+func (m *skillTaxonomySeed) Up(ctx context.Context) error {
+	seeded, err := m.taxonomyRepo.SeedDefaults(ctx, models.DefaultSkillTaxonomy())
+	if err != nil {
+		return err
+	}
+	if seeded > 0 {
+		log.Printf("Added %d default skills to the taxonomy", seeded)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/repositories"
+	"log"
+)
+
+// usernameIndexes builds the unique, case-insensitive username indexes that conflict detection
+// relies on. It runs after report-duplicate-usernames and refuses to build while duplicates
+// remain, so a missing index shows up as a failed migration instead of going unnoticed.
+type usernameIndexes struct {
+	userRepo  repositories.UserRepository
+	skillRepo repositories.SkillRepository
+}
+
+func NewUsernameIndexes(userRepo repositories.UserRepository, skillRepo repositories.SkillRepository) Migration {
+	return &usernameIndexes{
+		userRepo:  userRepo,
+		skillRepo: skillRepo,
+	}
+}
+
+func (m *usernameIndexes) Name() string {
+	return "create-username-indexes"
+}
+
+func (m *usernameIndexes) Up(ctx context.Context) error {
+	userDuplicates, err := m.userRepo.FindDuplicateUsernames(ctx)
+	if err != nil {
+		return err
+	}
+	skillDuplicates, err := m.skillRepo.FindDuplicateUsernames(ctx)
+	if err != nil {
+		return err
+	}
+	if total := len(userDuplicates) + len(skillDuplicates); total > 0 {
+		return fmt.Errorf("unique username indexes not created: %d duplicate usernames must be resolved first", total)
+	}
+
+	var errs []error
+	if err := m.userRepo.EnsureUsernameIndex(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("users: %w", err))
+	}
+	if err := m.skillRepo.EnsureUsernameIndex(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("skills: %w", err))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	log.Printf("Unique username indexes are in place")
+	return nil
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"fmt"
+	"jboard-go-crud/internal/models/enums"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxApplicationNotesLength = 5000
+
+// Application tracks a user's progress on one job. The job is referenced by ID when it was
+// listed here and always by URL, with its title and company copied so the application outlives
+// the job listing.
+type Application struct {
+	ID         primitive.ObjectID          `json:"id" bson:"_id,omitempty"`
+	Username   string                      `json:"username" bson:"username"`
+	JobID      string                      `json:"jobId,omitempty" bson:"jobId,omitempty"`
+	JobUrl     string                      `json:"jobUrl" bson:"jobUrl"`
+	Title      string                      `json:"title,omitempty" bson:"title,omitempty"`
+	Company    string                      `json:"company,omitempty" bson:"company,omitempty"`
+	Status     enums.ApplicationStatusEnum `json:"status" bson:"status"`
+	Notes      string                      `json:"notes,omitempty" bson:"notes,omitempty"`
+	AppliedAt  *time.Time                  `json:"appliedAt,omitempty" bson:"appliedAt,omitempty"`
+	ReminderAt *time.Time                  `json:"reminderAt,omitempty" bson:"reminderAt,omitempty"`
+	History    []ApplicationStatusChange   `json:"history" bson:"history"`
+	CreatedAt  time.Time                   `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time                   `json:"updatedAt" bson:"updatedAt"`
+}
+
+type ApplicationStatusChange struct {
+	Status    enums.ApplicationStatusEnum `json:"status" bson:"status"`
+	ChangedAt time.Time                   `json:"changedAt" bson:"changedAt"`
+}
+
+// ApplicationRequest starts tracking a job, given by jobId, jobUrl or both. Title and company
+// are only needed for jobs that are not listed here. An empty status means INTERESTED.
+type ApplicationRequest struct {
+	JobID      string                      `json:"jobId"`
+	JobUrl     string                      `json:"jobUrl"`
+	Title      string                      `json:"title"`
+	Company    string                      `json:"company"`
+	Status     enums.ApplicationStatusEnum `json:"status"`
+	Notes      string                      `json:"notes"`
+	AppliedAt  *time.Time                  `json:"appliedAt"`
+	ReminderAt *time.Time                  `json:"reminderAt"`
+}
+
+// Normalized trims the free text and upper-cases the status.
+func (r ApplicationRequest) Normalized() ApplicationRequest {
+	r.JobID = strings.TrimSpace(r.JobID)
+	r.JobUrl = strings.TrimSpace(r.JobUrl)
+	r.Title = strings.TrimSpace(r.Title)
+	r.Company = strings.TrimSpace(r.Company)
+	r.Notes = strings.TrimSpace(r.Notes)
+	r.Status = enums.ApplicationStatusEnum(strings.ToUpper(strings.TrimSpace(string(r.Status))))
+	if r.Status == "" {
+		r.Status = enums.ApplicationInterested
+	}
+	return r
+}
+
+// FieldErrors validates a normalized request.
+func (r ApplicationRequest) FieldErrors() []FieldError {
+	var problems []FieldError
+	if r.JobID == "" && r.JobUrl == "" {
+		problems = append(problems, FieldError{Field: "jobId", Message: "or jobUrl is required"})
+	}
+	if r.JobUrl != "" && !validJobUrl(r.JobUrl) {
+		problems = append(problems, FieldError{Field: "jobUrl", Message: "must be an absolute http or https URL"})
+	}
+	if !r.Status.IsValid() {
+		problems = append(problems, FieldError{Field: "status", Message: fmt.Sprintf("must be one of %v", enums.GetAllApplicationStatuses())})
+	}
+	return append(problems, notesFieldErrors(r.Notes)...)
+}
+
+// ApplicationPatch updates an application: nil fields are left untouched. ClearReminder drops
+// the reminder, since a null reminderAt cannot be told apart from a missing one.
+type ApplicationPatch struct {
+	Status        *enums.ApplicationStatusEnum `json:"status"`
+	Notes         *string                      `json:"notes"`
+	AppliedAt     *time.Time                   `json:"appliedAt"`
+	ReminderAt    *time.Time                   `json:"reminderAt"`
+	ClearReminder bool                         `json:"clearReminder"`
+}
+
+// Normalized trims the notes and upper-cases the status.
+func (p ApplicationPatch) Normalized() ApplicationPatch {
+	if p.Status != nil {
+		status := enums.ApplicationStatusEnum(strings.ToUpper(strings.TrimSpace(string(*p.Status))))
+		p.Status = &status
+	}
+	if p.Notes != nil {
+		notes := strings.TrimSpace(*p.Notes)
+		p.Notes = &notes
+	}
+	return p
+}
+
+// FieldErrors validates a normalized patch against the application it applies to, rejecting
+// status changes the pipeline does not allow.
+func (p ApplicationPatch) FieldErrors(current Application) []FieldError {
+	var problems []FieldError
+	if p.Status != nil && *p.Status != current.Status {
+		switch {
+		case !p.Status.IsValid():
+			problems = append(problems, FieldError{Field: "status", Message: fmt.Sprintf("must be one of %v", enums.GetAllApplicationStatuses())})
+		case !current.Status.CanTransitionTo(*p.Status):
+			problems = append(problems, FieldError{Field: "status", Message: fmt.Sprintf("cannot change from %s to %s", current.Status, *p.Status)})
+		}
+	}
+	if p.ReminderAt != nil && p.ClearReminder {
+		problems = append(problems, FieldError{Field: "clearReminder", Message: "cannot be combined with reminderAt"})
+	}
+	if p.Notes != nil {
+		problems = append(problems, notesFieldErrors(*p.Notes)...)
+	}
+	return problems
+}
+
+// Apply returns a copy of application with the patch applied at now. A status change is added
+// to the history, moving to APPLIED sets appliedAt unless already known, and closing the
+// application drops its reminder.
+func (p ApplicationPatch) Apply(application Application, now time.Time) Application {
+	if p.Status != nil && *p.Status != application.Status {
+		application.Status = *p.Status
+		application.History = append(application.History, ApplicationStatusChange{Status: *p.Status, ChangedAt: now})
+		if application.Status == enums.ApplicationApplied && application.AppliedAt == nil {
+			application.AppliedAt = &now
+		}
+	}
+	if p.Notes != nil {
+		application.Notes = *p.Notes
+	}
+	if p.AppliedAt != nil {
+		application.AppliedAt = p.AppliedAt
+	}
+	if p.ReminderAt != nil {
+		application.ReminderAt = p.ReminderAt
+	}
+	if p.ClearReminder || application.Status.IsClosed() {
+		application.ReminderAt = nil
+	}
+	application.UpdatedAt = now
+	return application
+}
+
+// ApplicationColumn holds the applications in one status of the board.
+type ApplicationColumn struct {
+	Status       enums.ApplicationStatusEnum `json:"status"`
+	Count        int                         `json:"count"`
+	Applications []Application               `json:"applications"`
+}
+
+// ApplicationBoard groups a user's applications by status, one column per status in pipeline
+// order, empty columns included.
+type ApplicationBoard struct {
+	Total   int                 `json:"total"`
+	Columns []ApplicationColumn `json:"columns"`
+}
+
+func notesFieldErrors(notes string) []FieldError {
+	if len(notes) > maxApplicationNotesLength {
+		return []FieldError{{Field: "notes", Message: fmt.Sprintf("must be at most %d characters", maxApplicationNotesLength)}}
+	}
+	return nil
+}
+
+func validJobUrl(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bookmark keeps a snapshot of a job so that it stays listed after the job expires from the
+// jobs collection. Closed is computed when listing and never stored.
+type Bookmark struct {
+	ID        primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	Username  string             `json:"username" bson:"username"`
+	JobID     string             `json:"jobId" bson:"jobId"`
+	Job       Job                `json:"job" bson:"job"`
+	Closed    bool               `json:"closed" bson:"-"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
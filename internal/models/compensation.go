@@ -0,0 +1,109 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hoursPerYear and monthsPerYear annualize hourly and monthly pay.
+const (
+	hoursPerYear  = 2080
+	monthsPerYear = 12
+)
+
+// Compensation is a yearly pay range parsed from a job's compensation summary.
+type Compensation struct {
+	Currency string  `json:"currency"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+}
+
+// Midpoint is the middle of the range, used as the job's pay in aggregates.
+func (c Compensation) Midpoint() float64 {
+	return (c.Min + c.Max) / 2
+}
+
+var currencyCodes = map[string]string{
+	"$": "USD", "us$": "USD", "usd": "USD",
+	"r$": "BRL", "brl": "BRL",
+	"€": "EUR", "eur": "EUR",
+	"£": "GBP", "gbp": "GBP",
+	"ca$": "CAD", "cad": "CAD",
+	"a$": "AUD", "aud": "AUD",
+}
+
+const currencyPattern = `us\$|r\$|ca\$|a\$|\$|€|£|usd|brl|eur|gbp|cad|aud`
+
+// compensationPattern matches an amount with a currency in front, optionally followed by the
+// upper end of a range, e.g. "$120K – $160K", "R$ 8.000 a R$ 12.000" or "€60-80k".
+var compensationPattern = regexp.MustCompile(`(?i)(` + currencyPattern + `)\s?(\d[\d.,]*)\s*([km])?` +
+	`(?:\s*(?:-|–|—|to|a)\s*(?:` + currencyPattern + `)?\s?(\d[\d.,]*)\s*([km])?)?`)
+
+var thousandsPattern = regexp.MustCompile(`^\d{1,3}([.,]\d{3})+$`)
+
+// ParseCompensation reads the first pay range of a free-text compensation summary, converting
+// hourly and monthly pay to yearly. Summaries without an amount in a known currency, or with
+// implausibly small yearly amounts, are rejected.
+func ParseCompensation(summary string) (Compensation, bool) {
+	match := compensationPattern.FindStringSubmatch(summary)
+	if match == nil {
+		return Compensation{}, false
+	}
+
+	low, ok := parseAmount(match[2], match[3])
+	if !ok {
+		return Compensation{}, false
+	}
+	high := low
+	if match[4] != "" {
+		if parsed, ok := parseAmount(match[4], match[5]); ok {
+			high = parsed
+		}
+		// "$120-160K": the suffix of the upper end applies to both.
+		if match[3] == "" && match[5] != "" && low < 1000 {
+			low, _ = parseAmount(match[2], match[5])
+		}
+	}
+	if high < low {
+		high = low
+	}
+
+	period := 1.0
+	lower := strings.ToLower(summary)
+	switch {
+	case strings.Contains(lower, "hour") || strings.Contains(lower, "/hr") || strings.Contains(lower, "/h "):
+		period = hoursPerYear
+	case strings.Contains(lower, "month") || strings.Contains(lower, "/mo") || strings.Contains(lower, "mês") || strings.Contains(lower, "mensal"):
+		period = monthsPerYear
+	}
+
+	compensation := Compensation{
+		Currency: currencyCodes[strings.ToLower(match[1])],
+		Min:      low * period,
+		Max:      high * period,
+	}
+	if compensation.Min < 1000 {
+		return Compensation{}, false
+	}
+	return compensation, true
+}
+
+func parseAmount(number, suffix string) (float64, bool) {
+	if thousandsPattern.MatchString(number) {
+		number = strings.NewReplacer(".", "", ",", "").Replace(number)
+	} else {
+		number = strings.ReplaceAll(strings.TrimRight(number, ".,"), ",", ".")
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch strings.ToLower(suffix) {
+	case "k":
+		value *= 1_000
+	case "m":
+		value *= 1_000_000
+	}
+	return value, true
+}
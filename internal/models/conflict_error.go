@@ -0,0 +1,18 @@
+package models
+
+import "fmt"
+
+// ConflictError reports a write rejected because another document already holds a unique value,
+// or, when Reason is set, because the document changed since it was read.
+type ConflictError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *ConflictError) Error() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return fmt.Sprintf("%s already exists", e.Field)
+}
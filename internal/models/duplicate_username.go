@@ -0,0 +1,10 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// DuplicateUsername groups documents whose usernames only differ by letter case.
+type DuplicateUsername struct {
+	Username string               `json:"username" bson:"_id"`
+	IDs      []primitive.ObjectID `json:"ids" bson:"ids"`
+	Count    int                  `json:"count" bson:"count"`
+}
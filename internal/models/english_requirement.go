@@ -0,0 +1,39 @@
+package models
+
+import (
+	"jboard-go-crud/internal/models/enums"
+	"regexp"
+	"strings"
+)
+
+var englishLevelWords = map[string]enums.EnglishLevelEnum{
+	"basic": enums.EnglishBasic, "básico": enums.EnglishBasic, "basico": enums.EnglishBasic,
+	"intermediate": enums.EnglishIntermediate, "intermediário": enums.EnglishIntermediate, "intermediario": enums.EnglishIntermediate,
+	"advanced": enums.EnglishAdvanced, "avançado": enums.EnglishAdvanced, "avancado": enums.EnglishAdvanced,
+	"fluent": enums.EnglishFluent, "fluente": enums.EnglishFluent,
+	"native": enums.EnglishNative, "nativo": enums.EnglishNative,
+}
+
+// englishRequirementPattern matches an English level stated in a posting, e.g. "fluent English",
+// "English (advanced)", "English level: intermediate" or "inglês avançado".
+var englishRequirementPattern = regexp.MustCompile(`(?i)\b(basic|intermediate|advanced|fluent|native)(?:\s+level)?\s+(?:of\s+)?english\b` +
+	`|\benglish(?:\s+level)?\s*[:(–-]?\s*(basic|intermediate|advanced|fluent|native)\b` +
+	`|ingl[eê]s(?:\s+n[ií]vel)?\s*[:(–-]?\s*(b[aá]sico|intermedi[aá]rio|avan[cç]ado|fluente|nativo)` +
+	`|(b[aá]sico|intermedi[aá]rio|avan[cç]ado|fluente|nativo)\s+(?:em\s+)?ingl[eê]s`)
+
+// RequiredEnglishLevel reads the English level a job asks for from its title and description,
+// taking the first level stated. Postings that state none report false.
+func RequiredEnglishLevel(job Job) (enums.EnglishLevelEnum, bool) {
+	for _, text := range []string{job.Title, job.Description} {
+		match := englishRequirementPattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		for _, word := range match[1:] {
+			if level, ok := englishLevelWords[strings.ToLower(word)]; ok {
+				return level, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,17 @@
+package enums
+
+type AlertFrequencyEnum string
+
+const (
+	AlertInstant AlertFrequencyEnum = "INSTANT"
+	AlertDaily   AlertFrequencyEnum = "DAILY"
+)
+
+// AlertFrequencyFor returns the digest frequency of a role: PREMIUM users are alerted as soon
+// as a job arrives, FREE users get one digest a day.
+func AlertFrequencyFor(role RoleEnum) AlertFrequencyEnum {
+	if role == Premium {
+		return AlertInstant
+	}
+	return AlertDaily
+}
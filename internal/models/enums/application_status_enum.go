@@ -0,0 +1,53 @@
+package enums
+
+type ApplicationStatusEnum string
+
+const (
+	ApplicationInterested   ApplicationStatusEnum = "INTERESTED"
+	ApplicationApplied      ApplicationStatusEnum = "APPLIED"
+	ApplicationInterviewing ApplicationStatusEnum = "INTERVIEWING"
+	ApplicationOffer        ApplicationStatusEnum = "OFFER"
+	ApplicationRejected     ApplicationStatusEnum = "REJECTED"
+	ApplicationWithdrawn    ApplicationStatusEnum = "WITHDRAWN"
+)
+
+// applicationTransitions lists the statuses each status may move to. Rejected and withdrawn
+// applications are closed and cannot move anymore.
+var applicationTransitions = map[ApplicationStatusEnum][]ApplicationStatusEnum{
+	ApplicationInterested:   {ApplicationApplied, ApplicationWithdrawn},
+	ApplicationApplied:      {ApplicationInterviewing, ApplicationOffer, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationInterviewing: {ApplicationOffer, ApplicationRejected, ApplicationWithdrawn},
+	ApplicationOffer:        {ApplicationRejected, ApplicationWithdrawn},
+}
+
+func (s ApplicationStatusEnum) String() string {
+	return string(s)
+}
+
+func (s ApplicationStatusEnum) IsValid() bool {
+	switch s {
+	case ApplicationInterested, ApplicationApplied, ApplicationInterviewing, ApplicationOffer, ApplicationRejected, ApplicationWithdrawn:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsClosed reports whether the application reached a final status.
+func (s ApplicationStatusEnum) IsClosed() bool {
+	return s == ApplicationRejected || s == ApplicationWithdrawn
+}
+
+func (s ApplicationStatusEnum) CanTransitionTo(next ApplicationStatusEnum) bool {
+	for _, allowed := range applicationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllApplicationStatuses returns the statuses in pipeline order.
+func GetAllApplicationStatuses() []ApplicationStatusEnum {
+	return []ApplicationStatusEnum{ApplicationInterested, ApplicationApplied, ApplicationInterviewing, ApplicationOffer, ApplicationRejected, ApplicationWithdrawn}
+}
@@ -0,0 +1,42 @@
+package enums
+
+type EnglishLevelEnum string
+
+const (
+	EnglishBasic        EnglishLevelEnum = "BASIC"
+	EnglishIntermediate EnglishLevelEnum = "INTERMEDIATE"
+	EnglishAdvanced     EnglishLevelEnum = "ADVANCED"
+	EnglishFluent       EnglishLevelEnum = "FLUENT"
+	EnglishNative       EnglishLevelEnum = "NATIVE"
+)
+
+func (e EnglishLevelEnum) String() string {
+	return string(e)
+}
+
+func (e EnglishLevelEnum) IsValid() bool {
+	switch e {
+	case EnglishBasic, EnglishIntermediate, EnglishAdvanced, EnglishFluent, EnglishNative:
+		return true
+	default:
+		return false
+	}
+}
+
+func GetAllEnglishLevels() []EnglishLevelEnum {
+	return []EnglishLevelEnum{EnglishBasic, EnglishIntermediate, EnglishAdvanced, EnglishFluent, EnglishNative}
+}
+
+// AtLeast reports whether e is the same level as other or above it.
+func (e EnglishLevelEnum) AtLeast(other EnglishLevelEnum) bool {
+	return englishLevelRank(e) >= englishLevelRank(other)
+}
+
+func englishLevelRank(level EnglishLevelEnum) int {
+	for rank, candidate := range GetAllEnglishLevels() {
+		if candidate == level {
+			return rank
+		}
+	}
+	return -1
+}
@@ -0,0 +1,43 @@
+package enums
+
+type ProficiencyEnum string
+
+const (
+	ProficiencyBeginner     ProficiencyEnum = "BEGINNER"
+	ProficiencyIntermediate ProficiencyEnum = "INTERMEDIATE"
+	ProficiencyAdvanced     ProficiencyEnum = "ADVANCED"
+	ProficiencyExpert       ProficiencyEnum = "EXPERT"
+)
+
+func (p ProficiencyEnum) String() string {
+	return string(p)
+}
+
+func (p ProficiencyEnum) IsValid() bool {
+	switch p {
+	case ProficiencyBeginner, ProficiencyIntermediate, ProficiencyAdvanced, ProficiencyExpert:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank orders the levels from 1 (beginner) to 4 (expert); an unknown level ranks 0.
+func (p ProficiencyEnum) Rank() int {
+	switch p {
+	case ProficiencyBeginner:
+		return 1
+	case ProficiencyIntermediate:
+		return 2
+	case ProficiencyAdvanced:
+		return 3
+	case ProficiencyExpert:
+		return 4
+	default:
+		return 0
+	}
+}
+
+func GetAllProficiencies() []ProficiencyEnum {
+	return []ProficiencyEnum{ProficiencyBeginner, ProficiencyIntermediate, ProficiencyAdvanced, ProficiencyExpert}
+}
@@ -0,0 +1,27 @@
+package enums
+
+type SkillCategoryEnum string
+
+const (
+	SkillCategoryLanguage  SkillCategoryEnum = "LANGUAGE"
+	SkillCategoryFramework SkillCategoryEnum = "FRAMEWORK"
+	SkillCategoryCloud     SkillCategoryEnum = "CLOUD"
+	SkillCategoryDatabase  SkillCategoryEnum = "DATABASE"
+)
+
+func (c SkillCategoryEnum) String() string {
+	return string(c)
+}
+
+func (c SkillCategoryEnum) IsValid() bool {
+	switch c {
+	case SkillCategoryLanguage, SkillCategoryFramework, SkillCategoryCloud, SkillCategoryDatabase:
+		return true
+	default:
+		return false
+	}
+}
+
+func GetAllSkillCategories() []SkillCategoryEnum {
+	return []SkillCategoryEnum{SkillCategoryLanguage, SkillCategoryFramework, SkillCategoryCloud, SkillCategoryDatabase}
+}
@@ -0,0 +1,27 @@
+package enums
+
+type SubscriptionStatusEnum string
+
+const (
+	Trial     SubscriptionStatusEnum = "TRIAL"
+	Active    SubscriptionStatusEnum = "ACTIVE"
+	PastDue   SubscriptionStatusEnum = "PAST_DUE"
+	Cancelled SubscriptionStatusEnum = "CANCELLED"
+)
+
+func (s SubscriptionStatusEnum) String() string {
+	return string(s)
+}
+
+func (s SubscriptionStatusEnum) IsValid() bool {
+	switch s {
+	case Trial, Active, PastDue, Cancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+func GetAllSubscriptionStatuses() []SubscriptionStatusEnum {
+	return []SubscriptionStatusEnum{Trial, Active, PastDue, Cancelled}
+}
@@ -0,0 +1,21 @@
+package enums
+
+type TokenPurposeEnum string
+
+const (
+	EmailVerification TokenPurposeEnum = "EMAIL_VERIFICATION"
+	PasswordReset     TokenPurposeEnum = "PASSWORD_RESET"
+)
+
+func (p TokenPurposeEnum) String() string {
+	return string(p)
+}
+
+func (p TokenPurposeEnum) IsValid() bool {
+	switch p {
+	case EmailVerification, PasswordReset:
+		return true
+	default:
+		return false
+	}
+}
@@ -19,5 +19,14 @@ type Job struct {
 	Url                     string            `json:"url" bson:"url" validate:"required"`
 	SeniorityLevel          string            `json:"seniorityLevel" bson:"seniorityLevel" validate:"required"`
 	Field                   string            `json:"field" bson:"field" validate:"required"`
+	Description             string            `json:"description,omitempty" bson:"description,omitempty"`
+	Skills                  []string          `json:"skills,omitempty" bson:"skills,omitempty"`
+	FirstSeenAt             time.Time         `json:"firstSeenAt" bson:"firstSeenAt,omitempty"`
 	ExpiresAt               time.Time         `json:"expiresAt" bson:"expiresAt"`
 }
+
+// JobRetagResult summarizes a skill re-tagging run over the stored jobs.
+type JobRetagResult struct {
+	Scanned int `json:"scanned"`
+	Updated int `json:"updated"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"jboard-go-crud/internal/models/enums"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobAlert is a queued notification about a new job. Reasons name what matched: saved search
+// names, or "skills" when the job fits the user's skills. DeliveredTo lists the channels that
+// already accepted the alert, so a retry after a partial failure only uses the others.
+type JobAlert struct {
+	ID           primitive.ObjectID       `json:"id" bson:"_id,omitempty"`
+	Username     string                   `json:"username" bson:"username"`
+	JobID        string                   `json:"jobId" bson:"jobId"`
+	Title        string                   `json:"title" bson:"title"`
+	Company      string                   `json:"company" bson:"company"`
+	Url          string                   `json:"url" bson:"url"`
+	Reasons      []string                 `json:"reasons" bson:"reasons"`
+	Frequency    enums.AlertFrequencyEnum `json:"frequency" bson:"frequency"`
+	CreatedAt    time.Time                `json:"createdAt" bson:"createdAt"`
+	DeliverAfter time.Time                `json:"deliverAfter" bson:"deliverAfter"`
+	DeliveredTo  []string                 `json:"-" bson:"deliveredTo,omitempty"`
+	Attempts     int                      `json:"-" bson:"attempts,omitempty"`
+}
+
+// AlertDigest groups the due alerts of one user into a single delivery.
+type AlertDigest struct {
+	Username string     `json:"username"`
+	Email    string     `json:"-"`
+	Alerts   []JobAlert `json:"alerts"`
+}
+
+// AlertPolicy tunes job alerts. A job fits a user's skills when its match score reaches
+// MinMatchScore; DailyDigestHour is the UTC hour FREE users receive their digest. A failed
+// delivery is retried after RetryBaseDelay, doubling on each attempt up to MaxRetryDelay.
+type AlertPolicy struct {
+	MinMatchScore   int
+	DailyDigestHour int
+	RetryBaseDelay  time.Duration
+	MaxRetryDelay   time.Duration
+}
+
+func DefaultAlertPolicy() AlertPolicy {
+	return AlertPolicy{
+		MinMatchScore:   60,
+		DailyDigestHour: 12,
+		RetryBaseDelay:  time.Minute,
+		MaxRetryDelay:   6 * time.Hour,
+	}
+}
+
+// RetryDelay returns how long to wait before the given delivery attempt, counting from 1.
+func (p AlertPolicy) RetryDelay(attempt int) time.Duration {
+	delay := p.RetryBaseDelay
+	for i := 1; i < attempt && delay < p.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxRetryDelay)
+}
+
+// NextDelivery returns when an alert queued at now should be sent for the given frequency.
+func (p AlertPolicy) NextDelivery(frequency enums.AlertFrequencyEnum, now time.Time) time.Time {
+	if frequency == enums.AlertInstant {
+		return now
+	}
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), p.DailyDigestHour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+type AlertDispatchResult struct {
+	Users  int `json:"users"`
+	Alerts int `json:"alerts"`
+	Failed int `json:"failed"`
+}
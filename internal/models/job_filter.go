@@ -0,0 +1,95 @@
+package models
+
+import (
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+)
+
+// JobFilter narrows the job listing; each non-empty list matches any of its values, ignoring case,
+// except Skills, where a job must have every listed skill. BrazilianFriendly keeps only jobs
+// open to candidates based in Brazil.
+//
+// MinSalary, SalaryCurrency and EnglishLevel come only from the user profile and are checked
+// against the posting text, since jobs store pay and language requirements as free text.
+type JobFilter struct {
+	Fields            []string `json:"fields,omitempty" bson:"fields,omitempty"`
+	SeniorityLevels   []string `json:"seniorityLevels,omitempty" bson:"seniorityLevels,omitempty"`
+	WorkplaceTypes    []string `json:"workplaceTypes,omitempty" bson:"workplaceTypes,omitempty"`
+	Skills            []string `json:"skills,omitempty" bson:"skills,omitempty"`
+	BrazilianFriendly bool     `json:"brazilianFriendly,omitempty" bson:"brazilianFriendly,omitempty"`
+
+	MinSalary      int64                  `json:"-" bson:"-"`
+	SalaryCurrency string                 `json:"-" bson:"-"`
+	EnglishLevel   enums.EnglishLevelEnum `json:"-" bson:"-"`
+}
+
+func (f JobFilter) IsEmpty() bool {
+	return len(f.Fields) == 0 && len(f.SeniorityLevels) == 0 && len(f.WorkplaceTypes) == 0 && len(f.Skills) == 0 && !f.BrazilianFriendly &&
+		!f.HasTextCriteria()
+}
+
+// HasTextCriteria reports whether the filter has criteria the database query cannot apply, so
+// the matching jobs must also pass MatchesText.
+func (f JobFilter) HasTextCriteria() bool {
+	return f.MinSalary > 0 || f.EnglishLevel != ""
+}
+
+// MatchesText applies the salary floor and English level. A job is dropped only when its pay,
+// in the filter's currency, tops out below the floor or it asks for more English than the
+// filter's level; jobs whose pay or English requirement cannot be read are kept.
+func (f JobFilter) MatchesText(job Job) bool {
+	if f.MinSalary > 0 {
+		compensation, ok := ParseCompensation(job.CompensationTierSummary)
+		if ok && strings.EqualFold(compensation.Currency, f.SalaryCurrency) && compensation.Max < float64(f.MinSalary) {
+			return false
+		}
+	}
+	if f.EnglishLevel != "" {
+		required, ok := RequiredEnglishLevel(job)
+		if ok && !f.EnglishLevel.AtLeast(required) {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalized trims and de-duplicates every list.
+func (f JobFilter) Normalized() JobFilter {
+	f.Fields = normalizeList(f.Fields)
+	f.SeniorityLevels = normalizeList(f.SeniorityLevels)
+	f.WorkplaceTypes = normalizeList(f.WorkplaceTypes)
+	f.Skills = normalizeList(f.Skills)
+	return f
+}
+
+// Matches reports whether job passes the filter, mirroring the listing query: list values
+// match ignoring case and the job must carry every skill. Skills must already be canonical.
+func (f JobFilter) Matches(job Job) bool {
+	if len(f.Fields) > 0 && !containsFold(f.Fields, job.Field) {
+		return false
+	}
+	if len(f.SeniorityLevels) > 0 && !containsFold(f.SeniorityLevels, job.SeniorityLevel) {
+		return false
+	}
+	if len(f.WorkplaceTypes) > 0 && !containsFold(f.WorkplaceTypes, job.WorkplaceType) {
+		return false
+	}
+	if f.BrazilianFriendly && !job.IsBrazilianFriendly.IsFriendly {
+		return false
+	}
+	for _, skill := range f.Skills {
+		if !containsFold(job.Skills, skill) {
+			return false
+		}
+	}
+	return f.MatchesText(job)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
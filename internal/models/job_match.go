@@ -0,0 +1,11 @@
+package models
+
+// JobMatch is a job ranked for a user. Score runs from 0 to 100: up to 70 points for the share
+// of the job's skills the user has, weighted by proficiency, and up to 30 for matching the
+// profile's seniority, preferred fields, workplace types and Brazilian-friendliness.
+type JobMatch struct {
+	Job           Job      `json:"job"`
+	Score         int      `json:"score"`
+	MatchedSkills []string `json:"matchedSkills"`
+	MissingSkills []string `json:"missingSkills"`
+}
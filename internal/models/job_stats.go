@@ -0,0 +1,20 @@
+package models
+
+// FacetCount is the number of jobs sharing one value of a listing attribute.
+type FacetCount struct {
+	Value string `json:"value" bson:"_id"`
+	Count int    `json:"count" bson:"count"`
+}
+
+// JobStats holds facet counts over the jobs matching a listing filter, most common value
+// first. Companies only lists the most common ones.
+type JobStats struct {
+	Total                  int          `json:"total"`
+	Fields                 []FacetCount `json:"fields"`
+	SeniorityLevels        []FacetCount `json:"seniorityLevels"`
+	WorkplaceTypes         []FacetCount `json:"workplaceTypes"`
+	EmploymentTypes        []FacetCount `json:"employmentTypes"`
+	Companies              []FacetCount `json:"companies"`
+	BrazilianFriendly      int          `json:"brazilianFriendly"`
+	BrazilianFriendlyRatio float64      `json:"brazilianFriendlyRatio"`
+}
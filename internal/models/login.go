@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LoginRequest struct {
+	Username  string
+	Password  string
+	IP        string
+	UserAgent string
+}
+
+// UserAttemptKey is the lockout counter key of a username.
+func UserAttemptKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+// LoginAttempt tracks consecutive failed logins for one key ("user:<name>" or "ip:<address>").
+type LoginAttempt struct {
+	Key           string    `json:"key" bson:"_id"`
+	Failures      int       `json:"failures" bson:"failures"`
+	LastFailureAt time.Time `json:"lastFailureAt" bson:"lastFailureAt"`
+	LockedUntil   time.Time `json:"lockedUntil" bson:"lockedUntil"`
+	ExpiresAt     time.Time `json:"-" bson:"expiresAt"`
+}
+
+type LoginAuditEntry struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Username  string             `json:"username" bson:"username"`
+	IP        string             `json:"ip" bson:"ip"`
+	UserAgent string             `json:"userAgent" bson:"userAgent"`
+	Success   bool               `json:"success" bson:"success"`
+	Reason    string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	Timestamp time.Time          `json:"timestamp" bson:"timestamp"`
+}
+
+// LockoutPolicy locks a key for BaseLockout once MaxAttempts failures happen within Window,
+// doubling the lock for every further failure up to MaxLockout.
+type LockoutPolicy struct {
+	MaxAttempts int
+	Window      time.Duration
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxAttempts: 5,
+		Window:      15 * time.Minute,
+		BaseLockout: time.Minute,
+		MaxLockout:  time.Hour,
+	}
+}
+
+// LockoutFor returns how long to lock a key after the given number of consecutive failures.
+func (p LockoutPolicy) LockoutFor(failures int) time.Duration {
+	if failures < p.MaxAttempts {
+		return 0
+	}
+	lockout := p.BaseLockout
+	for i := p.MaxAttempts; i < failures && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+	return lockout
+}
+
+// AccountLockedError is returned while a username or IP is locked out.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("account temporarily locked until %s", e.Until.UTC().Format(time.RFC3339))
+}
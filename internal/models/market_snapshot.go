@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MarketSnapshotDateLayout is the layout of snapshot and trend dates, one snapshot per UTC day.
+const MarketSnapshotDateLayout = "2006-01-02"
+
+// MarketSnapshot records aggregate metrics over the jobs listed on one day, so trends survive
+// the jobs themselves expiring. Breakdowns are lists rather than maps because values such as
+// "Node.js" are not safe document keys.
+type MarketSnapshot struct {
+	ID                     primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	Date                   string             `json:"date" bson:"date"`
+	TakenAt                time.Time          `json:"takenAt" bson:"takenAt"`
+	TotalJobs              int                `json:"totalJobs" bson:"totalJobs"`
+	ByField                []FacetCount       `json:"byField" bson:"byField"`
+	BySeniority            []FacetCount       `json:"bySeniority" bson:"bySeniority"`
+	BySkill                []FacetCount       `json:"bySkill" bson:"bySkill"`
+	BrazilianFriendlyShare float64            `json:"brazilianFriendlyShare" bson:"brazilianFriendlyShare"`
+	// MedianCompensation is the median yearly pay midpoint per currency, over the jobs whose
+	// compensation summary could be parsed.
+	MedianCompensation map[string]float64 `json:"medianCompensation" bson:"medianCompensation"`
+}
+
+// Trend metrics. The breakdown metrics return one series per value; the others a single one.
+const (
+	TrendMetricJobs                   = "jobs"
+	TrendMetricField                  = "field"
+	TrendMetricSeniority              = "seniority"
+	TrendMetricSkill                  = "skill"
+	TrendMetricBrazilianFriendlyShare = "brazilianFriendlyShare"
+	TrendMetricMedianCompensation     = "medianCompensation"
+)
+
+func TrendMetrics() []string {
+	return []string{TrendMetricJobs, TrendMetricField, TrendMetricSeniority, TrendMetricSkill, TrendMetricBrazilianFriendlyShare, TrendMetricMedianCompensation}
+}
+
+// TrendQuery selects a metric over an inclusive date range. Keys restricts a breakdown metric
+// to the given values; without it the most common values are returned.
+type TrendQuery struct {
+	Metric string
+	Keys   []string
+	From   time.Time
+	To     time.Time
+}
+
+type TrendPoint struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+type TrendSeries struct {
+	Key    string       `json:"key,omitempty"`
+	Points []TrendPoint `json:"points"`
+}
+
+type TrendReport struct {
+	Metric string        `json:"metric"`
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Series []TrendSeries `json:"series"`
+}
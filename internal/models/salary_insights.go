@@ -0,0 +1,64 @@
+package models
+
+import "strings"
+
+// SalaryPolicy tunes salary insights. ExchangeRates holds the USD value of one unit of each
+// currency; pay in other currencies is left out. Groups with fewer than MinSampleSize jobs are
+// withheld, and so is every percentile but the median with fewer than MinSampleSize jobs beyond
+// it, so that no single posting can be singled out.
+type SalaryPolicy struct {
+	ExchangeRates map[string]float64
+	MinSampleSize int
+}
+
+func DefaultSalaryPolicy() SalaryPolicy {
+	return SalaryPolicy{
+		ExchangeRates: map[string]float64{
+			"USD": 1,
+			"EUR": 1.08,
+			"GBP": 1.27,
+			"CAD": 0.73,
+			"AUD": 0.66,
+			"BRL": 0.18,
+		},
+		MinSampleSize: 5,
+	}
+}
+
+// Convert converts an amount between currencies through their USD rates.
+func (p SalaryPolicy) Convert(amount float64, from, to string) (float64, bool) {
+	fromRate, fromKnown := p.ExchangeRates[strings.ToUpper(from)]
+	toRate, toKnown := p.ExchangeRates[strings.ToUpper(to)]
+	if !fromKnown || !toKnown || toRate <= 0 {
+		return 0, false
+	}
+	return amount * fromRate / toRate, true
+}
+
+// SalaryPercentiles summarizes the yearly pay midpoints of a group of jobs, rounded to the
+// nearest thousand. P25, P75 and P90 are nil when the group is too small to report them.
+type SalaryPercentiles struct {
+	SampleSize int      `json:"sampleSize"`
+	P25        *float64 `json:"p25,omitempty"`
+	P50        float64  `json:"p50"`
+	P75        *float64 `json:"p75,omitempty"`
+	P90        *float64 `json:"p90,omitempty"`
+}
+
+type SalaryGroup struct {
+	Value string `json:"value"`
+	SalaryPercentiles
+}
+
+// SalaryInsights reports pay percentiles in one currency. Overall is nil when too few jobs
+// disclose pay; the breakdowns are only filled in for PREMIUM users.
+type SalaryInsights struct {
+	Currency            string             `json:"currency"`
+	MinSampleSize       int                `json:"minSampleSize"`
+	Overall             *SalaryPercentiles `json:"overall"`
+	Detailed            bool               `json:"detailed"`
+	ByField             []SalaryGroup      `json:"byField,omitempty"`
+	BySeniority         []SalaryGroup      `json:"bySeniority,omitempty"`
+	ByWorkplaceType     []SalaryGroup      `json:"byWorkplaceType,omitempty"`
+	ByBrazilianFriendly []SalaryGroup      `json:"byBrazilianFriendly,omitempty"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxSavedSearchNameLength = 100
+	maxSavedSearchListSize   = 20
+)
+
+// SavedSearch is a named job listing filter owned by a user. LastRunAt is set each time the
+// search is executed, so the next run can flag the jobs first seen since then.
+type SavedSearch struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Username  string             `json:"username" bson:"username"`
+	Name      string             `json:"name" bson:"name"`
+	Filter    JobFilter          `json:"filter" bson:"filter"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+	LastRunAt *time.Time         `json:"lastRunAt,omitempty" bson:"lastRunAt,omitempty"`
+}
+
+type SavedSearchRequest struct {
+	Name   string    `json:"name"`
+	Filter JobFilter `json:"filter"`
+}
+
+// Normalized trims the name and the filter values.
+func (r SavedSearchRequest) Normalized() SavedSearchRequest {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Filter = r.Filter.Normalized()
+	return r
+}
+
+// FieldErrors validates a normalized request.
+func (r SavedSearchRequest) FieldErrors() []FieldError {
+	var problems []FieldError
+	if r.Name == "" {
+		problems = append(problems, FieldError{Field: "name", Message: "is required"})
+	} else if len(r.Name) > maxSavedSearchNameLength {
+		problems = append(problems, FieldError{Field: "name", Message: fmt.Sprintf("must be at most %d characters", maxSavedSearchNameLength)})
+	}
+	if r.Filter.IsEmpty() {
+		problems = append(problems, FieldError{Field: "filter", Message: "must set at least one of fields, seniorityLevels, workplaceTypes, skills"})
+	}
+	for _, list := range []struct {
+		name   string
+		values []string
+	}{
+		{"filter.fields", r.Filter.Fields}, {"filter.seniorityLevels", r.Filter.SeniorityLevels},
+		{"filter.workplaceTypes", r.Filter.WorkplaceTypes}, {"filter.skills", r.Filter.Skills},
+	} {
+		if len(list.values) > maxSavedSearchListSize {
+			problems = append(problems, FieldError{Field: list.name, Message: fmt.Sprintf("must have at most %d entries", maxSavedSearchListSize)})
+		}
+	}
+	return problems
+}
+
+// SavedSearchPolicy caps the number of saved searches per role.
+type SavedSearchPolicy struct {
+	FreeLimit    int
+	PremiumLimit int
+}
+
+func DefaultSavedSearchPolicy() SavedSearchPolicy {
+	return SavedSearchPolicy{
+		FreeLimit:    3,
+		PremiumLimit: 50,
+	}
+}
+
+func (p SavedSearchPolicy) Limit(role enums.RoleEnum) int {
+	if role == enums.Premium {
+		return p.PremiumLimit
+	}
+	return p.FreeLimit
+}
+
+// SavedSearchJob is a job returned by a saved search; New marks jobs first seen after the
+// previous run, or every job on the first run.
+type SavedSearchJob struct {
+	Job
+	New bool `json:"new"`
+}
+
+type SavedSearchResults struct {
+	Search   SavedSearch      `json:"search"`
+	NewCount int              `json:"newCount"`
+	Jobs     []SavedSearchJob `json:"jobs"`
+}
@@ -1,14 +1,117 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxSkillYearsOfExperience = 60
+	minSkillLastUsedYear      = 1970
+
+	// MaxSkillsPerUser bounds the skill list of a single user.
+	MaxSkillsPerUser = 100
+)
 
 type Skill struct {
 	ID       primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Username string             `json:"username" bson:"username"`
-	Skills   []string           `json:"skills" bson:"skills"`
+	Skills   []SkillEntry       `json:"skills" bson:"skills"`
+}
+
+// SkillEntry is one skill of a user. Entries migrated from the old string list only carry
+// the name until the user fills in the rest.
+type SkillEntry struct {
+	Name              string                `json:"name" bson:"name"`
+	Proficiency       enums.ProficiencyEnum `json:"proficiency,omitempty" bson:"proficiency,omitempty"`
+	YearsOfExperience int                   `json:"yearsOfExperience,omitempty" bson:"yearsOfExperience,omitempty"`
+	LastUsedYear      int                   `json:"lastUsedYear,omitempty" bson:"lastUsedYear,omitempty"`
 }
 
 type SkillRequest struct {
-	Username string `json:"username"`
-	Skill    string `json:"skill"`
+	Username          string                `json:"username"`
+	Skill             string                `json:"skill"`
+	Proficiency       enums.ProficiencyEnum `json:"proficiency,omitempty"`
+	YearsOfExperience int                   `json:"yearsOfExperience,omitempty"`
+	LastUsedYear      int                   `json:"lastUsedYear,omitempty"`
+}
+
+// Entry returns the skill entry described by the request.
+func (r SkillRequest) Entry() SkillEntry {
+	return SkillEntry{
+		Name:              r.Skill,
+		Proficiency:       r.Proficiency,
+		YearsOfExperience: r.YearsOfExperience,
+		LastUsedYear:      r.LastUsedYear,
+	}
+}
+
+// Normalized trims the skill name and upper-cases the proficiency.
+func (r SkillRequest) Normalized() SkillRequest {
+	r.Skill = strings.TrimSpace(r.Skill)
+	r.Proficiency = enums.ProficiencyEnum(strings.ToUpper(strings.TrimSpace(string(r.Proficiency))))
+	return r
+}
+
+// Validate checks the optional depth fields; currentYear bounds lastUsedYear.
+func (r SkillRequest) Validate(currentYear int) error {
+	fieldErrors := r.Entry().FieldErrors("", currentYear)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	problems := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		problems = append(problems, fieldError.Field+" "+fieldError.Message)
+	}
+	return errors.New("invalid skill: " + strings.Join(problems, ", "))
+}
+
+// Normalized trims the name and upper-cases the proficiency.
+func (e SkillEntry) Normalized() SkillEntry {
+	e.Name = strings.TrimSpace(e.Name)
+	e.Proficiency = enums.ProficiencyEnum(strings.ToUpper(strings.TrimSpace(string(e.Proficiency))))
+	return e
+}
+
+// FieldErrors validates the depth fields, naming each offending field with the given prefix.
+func (e SkillEntry) FieldErrors(prefix string, currentYear int) []FieldError {
+	var problems []FieldError
+	if e.Proficiency != "" && !e.Proficiency.IsValid() {
+		problems = append(problems, FieldError{Field: prefix + "proficiency", Message: "must be one of BEGINNER, INTERMEDIATE, ADVANCED, EXPERT"})
+	}
+	if e.YearsOfExperience < 0 || e.YearsOfExperience > maxSkillYearsOfExperience {
+		problems = append(problems, FieldError{Field: prefix + "yearsOfExperience", Message: fmt.Sprintf("must be between 0 and %d", maxSkillYearsOfExperience)})
+	}
+	if e.LastUsedYear != 0 && (e.LastUsedYear < minSkillLastUsedYear || e.LastUsedYear > currentYear) {
+		problems = append(problems, FieldError{Field: prefix + "lastUsedYear", Message: fmt.Sprintf("must be between %d and %d", minSkillLastUsedYear, currentYear)})
+	}
+	return problems
+}
+
+// Combined merges two entries for the same skill, keeping the highest proficiency, experience
+// and last-used year. The name of e is kept.
+func (e SkillEntry) Combined(other SkillEntry) SkillEntry {
+	if other.Proficiency.Rank() > e.Proficiency.Rank() {
+		e.Proficiency = other.Proficiency
+	}
+	e.YearsOfExperience = max(e.YearsOfExperience, other.YearsOfExperience)
+	e.LastUsedYear = max(e.LastUsedYear, other.LastUsedYear)
+	return e
+}
+
+// SkillSetRequest carries several skills for a bulk replace or batch add.
+type SkillSetRequest struct {
+	Skills []SkillEntry `json:"skills"`
+}
+
+// SkillSetChanges reports how a bulk operation changed a user's skills, by skill name.
+type SkillSetChanges struct {
+	Added   []string     `json:"added"`
+	Removed []string     `json:"removed"`
+	Updated []string     `json:"updated"`
+	Skills  []SkillEntry `json:"skills"`
 }
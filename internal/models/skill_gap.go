@@ -0,0 +1,19 @@
+package models
+
+// SkillGap is a skill the user lacks. Demand counts the jobs in scope asking for it; Unlocks
+// counts those where it is the only skill the user is missing.
+type SkillGap struct {
+	Name    string `json:"name"`
+	Demand  int    `json:"demand"`
+	Unlocks int    `json:"unlocks"`
+}
+
+// SkillGapReport compares a user's skills with the jobs in their preferred fields and
+// seniority. CoveredJobs counts the jobs for which the user already has every skill.
+type SkillGapReport struct {
+	Fields      []string   `json:"fields,omitempty"`
+	Seniority   string     `json:"seniority,omitempty"`
+	JobsInScope int        `json:"jobsInScope"`
+	CoveredJobs int        `json:"coveredJobs"`
+	Gaps        []SkillGap `json:"gaps"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "jboard-go-crud/internal/models/enums"
+
+// SkillSuggestion is a canonical skill offered by the autocomplete, with the counts used to
+// rank it.
+type SkillSuggestion struct {
+	Name     string                  `json:"name"`
+	Category enums.SkillCategoryEnum `json:"category"`
+	Users    int                     `json:"users"`
+	Jobs     int                     `json:"jobs"`
+}
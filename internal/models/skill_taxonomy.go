@@ -0,0 +1,167 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models/enums"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const maxSkillAliases = 50
+
+// CanonicalSkill is an entry of the skill taxonomy. Aliases hold the lookup keys (see SkillKey)
+// of every spelling that resolves to this skill, the key of the name included.
+// AmbiguousAliases lists spellings that are also common words ("Go", "Spring", "Java"): they
+// still resolve filters, but tag a job only when its text uses that exact spelling.
+type CanonicalSkill struct {
+	Key              string                  `json:"key" bson:"_id"`
+	Name             string                  `json:"name" bson:"name"`
+	Category         enums.SkillCategoryEnum `json:"category" bson:"category"`
+	Aliases          []string                `json:"aliases" bson:"aliases"`
+	AmbiguousAliases []string                `json:"ambiguousAliases,omitempty" bson:"ambiguousAliases,omitempty"`
+}
+
+type SkillMergeRequest struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
+
+type SkillMergeResult struct {
+	Target       CanonicalSkill `json:"target"`
+	UsersUpdated int            `json:"usersUpdated"`
+}
+
+// SkillKey folds the spellings of a skill name together: "GoLang", "go-lang" and "go lang"
+// all become "golang". Symbols such as "+" and "#" are kept so "C++" and "C#" stay apart.
+func SkillKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch r {
+		case ' ', '\t', '-', '_', '.':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Normalized trims the name, derives the key and turns the aliases into sorted, unique keys.
+func (c CanonicalSkill) Normalized() CanonicalSkill {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Key = SkillKey(c.Name)
+	c.Category = enums.SkillCategoryEnum(strings.ToUpper(strings.TrimSpace(string(c.Category))))
+
+	keys := map[string]bool{}
+	if c.Key != "" {
+		keys[c.Key] = true
+	}
+	for _, alias := range c.Aliases {
+		if key := SkillKey(alias); key != "" {
+			keys[key] = true
+		}
+	}
+	var ambiguous []string
+	for _, spelling := range c.AmbiguousAliases {
+		spelling = strings.TrimSpace(spelling)
+		if key := SkillKey(spelling); key != "" && !slices.Contains(ambiguous, spelling) {
+			keys[key] = true
+			ambiguous = append(ambiguous, spelling)
+		}
+	}
+	sort.Strings(ambiguous)
+	c.AmbiguousAliases = ambiguous
+	c.Aliases = make([]string, 0, len(keys))
+	for key := range keys {
+		c.Aliases = append(c.Aliases, key)
+	}
+	sort.Strings(c.Aliases)
+	return c
+}
+
+// IsAmbiguousKey reports whether key belongs to one of the ambiguous spellings, so free text
+// must use the spelling itself rather than any form folding to the key.
+func (c CanonicalSkill) IsAmbiguousKey(key string) bool {
+	for _, spelling := range c.AmbiguousAliases {
+		if SkillKey(spelling) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CanonicalSkill) Validate() error {
+	var problems []string
+	if c.Key == "" {
+		problems = append(problems, "name cannot be empty")
+	}
+	if !c.Category.IsValid() {
+		problems = append(problems, "category must be one of LANGUAGE, FRAMEWORK, CLOUD, DATABASE")
+	}
+	if len(c.Aliases) > maxSkillAliases {
+		problems = append(problems, fmt.Sprintf("aliases must have at most %d entries", maxSkillAliases))
+	}
+	for _, spelling := range c.AmbiguousAliases {
+		if strings.ContainsFunc(spelling, unicode.IsSpace) {
+			problems = append(problems, "ambiguous aliases must be single words")
+			break
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("invalid skill: " + strings.Join(problems, ", "))
+}
+
+// HasAlias reports whether name resolves to this skill.
+func (c CanonicalSkill) HasAlias(name string) bool {
+	key := SkillKey(name)
+	for _, alias := range c.Aliases {
+		if alias == key {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSkillTaxonomy is seeded into an empty taxonomy collection on startup.
+func DefaultSkillTaxonomy() []CanonicalSkill {
+	skills := []CanonicalSkill{
+		{Name: "Go", Category: enums.SkillCategoryLanguage, Aliases: []string{"golang", "go lang"}, AmbiguousAliases: []string{"Go"}},
+		{Name: "Java", Category: enums.SkillCategoryLanguage, AmbiguousAliases: []string{"Java"}},
+		{Name: "Kotlin", Category: enums.SkillCategoryLanguage},
+		{Name: "Python", Category: enums.SkillCategoryLanguage, Aliases: []string{"python3"}},
+		{Name: "JavaScript", Category: enums.SkillCategoryLanguage, Aliases: []string{"ecmascript"}},
+		{Name: "TypeScript", Category: enums.SkillCategoryLanguage},
+		{Name: "C#", Category: enums.SkillCategoryLanguage, Aliases: []string{"csharp", "c sharp"}},
+		{Name: "C++", Category: enums.SkillCategoryLanguage, Aliases: []string{"cpp"}},
+		{Name: "Ruby", Category: enums.SkillCategoryLanguage},
+		{Name: "Rust", Category: enums.SkillCategoryLanguage, AmbiguousAliases: []string{"Rust"}},
+		{Name: "PHP", Category: enums.SkillCategoryLanguage},
+		{Name: "React", Category: enums.SkillCategoryFramework, Aliases: []string{"react.js", "reactjs"}, AmbiguousAliases: []string{"React"}},
+		{Name: "Angular", Category: enums.SkillCategoryFramework, Aliases: []string{"angularjs"}},
+		{Name: "Vue.js", Category: enums.SkillCategoryFramework, Aliases: []string{"vue"}},
+		{Name: "Node.js", Category: enums.SkillCategoryFramework, AmbiguousAliases: []string{"Node"}},
+		{Name: "Spring Boot", Category: enums.SkillCategoryFramework, AmbiguousAliases: []string{"Spring"}},
+		{Name: "Django", Category: enums.SkillCategoryFramework},
+		{Name: "Ruby on Rails", Category: enums.SkillCategoryFramework, Aliases: []string{"ror"}, AmbiguousAliases: []string{"Rails"}},
+		{Name: ".NET", Category: enums.SkillCategoryFramework, Aliases: []string{"dotnet", ".net core", "asp.net"}, AmbiguousAliases: []string{".NET", ".Net"}},
+		{Name: "AWS", Category: enums.SkillCategoryCloud, Aliases: []string{"amazon web services"}},
+		{Name: "Azure", Category: enums.SkillCategoryCloud, Aliases: []string{"microsoft azure"}},
+		{Name: "Google Cloud", Category: enums.SkillCategoryCloud, Aliases: []string{"gcp", "google cloud platform"}},
+		{Name: "Kubernetes", Category: enums.SkillCategoryCloud, Aliases: []string{"k8s"}},
+		{Name: "Docker", Category: enums.SkillCategoryCloud},
+		{Name: "PostgreSQL", Category: enums.SkillCategoryDatabase, Aliases: []string{"postgres", "psql"}},
+		{Name: "MySQL", Category: enums.SkillCategoryDatabase},
+		{Name: "MongoDB", Category: enums.SkillCategoryDatabase, Aliases: []string{"mongo"}},
+		{Name: "Redis", Category: enums.SkillCategoryDatabase},
+		{Name: "SQL Server", Category: enums.SkillCategoryDatabase, Aliases: []string{"mssql", "microsoft sql server"}},
+		{Name: "Oracle Database", Category: enums.SkillCategoryDatabase, AmbiguousAliases: []string{"Oracle"}},
+	}
+	for i := range skills {
+		skills[i] = skills[i].Normalized()
+	}
+	return skills
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"jboard-go-crud/internal/models/enums"
+	"time"
+)
+
+type Subscription struct {
+	Plan        enums.RoleEnum               `json:"plan" bson:"plan"`
+	Status      enums.SubscriptionStatusEnum `json:"status" bson:"status"`
+	StartedAt   time.Time                    `json:"startedAt" bson:"startedAt"`
+	ExpiresAt   time.Time                    `json:"expiresAt" bson:"expiresAt"`
+	UpdatedAt   time.Time                    `json:"updatedAt" bson:"updatedAt"`
+	LastEventID string                       `json:"-" bson:"lastEventId,omitempty"`
+	LastEventAt time.Time                    `json:"-" bson:"lastEventAt,omitempty"`
+}
+
+// PaymentEvent is the provider-agnostic shape accepted by the payments webhook.
+type PaymentEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Username   string    `json:"username"`
+	PeriodEnd  time.Time `json:"periodEnd"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+const (
+	PaymentEventTrialStarted          = "subscription.trial_started"
+	PaymentEventSubscriptionActivated = "subscription.activated"
+	PaymentEventInvoicePaid           = "invoice.paid"
+	PaymentEventPaymentFailed         = "invoice.payment_failed"
+	PaymentEventSubscriptionCancelled = "subscription.cancelled"
+)
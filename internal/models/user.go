@@ -6,8 +6,12 @@ import (
 )
 
 type User struct {
-	ID       primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Username string             `json:"username" bson:"username"`
-	Password string             `json:"password" bson:"password"`
-	Role     enums.RoleEnum     `json:"role" bson:"role"`
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Username      string             `json:"username" bson:"username"`
+	Password      string             `json:"password" bson:"password"`
+	Role          enums.RoleEnum     `json:"role" bson:"role"`
+	Email         string             `json:"email,omitempty" bson:"email,omitempty"`
+	EmailVerified bool               `json:"emailVerified" bson:"emailVerified"`
+	Profile       *UserProfile       `json:"profile,omitempty" bson:"profile,omitempty"`
+	Subscription  *Subscription      `json:"subscription,omitempty" bson:"subscription,omitempty"`
 }
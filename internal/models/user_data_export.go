@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// UserDataExport holds everything stored about a user, as returned for LGPD/GDPR access requests.
+type UserDataExport struct {
+	GeneratedAt time.Time      `json:"generatedAt"`
+	User        User           `json:"user"`
+	Data        map[string]any `json:"data"`
+}
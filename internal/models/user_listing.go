@@ -0,0 +1,45 @@
+package models
+
+import (
+	"jboard-go-crud/internal/models/enums"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserFilter narrows the admin user listing. Zero values mean "no restriction".
+type UserFilter struct {
+	Role           enums.RoleEnum
+	UsernamePrefix string
+	CreatedFrom    time.Time
+	CreatedTo      time.Time
+	Page           int
+	PageSize       int
+}
+
+// UserSummary is the admin view of a user; it never carries the password.
+type UserSummary struct {
+	ID           primitive.ObjectID `json:"id"`
+	Username     string             `json:"username"`
+	Role         enums.RoleEnum     `json:"role"`
+	Subscription *Subscription      `json:"subscription,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt"`
+}
+
+type UserPage struct {
+	Items      []UserSummary            `json:"items"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"pageSize"`
+	Total      int64                    `json:"total"`
+	RoleCounts map[enums.RoleEnum]int64 `json:"roleCounts"`
+}
+
+func NewUserSummary(user User) UserSummary {
+	return UserSummary{
+		ID:           user.ID,
+		Username:     user.Username,
+		Role:         user.Role,
+		Subscription: user.Subscription,
+		CreatedAt:    user.ID.Timestamp().UTC(),
+	}
+}
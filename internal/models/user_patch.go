@@ -0,0 +1,8 @@
+package models
+
+// UserPatchActor identifies who sends a user patch. Admin patches arrive through the
+// gateway-protected /v1/admin routes and may change fields users cannot change themselves.
+type UserPatchActor struct {
+	Username string
+	Admin    bool
+}
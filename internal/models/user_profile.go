@@ -0,0 +1,196 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+	"time"
+)
+
+const (
+	maxProfileTextLength = 100
+	maxProfileListSize   = 20
+)
+
+type UserProfile struct {
+	DisplayName     string                 `json:"displayName,omitempty" bson:"displayName,omitempty"`
+	Location        string                 `json:"location,omitempty" bson:"location,omitempty"`
+	Timezone        string                 `json:"timezone,omitempty" bson:"timezone,omitempty"`
+	PreferredFields []string               `json:"preferredFields,omitempty" bson:"preferredFields,omitempty"`
+	Seniority       string                 `json:"seniority,omitempty" bson:"seniority,omitempty"`
+	WorkplaceTypes  []string               `json:"workplaceTypes,omitempty" bson:"workplaceTypes,omitempty"`
+	SalaryFloor     int64                  `json:"salaryFloor,omitempty" bson:"salaryFloor,omitempty"`
+	SalaryCurrency  string                 `json:"salaryCurrency,omitempty" bson:"salaryCurrency,omitempty"`
+	EnglishLevel    enums.EnglishLevelEnum `json:"englishLevel,omitempty" bson:"englishLevel,omitempty"`
+	// BrazilianFriendly marks a preference for jobs open to candidates based in Brazil.
+	BrazilianFriendly bool `json:"brazilianFriendly,omitempty" bson:"brazilianFriendly,omitempty"`
+}
+
+// UserProfilePatch carries a partial profile update: nil fields are left untouched, while
+// empty values clear the stored one.
+type UserProfilePatch struct {
+	DisplayName       *string                 `json:"displayName"`
+	Location          *string                 `json:"location"`
+	Timezone          *string                 `json:"timezone"`
+	PreferredFields   *[]string               `json:"preferredFields"`
+	Seniority         *string                 `json:"seniority"`
+	WorkplaceTypes    *[]string               `json:"workplaceTypes"`
+	SalaryFloor       *int64                  `json:"salaryFloor"`
+	SalaryCurrency    *string                 `json:"salaryCurrency"`
+	EnglishLevel      *enums.EnglishLevelEnum `json:"englishLevel"`
+	BrazilianFriendly *bool                   `json:"brazilianFriendly"`
+}
+
+// Apply returns a copy of profile with the patch applied and free text normalized.
+func (p UserProfilePatch) Apply(profile UserProfile) UserProfile {
+	if p.DisplayName != nil {
+		profile.DisplayName = *p.DisplayName
+	}
+	if p.Location != nil {
+		profile.Location = *p.Location
+	}
+	if p.Timezone != nil {
+		profile.Timezone = *p.Timezone
+	}
+	if p.PreferredFields != nil {
+		profile.PreferredFields = *p.PreferredFields
+	}
+	if p.Seniority != nil {
+		profile.Seniority = *p.Seniority
+	}
+	if p.WorkplaceTypes != nil {
+		profile.WorkplaceTypes = *p.WorkplaceTypes
+	}
+	if p.SalaryFloor != nil {
+		profile.SalaryFloor = *p.SalaryFloor
+	}
+	if p.SalaryCurrency != nil {
+		profile.SalaryCurrency = *p.SalaryCurrency
+	}
+	if p.EnglishLevel != nil {
+		profile.EnglishLevel = *p.EnglishLevel
+	}
+	if p.BrazilianFriendly != nil {
+		profile.BrazilianFriendly = *p.BrazilianFriendly
+	}
+	return profile.Normalized()
+}
+
+// Normalized trims free text, de-duplicates lists and upper-cases codes.
+func (p UserProfile) Normalized() UserProfile {
+	p.DisplayName = strings.TrimSpace(p.DisplayName)
+	p.Location = strings.TrimSpace(p.Location)
+	p.Timezone = strings.TrimSpace(p.Timezone)
+	p.PreferredFields = normalizeList(p.PreferredFields)
+	p.Seniority = strings.TrimSpace(p.Seniority)
+	p.WorkplaceTypes = normalizeList(p.WorkplaceTypes)
+	p.SalaryCurrency = strings.ToUpper(strings.TrimSpace(p.SalaryCurrency))
+	p.EnglishLevel = enums.EnglishLevelEnum(strings.ToUpper(strings.TrimSpace(string(p.EnglishLevel))))
+	return p
+}
+
+// Validate returns an error listing every invalid field.
+func (p UserProfile) Validate() error {
+	fieldErrors := p.FieldErrors("")
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	problems := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		problems = append(problems, fieldError.Field+" "+fieldError.Message)
+	}
+	return errors.New("invalid profile: " + strings.Join(problems, ", "))
+}
+
+// FieldErrors validates the profile, naming each offending field with the given prefix.
+func (p UserProfile) FieldErrors(prefix string) []FieldError {
+	var problems []FieldError
+	add := func(field, message string) {
+		problems = append(problems, FieldError{Field: prefix + field, Message: message})
+	}
+
+	for _, text := range []struct{ name, value string }{
+		{"displayName", p.DisplayName}, {"location", p.Location}, {"seniority", p.Seniority},
+	} {
+		if len(text.value) > maxProfileTextLength {
+			add(text.name, fmt.Sprintf("must be at most %d characters", maxProfileTextLength))
+		}
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			add("timezone", "must be an IANA time zone such as America/Sao_Paulo")
+		}
+	}
+	for _, list := range []struct {
+		name   string
+		values []string
+	}{
+		{"preferredFields", p.PreferredFields}, {"workplaceTypes", p.WorkplaceTypes},
+	} {
+		if len(list.values) > maxProfileListSize {
+			add(list.name, fmt.Sprintf("must have at most %d entries", maxProfileListSize))
+		}
+		for _, value := range list.values {
+			if len(value) > maxProfileTextLength {
+				add(list.name, fmt.Sprintf("entries must be at most %d characters", maxProfileTextLength))
+				break
+			}
+		}
+	}
+	if p.SalaryFloor < 0 {
+		add("salaryFloor", "must not be negative")
+	}
+	if p.SalaryCurrency != "" && !isCurrencyCode(p.SalaryCurrency) {
+		add("salaryCurrency", "must be a 3-letter ISO 4217 code")
+	}
+	if p.SalaryFloor > 0 && p.SalaryCurrency == "" {
+		add("salaryCurrency", "is required with salaryFloor")
+	}
+	if p.EnglishLevel != "" && !p.EnglishLevel.IsValid() {
+		add("englishLevel", "must be one of BASIC, INTERMEDIATE, ADVANCED, FLUENT, NATIVE")
+	}
+	return problems
+}
+
+// JobFilter derives the default job listing filter from the profile preferences.
+func (p UserProfile) JobFilter() JobFilter {
+	filter := JobFilter{
+		Fields:         p.PreferredFields,
+		WorkplaceTypes: p.WorkplaceTypes,
+		MinSalary:      p.SalaryFloor,
+		SalaryCurrency: p.SalaryCurrency,
+		EnglishLevel:   p.EnglishLevel,
+	}
+	if p.Seniority != "" {
+		filter.SeniorityLevels = []string{p.Seniority}
+	}
+	return filter
+}
+
+func normalizeList(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"jboard-go-crud/internal/models/enums"
+	"time"
+)
+
+// UserToken is a single-use secret mailed to a user. Only the SHA-256 hash of the secret is
+// stored, so a leaked collection cannot be replayed.
+type UserToken struct {
+	Hash      string                 `json:"-" bson:"_id"`
+	Username  string                 `json:"username" bson:"username"`
+	Email     string                 `json:"email" bson:"email"`
+	Purpose   enums.TokenPurposeEnum `json:"purpose" bson:"purpose"`
+	CreatedAt time.Time              `json:"createdAt" bson:"createdAt"`
+	ExpiresAt time.Time              `json:"expiresAt" bson:"expiresAt"`
+}
+
+type TokenPolicy struct {
+	VerificationTTL  time.Duration
+	PasswordResetTTL time.Duration
+}
+
+func DefaultTokenPolicy() TokenPolicy {
+	return TokenPolicy{
+		VerificationTTL:  24 * time.Hour,
+		PasswordResetTTL: time.Hour,
+	}
+}
+
+func (p TokenPolicy) TTL(purpose enums.TokenPurposeEnum) time.Duration {
+	if purpose == enums.PasswordReset {
+		return p.PasswordResetTTL
+	}
+	return p.VerificationTTL
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError reports every offending field of a request at once.
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s %s", field.Field, field.Message))
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+// ForbiddenError is returned when the caller may not act on a resource or change some of its fields.
+type ForbiddenError struct {
+	Reason string   `json:"error"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+func (e *ForbiddenError) Error() string {
+	if len(e.Fields) == 0 {
+		return "forbidden: " + e.Reason
+	}
+	return fmt.Sprintf("forbidden: %s (%s)", e.Reason, strings.Join(e.Fields, ", "))
+}
@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"jboard-go-crud/internal/mailer"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+)
+
+// EmailChannel mails the digest to the user's address. Users without an email on file are
+// skipped rather than failed, so they do not block the other channels.
+type EmailChannel struct {
+	mailer mailer.Mailer
+}
+
+func NewEmailChannel(m mailer.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: m}
+}
+
+func (c *EmailChannel) Name() string {
+	return "email"
+}
+
+func (c *EmailChannel) Deliver(ctx context.Context, digest models.AlertDigest) error {
+	if digest.Email == "" {
+		log.Printf("No email on file for %s, skipping email alert", digest.Username)
+		return nil
+	}
+
+	subject := "A new job matches your alerts"
+	if len(digest.Alerts) > 1 {
+		subject = fmt.Sprintf("%d new jobs match your alerts", len(digest.Alerts))
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", digest.Username)
+	for _, alert := range digest.Alerts {
+		fmt.Fprintf(&body, "%s at %s\n%s\nMatched: %s\n\n", alert.Title, alert.Company, alert.Url, strings.Join(alert.Reasons, ", "))
+	}
+
+	return c.mailer.Send(ctx, mailer.Message{To: digest.Email, Subject: subject, Body: body.String()})
+}
@@ -0,0 +1,35 @@
+package notifier
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"sync"
+)
+
+// MemoryChannel keeps delivered digests in memory, for tests.
+type MemoryChannel struct {
+	mu      sync.Mutex
+	digests []models.AlertDigest
+}
+
+func NewMemoryChannel() *MemoryChannel {
+	return &MemoryChannel{}
+}
+
+func (c *MemoryChannel) Name() string {
+	return "memory"
+}
+
+func (c *MemoryChannel) Deliver(_ context.Context, digest models.AlertDigest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.digests = append(c.digests, digest)
+	return nil
+}
+
+// Digests returns a copy of every digest delivered so far.
+func (c *MemoryChannel) Digests() []models.AlertDigest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]models.AlertDigest(nil), c.digests...)
+}
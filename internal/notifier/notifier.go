@@ -0,0 +1,13 @@
+package notifier
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+)
+
+// Channel delivers a digest of job alerts to one user. Implementations must be safe for
+// concurrent use.
+type Channel interface {
+	Name() string
+	Deliver(ctx context.Context, digest models.AlertDigest) error
+}
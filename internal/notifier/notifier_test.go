@@ -0,0 +1,112 @@
+package notifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"jboard-go-crud/internal/mailer"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testDigest() models.AlertDigest {
+	return models.AlertDigest{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Alerts: []models.JobAlert{
+			{JobID: "job-1", Title: "Go Developer", Company: "Acme", Url: "https://example.com/1", Reasons: []string{"Go remote"}},
+			{JobID: "job-2", Title: "SRE", Company: "Initech", Url: "https://example.com/2", Reasons: []string{"skills"}},
+		},
+	}
+}
+
+func TestEmailChannel_Deliver(t *testing.T) {
+	m := mailer.NewMemoryMailer()
+	channel := NewEmailChannel(m)
+
+	if err := channel.Deliver(context.Background(), testDigest()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	messages := m.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if messages[0].To != "test@example.com" || messages[0].Subject != "2 new jobs match your alerts" {
+		t.Errorf("Unexpected message: %+v", messages[0])
+	}
+	for _, expected := range []string{"Go Developer at Acme", "https://example.com/2", "Matched: Go remote"} {
+		if !strings.Contains(messages[0].Body, expected) {
+			t.Errorf("Expected body to contain %q", expected)
+		}
+	}
+}
+
+func TestEmailChannel_Deliver_SkipsWithoutEmail(t *testing.T) {
+	m := mailer.NewMemoryMailer()
+	digest := testDigest()
+	digest.Email = ""
+
+	if err := NewEmailChannel(m).Deliver(context.Background(), digest); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(m.Messages()) != 0 {
+		t.Errorf("Expected no message to be sent")
+	}
+}
+
+func TestWebhookChannel_Deliver_Signed(t *testing.T) {
+	var gotSignature string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Webhook-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookChannel(server.URL, "secret").Deliver(context.Background(), testDigest()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(gotBody)
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); gotSignature != expected {
+		t.Errorf("Expected signature %s, got %s", expected, gotSignature)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("Expected JSON body, got %v", err)
+	}
+	if _, leaked := payload["Email"]; leaked {
+		t.Errorf("Expected email to stay out of the webhook payload")
+	}
+}
+
+func TestWebhookChannel_Deliver_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewWebhookChannel(server.URL, "").Deliver(context.Background(), testDigest())
+	if err == nil || !strings.Contains(err.Error(), "status 500") {
+		t.Errorf("Expected status error, got %v", err)
+	}
+}
+
+func TestMemoryChannel_Deliver(t *testing.T) {
+	channel := NewMemoryChannel()
+
+	_ = channel.Deliver(context.Background(), testDigest())
+
+	if digests := channel.Digests(); len(digests) != 1 || digests[0].Username != "testuser" {
+		t.Errorf("Unexpected digests: %+v", digests)
+	}
+}
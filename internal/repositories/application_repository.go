@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ApplicationRepository stores job applications, one per user and job URL. Every lookup is
+// scoped to the owner.
+type ApplicationRepository interface {
+	UserDataStore
+	Create(ctx context.Context, application models.Application) (models.Application, error)
+	FindByUsername(ctx context.Context, username string) ([]models.Application, error)
+	FindByID(ctx context.Context, username, id string) (models.Application, bool, error)
+	FindDueReminders(ctx context.Context, username string, until time.Time) ([]models.Application, error)
+	Replace(ctx context.Context, current, updated models.Application) (bool, error)
+	Delete(ctx context.Context, username, id string) (bool, error)
+}
+
+type mongoApplicationRepository struct {
+	database string
+}
+
+func NewApplicationRepository(client *mongo.Client, dbName, collectionName string) ApplicationRepository {
+	log.Printf("Creating new ApplicationRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoApplicationRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoApplicationRepository) getCollection() *mongo.Collection {
+	return config.GetApplicationsCollection(m.database)
+}
+
+func (m *mongoApplicationRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring unique index on applications username and jobUrl fields...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection when ensuring indexes")
+		return errors.New("failed to get applications getCollection")
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}, {Key: "jobUrl", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetName("username_jobUrl_unique").
+			SetCollation(usernameCollation),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create applications index: %v", err)
+		return err
+	}
+
+	log.Printf("Applications index created successfully")
+	return nil
+}
+
+func (m *mongoApplicationRepository) Create(ctx context.Context, application models.Application) (models.Application, error) {
+	log.Printf("Repository Create called for application to %s of username: %s", application.JobUrl, application.Username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in Create")
+		return models.Application{}, errors.New("failed to get applications getCollection")
+	}
+
+	application.ID = primitive.NewObjectID()
+	if _, err := coll.InsertOne(ctx, application); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("ERROR: Application to %s already exists for username: %s", application.JobUrl, application.Username)
+			return models.Application{}, &models.ConflictError{Field: "jobUrl", Value: application.JobUrl}
+		}
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for application of %s - treating as success since data was written to database", application.Username)
+			return application, nil
+		}
+		log.Printf("ERROR: Failed to insert application for %s: %v", application.Username, err)
+		return models.Application{}, err
+	}
+
+	return application, nil
+}
+
+func (m *mongoApplicationRepository) FindByUsername(ctx context.Context, username string) ([]models.Application, error) {
+	log.Printf("Repository FindByUsername called for applications of username: %s", username)
+	return m.find(ctx, bson.M{"username": username}, bson.D{{Key: "updatedAt", Value: -1}})
+}
+
+func (m *mongoApplicationRepository) FindByID(ctx context.Context, username, id string) (models.Application, bool, error) {
+	log.Printf("Repository FindByID called for application %s of username: %s", id, username)
+
+	filter, err := applicationFilter(username, id)
+	if err != nil {
+		return models.Application{}, false, err
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in FindByID")
+		return models.Application{}, false, errors.New("failed to get applications getCollection")
+	}
+
+	var application models.Application
+	opts := options.FindOne().SetCollation(usernameCollation)
+	if err := coll.FindOne(ctx, filter, opts).Decode(&application); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Application{}, false, nil
+		}
+		log.Printf("ERROR: Failed to find application %s: %v", id, err)
+		return models.Application{}, false, err
+	}
+	return application, true, nil
+}
+
+// FindDueReminders lists the user's applications with a reminder at or before until, soonest
+// first.
+func (m *mongoApplicationRepository) FindDueReminders(ctx context.Context, username string, until time.Time) ([]models.Application, error) {
+	log.Printf("Repository FindDueReminders called for applications of username: %s", username)
+	filter := bson.M{"username": username, "reminderAt": bson.M{"$lte": until}}
+	return m.find(ctx, filter, bson.D{{Key: "reminderAt", Value: 1}})
+}
+
+// Replace stores updated in place of current, but only while the stored application still has
+// the status and update time of current. It reports whether it matched, so a concurrent change
+// is never overwritten.
+func (m *mongoApplicationRepository) Replace(ctx context.Context, current, updated models.Application) (bool, error) {
+	log.Printf("Repository Replace called for application %s of username: %s", updated.ID.Hex(), updated.Username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in Replace")
+		return false, errors.New("failed to get applications getCollection")
+	}
+
+	// The matched count decides between success and conflict, so the write must be acknowledged.
+	coll = acknowledged(coll)
+	filter := bson.M{
+		"_id":       current.ID,
+		"username":  current.Username,
+		"status":    current.Status,
+		"updatedAt": current.UpdatedAt,
+	}
+	opts := options.Replace().SetCollation(usernameCollation)
+	result, err := coll.ReplaceOne(ctx, filter, updated, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to replace application %s: %v", updated.ID.Hex(), err)
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (m *mongoApplicationRepository) Delete(ctx context.Context, username, id string) (bool, error) {
+	log.Printf("Repository Delete called for application %s of username: %s", id, username)
+
+	filter, err := applicationFilter(username, id)
+	if err != nil {
+		return false, err
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in Delete")
+		return false, errors.New("failed to get applications getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteOne(ctx, filter, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting application %s - treating as success since data was written to database", id)
+			return true, nil
+		}
+		log.Printf("ERROR: Failed to delete application %s: %v", id, err)
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (m *mongoApplicationRepository) Name() string {
+	return "applications"
+}
+
+func (m *mongoApplicationRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	log.Printf("Repository ExportByUsername called for applications of username: %s", username)
+	return m.find(ctx, bson.M{"username": username}, bson.D{{Key: "createdAt", Value: 1}})
+}
+
+func (m *mongoApplicationRepository) DeleteByUsername(ctx context.Context, username string) error {
+	log.Printf("Repository DeleteByUsername called for applications of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in DeleteByUsername")
+		return errors.New("failed to get applications getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteMany(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting applications of %s - treating as success since data was written to database", username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete applications of %s: %v", username, err)
+		return err
+	}
+
+	log.Printf("Successfully deleted %d applications of %s", result.DeletedCount, username)
+	return nil
+}
+
+func (m *mongoApplicationRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for applications, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in RenameUsername")
+		return errors.New("failed to get applications getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"username": newUsername}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, bson.M{"username": oldUsername}, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming applications of %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename applications username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed applications username %s to %s, modified: %d", oldUsername, newUsername, result.ModifiedCount)
+	return nil
+}
+
+func (m *mongoApplicationRepository) find(ctx context.Context, filter bson.M, sort bson.D) ([]models.Application, error) {
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get applications getCollection in find")
+		return nil, errors.New("failed to get applications getCollection")
+	}
+
+	opts := options.Find().SetCollation(usernameCollation).SetSort(sort)
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute applications query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	applications := []models.Application{}
+	if err = cursor.All(ctx, &applications); err != nil {
+		log.Printf("ERROR: Failed to decode applications: %v", err)
+		return nil, err
+	}
+	return applications, nil
+}
+
+func applicationFilter(username, id string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Printf("ERROR: Invalid ObjectID format for application ID %s: %v", id, err)
+		return nil, errors.New("invalid application ID format")
+	}
+	return bson.M{"_id": objectID, "username": username}, nil
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestApplicationRepository_Name(t *testing.T) {
+	repo := NewApplicationRepository(nil, "test", "applications")
+
+	assert.Equal(t, "applications", repo.Name())
+}
+
+func TestApplicationRepository_InvalidID(t *testing.T) {
+	repo := NewApplicationRepository(nil, "test", "applications")
+
+	_, _, err := repo.FindByID(context.Background(), "testuser", "not-an-id")
+	assert.EqualError(t, err, "invalid application ID format")
+	_, err = repo.Delete(context.Background(), "testuser", "not-an-id")
+	assert.EqualError(t, err, "invalid application ID format")
+}
+
+func TestApplicationRepository_NilClient(t *testing.T) {
+	repo := NewApplicationRepository(nil, "test", "applications")
+	ctx := context.Background()
+	id := "68e462f868efefe99e226a8b"
+
+	_, err := repo.Create(ctx, models.Application{Username: "testuser", JobUrl: "https://example.com/job"})
+	assert.Error(t, err)
+	_, err = repo.FindByUsername(ctx, "testuser")
+	assert.Error(t, err)
+	_, _, err = repo.FindByID(ctx, "testuser", id)
+	assert.Error(t, err)
+	_, err = repo.FindDueReminders(ctx, "testuser", time.Now())
+	assert.Error(t, err)
+	_, err = repo.Replace(ctx, models.Application{ID: primitive.NewObjectID(), Username: "testuser"}, models.Application{})
+	assert.Error(t, err)
+	_, err = repo.Delete(ctx, "testuser", id)
+	assert.Error(t, err)
+
+	assert.Error(t, repo.DeleteByUsername(ctx, "testuser"))
+	assert.Error(t, repo.RenameUsername(ctx, "old", "new"))
+	_, err = repo.ExportByUsername(ctx, "testuser")
+	assert.Error(t, err)
+}
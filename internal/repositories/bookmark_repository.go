@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BookmarkRepository stores job bookmarks, at most one per user and job.
+type BookmarkRepository interface {
+	UserDataStore
+	Save(ctx context.Context, bookmark models.Bookmark) (models.Bookmark, bool, error)
+	FindByUsername(ctx context.Context, username string) ([]models.Bookmark, error)
+	Delete(ctx context.Context, username, jobID string) (bool, error)
+}
+
+type mongoBookmarkRepository struct {
+	database string
+}
+
+func NewBookmarkRepository(client *mongo.Client, dbName, collectionName string) BookmarkRepository {
+	log.Printf("Creating new BookmarkRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoBookmarkRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoBookmarkRepository) getCollection() *mongo.Collection {
+	return config.GetBookmarksCollection(m.database)
+}
+
+func (m *mongoBookmarkRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring unique index on bookmarks username and jobId fields...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get bookmarks getCollection when ensuring indexes")
+		return errors.New("failed to get bookmarks getCollection")
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}, {Key: "jobId", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetName("username_jobId_unique").
+			SetCollation(usernameCollation),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create bookmarks index: %v", err)
+		return err
+	}
+
+	log.Printf("Bookmarks index created successfully")
+	return nil
+}
+
+// Save stores the bookmark, or refreshes the job snapshot of an existing one while keeping its
+// creation time. It returns the stored bookmark and whether it is new.
+func (m *mongoBookmarkRepository) Save(ctx context.Context, bookmark models.Bookmark) (models.Bookmark, bool, error) {
+	log.Printf("Repository Save called for bookmark of job %s by username: %s", bookmark.JobID, bookmark.Username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get bookmarks getCollection in Save")
+		return models.Bookmark{}, false, errors.New("failed to get bookmarks getCollection")
+	}
+	// The previous document is read back, which an unacknowledged write cannot do.
+	coll = acknowledged(coll)
+
+	filter := bson.M{"username": bookmark.Username, "jobId": bookmark.JobID}
+	update := bson.M{
+		"$set":         bson.M{"job": bookmark.Job},
+		"$setOnInsert": bson.M{"createdAt": bookmark.CreatedAt},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetCollation(usernameCollation).
+		SetReturnDocument(options.Before)
+
+	// With the document as it was before the update, "no documents" means it was just inserted.
+	var existing models.Bookmark
+	if err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&existing); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return bookmark, true, nil
+		}
+		log.Printf("ERROR: Failed to save bookmark for %s: %v", bookmark.Username, err)
+		return models.Bookmark{}, false, err
+	}
+	existing.Job = bookmark.Job
+	return existing, false, nil
+}
+
+func (m *mongoBookmarkRepository) FindByUsername(ctx context.Context, username string) ([]models.Bookmark, error) {
+	log.Printf("Repository FindByUsername called for bookmarks of username: %s", username)
+	return m.find(ctx, bson.M{"username": username})
+}
+
+func (m *mongoBookmarkRepository) Delete(ctx context.Context, username, jobID string) (bool, error) {
+	log.Printf("Repository Delete called for bookmark of job %s by username: %s", jobID, username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get bookmarks getCollection in Delete")
+		return false, errors.New("failed to get bookmarks getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteOne(ctx, bson.M{"username": username, "jobId": jobID}, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting bookmark of job %s - treating as success since data was written to database", jobID)
+			return true, nil
+		}
+		log.Printf("ERROR: Failed to delete bookmark of job %s: %v", jobID, err)
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (m *mongoBookmarkRepository) Name() string {
+	return "bookmarks"
+}
+
+func (m *mongoBookmarkRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	log.Printf("Repository ExportByUsername called for bookmarks of username: %s", username)
+	return m.find(ctx, bson.M{"username": username})
+}
+
+func (m *mongoBookmarkRepository) DeleteByUsername(ctx context.Context, username string) error {
+	log.Printf("Repository DeleteByUsername called for bookmarks of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get bookmarks getCollection in DeleteByUsername")
+		return errors.New("failed to get bookmarks getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteMany(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting bookmarks of %s - treating as success since data was written to database", username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete bookmarks of %s: %v", username, err)
+		return err
+	}
+
+	log.Printf("Successfully deleted %d bookmarks of %s", result.DeletedCount, username)
+	return nil
+}
+
+func (m *mongoBookmarkRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for bookmarks, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get bookmarks getCollection in RenameUsername")
+		return errors.New("failed to get bookmarks getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"username": newUsername}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, bson.M{"username": oldUsername}, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming bookmarks of %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename bookmarks username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed bookmarks username %s to %s, modified: %d", oldUsername, newUsername, result.ModifiedCount)
+	return nil
+}
+
+func (m *mongoBookmarkRepository) find(ctx context.Context, filter bson.M) ([]models.Bookmark, error) {
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get bookmarks getCollection in find")
+		return nil, errors.New("failed to get bookmarks getCollection")
+	}
+
+	opts := options.Find().SetCollation(usernameCollation).SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute bookmarks query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	bookmarks := []models.Bookmark{}
+	if err = cursor.All(ctx, &bookmarks); err != nil {
+		log.Printf("ERROR: Failed to decode bookmarks: %v", err)
+		return nil, err
+	}
+	return bookmarks, nil
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookmarkRepository_Name(t *testing.T) {
+	repo := NewBookmarkRepository(nil, "test", "bookmarks")
+
+	assert.Equal(t, "bookmarks", repo.Name())
+}
+
+func TestBookmarkRepository_NilClient(t *testing.T) {
+	repo := NewBookmarkRepository(nil, "test", "bookmarks")
+	ctx := context.Background()
+
+	_, _, err := repo.Save(ctx, models.Bookmark{Username: "testuser", JobID: "job-1"})
+	assert.Error(t, err)
+	_, err = repo.FindByUsername(ctx, "testuser")
+	assert.Error(t, err)
+	_, err = repo.Delete(ctx, "testuser", "job-1")
+	assert.Error(t, err)
+
+	assert.Error(t, repo.DeleteByUsername(ctx, "testuser"))
+	assert.Error(t, repo.RenameUsername(ctx, "old", "new"))
+	_, err = repo.ExportByUsername(ctx, "testuser")
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobAlertQueueRepository holds the IDs of created jobs that still have to be matched against
+// saved searches and skills, oldest first. A job is queued at most once.
+type JobAlertQueueRepository interface {
+	Push(ctx context.Context, jobID string, queuedAt time.Time) error
+	FindBatch(ctx context.Context, limit int) ([]string, error)
+	DeleteByIDs(ctx context.Context, jobIDs []string) error
+}
+
+type queuedJob struct {
+	JobID    string    `bson:"_id"`
+	QueuedAt time.Time `bson:"queuedAt"`
+}
+
+type mongoJobAlertQueueRepository struct {
+	database string
+}
+
+func NewJobAlertQueueRepository(client *mongo.Client, dbName, collectionName string) JobAlertQueueRepository {
+	log.Printf("Creating new JobAlertQueueRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoJobAlertQueueRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoJobAlertQueueRepository) getCollection() *mongo.Collection {
+	return config.GetJobAlertQueueCollection(m.database)
+}
+
+func (m *mongoJobAlertQueueRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring index on job alert queue queuedAt field...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alert queue getCollection when ensuring indexes")
+		return errors.New("failed to get job alert queue getCollection")
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "queuedAt", Value: 1}},
+		Options: options.Index().SetName("queuedAt"),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create job alert queue index: %v", err)
+		return err
+	}
+
+	log.Printf("Job alert queue index created successfully")
+	return nil
+}
+
+func (m *mongoJobAlertQueueRepository) Push(ctx context.Context, jobID string, queuedAt time.Time) error {
+	log.Printf("Repository Push called for job: %s", jobID)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alert queue getCollection in Push")
+		return errors.New("failed to get job alert queue getCollection")
+	}
+
+	update := bson.M{"$setOnInsert": bson.M{"queuedAt": queuedAt}}
+	opts := options.Update().SetUpsert(true)
+	if _, err := coll.UpdateByID(ctx, jobID, update, opts); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for queuing job %s - treating as success since data was written to database", jobID)
+			return nil
+		}
+		log.Printf("ERROR: Failed to queue job %s for alerts: %v", jobID, err)
+		return err
+	}
+	return nil
+}
+
+func (m *mongoJobAlertQueueRepository) FindBatch(ctx context.Context, limit int) ([]string, error) {
+	log.Printf("Repository FindBatch called for up to %d queued jobs", limit)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alert queue getCollection in FindBatch")
+		return nil, errors.New("failed to get job alert queue getCollection")
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "queuedAt", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := coll.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute job alert queue query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var queued []queuedJob
+	if err = cursor.All(ctx, &queued); err != nil {
+		log.Printf("ERROR: Failed to decode queued jobs: %v", err)
+		return nil, err
+	}
+	jobIDs := make([]string, 0, len(queued))
+	for _, job := range queued {
+		jobIDs = append(jobIDs, job.JobID)
+	}
+	return jobIDs, nil
+}
+
+func (m *mongoJobAlertQueueRepository) DeleteByIDs(ctx context.Context, jobIDs []string) error {
+	log.Printf("Repository DeleteByIDs called for %d queued jobs", len(jobIDs))
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alert queue getCollection in DeleteByIDs")
+		return errors.New("failed to get job alert queue getCollection")
+	}
+
+	if _, err := coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": jobIDs}}); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting queued jobs - treating as success since data was written to database")
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete queued jobs: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobAlertQueueRepository_NilClient(t *testing.T) {
+	repo := NewJobAlertQueueRepository(nil, "test", "job_alert_queue")
+	ctx := context.Background()
+
+	assert.Error(t, repo.Push(ctx, "job-1", time.Now()))
+	_, err := repo.FindBatch(ctx, 10)
+	assert.Error(t, err)
+	assert.Error(t, repo.DeleteByIDs(ctx, []string{"job-1"}))
+}
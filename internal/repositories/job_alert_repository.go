@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobAlertRepository queues job alerts until they are delivered. A user holds at most one
+// pending alert per job; queuing it again only adds the new reasons. An alert that some channel
+// failed to deliver is rescheduled, remembering the channels that did accept it.
+type JobAlertRepository interface {
+	UserDataStore
+	Enqueue(ctx context.Context, alert models.JobAlert) error
+	FindDue(ctx context.Context, now time.Time) ([]models.JobAlert, error)
+	DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error
+	Reschedule(ctx context.Context, id primitive.ObjectID, deliveredTo []string, deliverAfter time.Time) error
+}
+
+type mongoJobAlertRepository struct {
+	database string
+}
+
+func NewJobAlertRepository(client *mongo.Client, dbName, collectionName string) JobAlertRepository {
+	log.Printf("Creating new JobAlertRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoJobAlertRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoJobAlertRepository) getCollection() *mongo.Collection {
+	return config.GetJobAlertsCollection(m.database)
+}
+
+func (m *mongoJobAlertRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring indexes on job alerts...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection when ensuring indexes")
+		return errors.New("failed to get job alerts getCollection")
+	}
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "username", Value: 1}, {Key: "jobId", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetName("username_jobId_unique").
+				SetCollation(usernameCollation),
+		},
+		{
+			Keys:    bson.D{{Key: "deliverAfter", Value: 1}},
+			Options: options.Index().SetName("deliverAfter"),
+		},
+	}
+	if _, err := coll.Indexes().CreateMany(ctx, indexModels); err != nil {
+		log.Printf("ERROR: Failed to create job alerts indexes: %v", err)
+		return err
+	}
+
+	log.Printf("Job alerts indexes created successfully")
+	return nil
+}
+
+// Enqueue stores the alert, or merges its reasons into the pending alert for the same job.
+// The delivery time of a pending alert is kept.
+func (m *mongoJobAlertRepository) Enqueue(ctx context.Context, alert models.JobAlert) error {
+	log.Printf("Repository Enqueue called for job %s of username: %s", alert.JobID, alert.Username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection in Enqueue")
+		return errors.New("failed to get job alerts getCollection")
+	}
+
+	filter := bson.M{"username": alert.Username, "jobId": alert.JobID}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"title":        alert.Title,
+			"company":      alert.Company,
+			"url":          alert.Url,
+			"frequency":    alert.Frequency,
+			"createdAt":    alert.CreatedAt,
+			"deliverAfter": alert.DeliverAfter,
+		},
+		"$addToSet": bson.M{"reasons": bson.M{"$each": alert.Reasons}},
+	}
+	opts := options.Update().SetUpsert(true).SetCollation(usernameCollation)
+	if _, err := coll.UpdateOne(ctx, filter, update, opts); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for job alert of %s - treating as success since data was written to database", alert.Username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to enqueue job alert for %s: %v", alert.Username, err)
+		return err
+	}
+	return nil
+}
+
+func (m *mongoJobAlertRepository) FindDue(ctx context.Context, now time.Time) ([]models.JobAlert, error) {
+	log.Printf("Repository FindDue called for job alerts due by %v", now)
+	return m.find(ctx, bson.M{"deliverAfter": bson.M{"$lte": now}})
+}
+
+func (m *mongoJobAlertRepository) DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error {
+	log.Printf("Repository DeleteByIDs called for %d job alerts", len(ids))
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection in DeleteByIDs")
+		return errors.New("failed to get job alerts getCollection")
+	}
+
+	if _, err := coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting job alerts - treating as success since data was written to database")
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete job alerts: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Reschedule records a failed delivery attempt of the alert: the channels in deliveredTo are
+// added to those that accepted it, and it becomes due again at deliverAfter.
+func (m *mongoJobAlertRepository) Reschedule(ctx context.Context, id primitive.ObjectID, deliveredTo []string, deliverAfter time.Time) error {
+	log.Printf("Repository Reschedule called for job alert %s", id.Hex())
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection in Reschedule")
+		return errors.New("failed to get job alerts getCollection")
+	}
+
+	if deliveredTo == nil {
+		deliveredTo = []string{}
+	}
+	update := bson.M{
+		"$set":      bson.M{"deliverAfter": deliverAfter},
+		"$inc":      bson.M{"attempts": 1},
+		"$addToSet": bson.M{"deliveredTo": bson.M{"$each": deliveredTo}},
+	}
+	if _, err := coll.UpdateByID(ctx, id, update); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for rescheduling job alert %s - treating as success since data was written to database", id.Hex())
+			return nil
+		}
+		log.Printf("ERROR: Failed to reschedule job alert %s: %v", id.Hex(), err)
+		return err
+	}
+	return nil
+}
+
+func (m *mongoJobAlertRepository) Name() string {
+	return "job_alerts"
+}
+
+func (m *mongoJobAlertRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	log.Printf("Repository ExportByUsername called for job alerts of username: %s", username)
+	return m.find(ctx, bson.M{"username": username})
+}
+
+func (m *mongoJobAlertRepository) DeleteByUsername(ctx context.Context, username string) error {
+	log.Printf("Repository DeleteByUsername called for job alerts of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection in DeleteByUsername")
+		return errors.New("failed to get job alerts getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteMany(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting job alerts of %s - treating as success since data was written to database", username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete job alerts of %s: %v", username, err)
+		return err
+	}
+
+	log.Printf("Successfully deleted %d job alerts of %s", result.DeletedCount, username)
+	return nil
+}
+
+func (m *mongoJobAlertRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for job alerts, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection in RenameUsername")
+		return errors.New("failed to get job alerts getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"username": newUsername}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, bson.M{"username": oldUsername}, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming job alerts of %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename job alerts username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed job alerts username %s to %s, modified: %d", oldUsername, newUsername, result.ModifiedCount)
+	return nil
+}
+
+func (m *mongoJobAlertRepository) find(ctx context.Context, filter bson.M) ([]models.JobAlert, error) {
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get job alerts getCollection in find")
+		return nil, errors.New("failed to get job alerts getCollection")
+	}
+
+	opts := options.Find().SetCollation(usernameCollation).SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute job alerts query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	alerts := []models.JobAlert{}
+	if err = cursor.All(ctx, &alerts); err != nil {
+		log.Printf("ERROR: Failed to decode job alerts: %v", err)
+		return nil, err
+	}
+	return alerts, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestJobAlertRepository_Name(t *testing.T) {
+	repo := NewJobAlertRepository(nil, "test", "job_alerts")
+
+	assert.Equal(t, "job_alerts", repo.Name())
+}
+
+func TestJobAlertRepository_NilClient(t *testing.T) {
+	repo := NewJobAlertRepository(nil, "test", "job_alerts")
+	ctx := context.Background()
+
+	assert.Error(t, repo.Enqueue(ctx, models.JobAlert{Username: "testuser", JobID: "job-1"}))
+	_, err := repo.FindDue(ctx, time.Now())
+	assert.Error(t, err)
+	assert.Error(t, repo.DeleteByIDs(ctx, []primitive.ObjectID{primitive.NewObjectID()}))
+	assert.Error(t, repo.Reschedule(ctx, primitive.NewObjectID(), []string{"email"}, time.Now()))
+
+	assert.Error(t, repo.DeleteByUsername(ctx, "testuser"))
+	assert.Error(t, repo.RenameUsername(ctx, "old", "new"))
+	_, err = repo.ExportByUsername(ctx, "testuser")
+	assert.Error(t, err)
+}
@@ -20,10 +20,23 @@ var validate = validator.New()
 type JobRepository interface {
 	Create(ctx context.Context, job models.Job) error
 	FindByID(ctx context.Context, id string) (models.Job, bool, error)
+	FindByIDs(ctx context.Context, ids []string) ([]models.Job, error)
 	UpdateByID(ctx context.Context, id string, job models.Job) error
 	FindAll(ctx context.Context) ([]models.Job, error)
+	FindByFilter(ctx context.Context, filter models.JobFilter) ([]models.Job, error)
+	FindRecent(ctx context.Context, filter models.JobFilter, openAt time.Time, limit int) ([]models.Job, error)
+	StreamByFilter(ctx context.Context, filter models.JobFilter, fn func(models.Job) error) error
+	UpdateSkills(ctx context.Context, id string, skills []string) error
+	FacetCounts(ctx context.Context, filter models.JobFilter) (models.JobStats, error)
 }
 
+// JobStatsCompanyLimit caps the company facet, which has far more values than the others.
+// Services counting facets in memory apply the same cap.
+const JobStatsCompanyLimit = 20
+
+// jobFilterCollation lets the filter match listing values regardless of case.
+var jobFilterCollation = &options.Collation{Locale: "en", Strength: 2}
+
 type mongoJobRepository struct {
 	database string
 }
@@ -66,6 +79,27 @@ func (m *mongoJobRepository) ensureIndexes(ctx context.Context) error {
 	}
 
 	log.Printf("TTL index created successfully")
+
+	skillsModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "skills", Value: 1}},
+		Options: options.Index().SetCollation(jobFilterCollation),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, skillsModel); err != nil {
+		log.Printf("ERROR: Failed to create skills index: %v", err)
+		return err
+	}
+
+	log.Printf("Skills index created successfully")
+
+	firstSeenModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "firstSeenAt", Value: -1}},
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, firstSeenModel); err != nil {
+		log.Printf("ERROR: Failed to create firstSeenAt index: %v", err)
+		return err
+	}
+
+	log.Printf("FirstSeenAt index created successfully")
 	return nil
 }
 
@@ -187,3 +221,294 @@ func (m *mongoJobRepository) FindAll(ctx context.Context) ([]models.Job, error)
 	log.Printf("Successfully retrieved %d jobs from database", len(jobs))
 	return jobs, nil
 }
+
+func (m *mongoJobRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Job, error) {
+	log.Printf("Repository FindByIDs called for %d job IDs", len(ids))
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get jobs getCollection in FindByIDs")
+		return nil, errors.New("failed to get jobs getCollection")
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		log.Printf("ERROR: Failed to execute find by IDs query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	jobs := []models.Job{}
+	if err = cursor.All(ctx, &jobs); err != nil {
+		log.Printf("ERROR: Failed to decode jobs from cursor: %v", err)
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (m *mongoJobRepository) FindByFilter(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+	log.Printf("Repository FindByFilter called with filter: %+v", filter)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get jobs getCollection in FindByFilter")
+		return nil, errors.New("failed to get jobs getCollection")
+	}
+
+	cursor, err := coll.Find(ctx, jobFilterQuery(filter), options.Find().SetCollation(jobFilterCollation))
+	if err != nil {
+		log.Printf("ERROR: Failed to execute filtered find query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var jobs []models.Job
+	if err = cursor.All(ctx, &jobs); err != nil {
+		log.Printf("ERROR: Failed to decode jobs from cursor: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Successfully retrieved %d filtered jobs from database", len(jobs))
+	return jobs, nil
+}
+
+// FindRecent returns the newest jobs matching filter that are still open at openAt, newest
+// first, at most limit of them when limit is positive. Jobs without an expiry count as open.
+func (m *mongoJobRepository) FindRecent(ctx context.Context, filter models.JobFilter, openAt time.Time, limit int) ([]models.Job, error) {
+	log.Printf("Repository FindRecent called with filter: %+v, limit: %d", filter, limit)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get jobs getCollection in FindRecent")
+		return nil, errors.New("failed to get jobs getCollection")
+	}
+
+	query := jobFilterQuery(filter)
+	query["$or"] = []bson.M{
+		{"expiresAt": bson.M{"$gt": openAt}},
+		{"expiresAt": time.Time{}},
+		{"expiresAt": bson.M{"$exists": false}},
+	}
+	opts := options.Find().
+		SetCollation(jobFilterCollation).
+		SetSort(bson.D{{Key: "firstSeenAt", Value: -1}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := coll.Find(ctx, query, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute recent jobs query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	jobs := []models.Job{}
+	if err = cursor.All(ctx, &jobs); err != nil {
+		log.Printf("ERROR: Failed to decode recent jobs from cursor: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Successfully retrieved %d recent jobs from database", len(jobs))
+	return jobs, nil
+}
+
+// StreamByFilter calls fn for every job matching filter as it is read from the cursor, so large
+// result sets are never held in memory. It stops at the first error fn returns.
+func (m *mongoJobRepository) StreamByFilter(ctx context.Context, filter models.JobFilter, fn func(models.Job) error) error {
+	log.Printf("Repository StreamByFilter called with filter: %+v", filter)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get jobs getCollection in StreamByFilter")
+		return errors.New("failed to get jobs getCollection")
+	}
+
+	cursor, err := coll.Find(ctx, jobFilterQuery(filter), options.Find().SetCollation(jobFilterCollation))
+	if err != nil {
+		log.Printf("ERROR: Failed to execute streamed find query: %v", err)
+		return err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	streamed := 0
+	for cursor.Next(ctx) {
+		var job models.Job
+		if err := cursor.Decode(&job); err != nil {
+			log.Printf("ERROR: Failed to decode streamed job: %v", err)
+			return err
+		}
+		if err := fn(job); err != nil {
+			return err
+		}
+		streamed++
+	}
+	if err := cursor.Err(); err != nil {
+		log.Printf("ERROR: Cursor error while streaming jobs: %v", err)
+		return err
+	}
+
+	log.Printf("Successfully streamed %d jobs from database", streamed)
+	return nil
+}
+
+// UpdateSkills sets only the skill tags, leaving expiresAt untouched unlike UpdateByID.
+func (m *mongoJobRepository) UpdateSkills(ctx context.Context, id string, skills []string) error {
+	log.Printf("Repository UpdateSkills called for job ID: %s with %d skills", id, len(skills))
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get jobs getCollection in UpdateSkills")
+		return errors.New("failed to get jobs getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"skills": skills}}
+	if len(skills) == 0 {
+		update = bson.M{"$unset": bson.M{"skills": ""}}
+	}
+	if _, err := coll.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for skills of job ID %s - treating as success since data was written to database", id)
+			return nil
+		}
+		log.Printf("ERROR: Failed to update skills of job ID %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// FacetCounts counts the jobs matching filter by field, seniority, workplace type, employment
+// type and company in a single aggregation, so callers never load the jobs themselves.
+func (m *mongoJobRepository) FacetCounts(ctx context.Context, filter models.JobFilter) (models.JobStats, error) {
+	log.Printf("Repository FacetCounts called with filter: %+v", filter)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get jobs getCollection in FacetCounts")
+		return models.JobStats{}, errors.New("failed to get jobs getCollection")
+	}
+
+	opts := options.Aggregate().SetCollation(jobFilterCollation)
+	cursor, err := coll.Aggregate(ctx, jobStatsPipeline(filter), opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to aggregate job facet counts: %v", err)
+		return models.JobStats{}, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	type count struct {
+		Count int `bson:"count"`
+	}
+	var rows []struct {
+		Total             []count             `bson:"total"`
+		BrazilianFriendly []count             `bson:"brazilianFriendly"`
+		Fields            []models.FacetCount `bson:"fields"`
+		SeniorityLevels   []models.FacetCount `bson:"seniorityLevels"`
+		WorkplaceTypes    []models.FacetCount `bson:"workplaceTypes"`
+		EmploymentTypes   []models.FacetCount `bson:"employmentTypes"`
+		Companies         []models.FacetCount `bson:"companies"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		log.Printf("ERROR: Failed to decode job facet counts: %v", err)
+		return models.JobStats{}, err
+	}
+
+	stats := models.JobStats{
+		Fields:          []models.FacetCount{},
+		SeniorityLevels: []models.FacetCount{},
+		WorkplaceTypes:  []models.FacetCount{},
+		EmploymentTypes: []models.FacetCount{},
+		Companies:       []models.FacetCount{},
+	}
+	if len(rows) == 0 {
+		return stats, nil
+	}
+	row := rows[0]
+	if len(row.Total) > 0 {
+		stats.Total = row.Total[0].Count
+	}
+	if len(row.BrazilianFriendly) > 0 {
+		stats.BrazilianFriendly = row.BrazilianFriendly[0].Count
+	}
+	stats.Fields = withoutBlankFacets(row.Fields)
+	stats.SeniorityLevels = withoutBlankFacets(row.SeniorityLevels)
+	stats.WorkplaceTypes = withoutBlankFacets(row.WorkplaceTypes)
+	stats.EmploymentTypes = withoutBlankFacets(row.EmploymentTypes)
+	stats.Companies = withoutBlankFacets(row.Companies)
+	return stats, nil
+}
+
+func jobStatsPipeline(filter models.JobFilter) mongo.Pipeline {
+	facet := func(path string, limit int) bson.A {
+		stages := bson.A{
+			bson.M{"$group": bson.M{"_id": "$" + path, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		}
+		if limit > 0 {
+			stages = append(stages, bson.M{"$limit": limit})
+		}
+		return stages
+	}
+	return mongo.Pipeline{
+		{{Key: "$match", Value: jobFilterQuery(filter)}},
+		{{Key: "$facet", Value: bson.M{
+			"total":             bson.A{bson.M{"$count": "count"}},
+			"brazilianFriendly": bson.A{bson.M{"$match": bson.M{"isBrazilianFriendly.isFriendly": true}}, bson.M{"$count": "count"}},
+			"fields":            facet("field", 0),
+			"seniorityLevels":   facet("seniorityLevel", 0),
+			"workplaceTypes":    facet("workplaceType", 0),
+			"employmentTypes":   facet("employmentType", 0),
+			"companies":         facet("company", JobStatsCompanyLimit),
+		}}},
+	}
+}
+
+// withoutBlankFacets drops the bucket of jobs missing the attribute.
+func withoutBlankFacets(facets []models.FacetCount) []models.FacetCount {
+	kept := make([]models.FacetCount, 0, len(facets))
+	for _, facet := range facets {
+		if strings.TrimSpace(facet.Value) != "" {
+			kept = append(kept, facet)
+		}
+	}
+	return kept
+}
+
+func jobFilterQuery(filter models.JobFilter) bson.M {
+	query := bson.M{}
+	if len(filter.Fields) > 0 {
+		query["field"] = bson.M{"$in": filter.Fields}
+	}
+	if len(filter.SeniorityLevels) > 0 {
+		query["seniorityLevel"] = bson.M{"$in": filter.SeniorityLevels}
+	}
+	if len(filter.WorkplaceTypes) > 0 {
+		query["workplaceType"] = bson.M{"$in": filter.WorkplaceTypes}
+	}
+	if len(filter.Skills) > 0 {
+		query["skills"] = bson.M{"$all": filter.Skills}
+	}
+	if filter.BrazilianFriendly {
+		query["isBrazilianFriendly.isFriendly"] = true
+	}
+	return query
+}
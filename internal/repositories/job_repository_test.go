@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -344,3 +345,124 @@ func TestJobRepository_ContextCancellation(t *testing.T) {
 		t.Error("Expected error due to cancelled context, got nil")
 	}
 }
+
+func TestJobRepository_FindRecent_NilClient(t *testing.T) {
+	repo := NewJobRepository(nil, "testdb", "jobs")
+
+	jobs, err := repo.FindRecent(context.Background(), models.JobFilter{}, time.Now(), 10)
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+	if jobs != nil {
+		t.Errorf("Expected nil jobs, got %v", jobs)
+	}
+}
+
+func TestJobRepository_FindByFilter_NilClient(t *testing.T) {
+	repo := NewJobRepository(nil, "testdb", "jobs")
+
+	jobs, err := repo.FindByFilter(context.Background(), models.JobFilter{Fields: []string{"Engineering"}})
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+	if jobs != nil {
+		t.Errorf("Expected nil jobs, got %v", jobs)
+	}
+}
+
+func TestJobFilterQuery(t *testing.T) {
+	query := jobFilterQuery(models.JobFilter{Fields: []string{"Engineering"}, WorkplaceTypes: []string{"Remote", "Hybrid"}, Skills: []string{"Go", "Kubernetes"}})
+
+	if _, ok := query["field"]; !ok {
+		t.Error("Expected field condition")
+	}
+	if _, ok := query["seniorityLevel"]; ok {
+		t.Error("Expected no seniority condition for an empty list")
+	}
+	if _, ok := query["workplaceType"]; !ok {
+		t.Error("Expected workplace type condition")
+	}
+	if skills, ok := query["skills"].(bson.M); !ok || skills["$all"] == nil {
+		t.Errorf("Expected skills to require every value, got %v", query["skills"])
+	}
+	if _, ok := query["isBrazilianFriendly.isFriendly"]; ok {
+		t.Error("Expected no Brazilian-friendly condition unless requested")
+	}
+
+	query = jobFilterQuery(models.JobFilter{BrazilianFriendly: true})
+	if query["isBrazilianFriendly.isFriendly"] != true {
+		t.Errorf("Expected Brazilian-friendly condition, got %v", query)
+	}
+}
+
+func TestJobRepository_StreamByFilter_NilClient(t *testing.T) {
+	repo := NewJobRepository(nil, "testdb", "jobs")
+
+	called := false
+	err := repo.StreamByFilter(context.Background(), models.JobFilter{}, func(models.Job) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("Expected an error before any job, got %v, called: %t", err, called)
+	}
+}
+
+func TestJobRepository_UpdateSkills_NilClient(t *testing.T) {
+	repo := NewJobRepository(nil, "testdb", "jobs")
+
+	err := repo.UpdateSkills(context.Background(), "test-id", []string{"Go"})
+	if err == nil {
+		t.Error("Expected error with nil client, got nil")
+	}
+}
+
+func TestJobRepository_FindByIDs_NilClient(t *testing.T) {
+	repo := NewJobRepository(nil, "testdb", "jobs")
+
+	jobs, err := repo.FindByIDs(context.Background(), []string{"test-id"})
+	if err == nil {
+		t.Error("Expected error with nil client, got nil")
+	}
+	if jobs != nil {
+		t.Errorf("Expected nil jobs, got %v", jobs)
+	}
+}
+
+func TestJobRepository_FacetCounts_NilClient(t *testing.T) {
+	repo := NewJobRepository(nil, "testdb", "jobs")
+
+	if _, err := repo.FacetCounts(context.Background(), models.JobFilter{}); err == nil {
+		t.Error("Expected error with nil client, got nil")
+	}
+}
+
+func TestJobStatsPipeline(t *testing.T) {
+	pipeline := jobStatsPipeline(models.JobFilter{WorkplaceTypes: []string{"Remote"}})
+
+	if len(pipeline) != 2 || pipeline[0][0].Key != "$match" || pipeline[1][0].Key != "$facet" {
+		t.Fatalf("Expected a $match then a $facet stage, got %v", pipeline)
+	}
+	if match := pipeline[0][0].Value.(bson.M); match["workplaceType"] == nil {
+		t.Errorf("Expected the listing filter in $match, got %v", match)
+	}
+	facets := pipeline[1][0].Value.(bson.M)
+	for _, name := range []string{"total", "brazilianFriendly", "fields", "seniorityLevels", "workplaceTypes", "employmentTypes", "companies"} {
+		if facets[name] == nil {
+			t.Errorf("Expected facet %s", name)
+		}
+	}
+	if companies := facets["companies"].(bson.A); len(companies) != 3 {
+		t.Errorf("Expected the company facet to be limited, got %v", companies)
+	}
+}
+
+func TestWithoutBlankFacets(t *testing.T) {
+	facets := withoutBlankFacets([]models.FacetCount{{Value: "Remote", Count: 3}, {Value: "", Count: 2}, {Value: " ", Count: 1}})
+
+	if len(facets) != 1 || facets[0].Value != "Remote" {
+		t.Errorf("Expected only the Remote facet, got %v", facets)
+	}
+}
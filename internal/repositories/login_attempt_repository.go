@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginAttemptRepository interface {
+	FindByKey(ctx context.Context, key string) (models.LoginAttempt, bool, error)
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (models.LoginAttempt, error)
+	Lock(ctx context.Context, key string, until time.Time, window time.Duration) error
+	Reset(ctx context.Context, key string) error
+	// The "user:<name>" counter belongs to the user; "ip:<address>" counters are shared.
+	UserDataStore
+}
+
+type mongoLoginAttemptRepository struct {
+	database string
+}
+
+func NewLoginAttemptRepository(client *mongo.Client, dbName, collectionName string) LoginAttemptRepository {
+	log.Printf("Creating new LoginAttemptRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoLoginAttemptRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoLoginAttemptRepository) getCollection() *mongo.Collection {
+	return config.GetLoginAttemptsCollection(m.database)
+}
+
+func (m *mongoLoginAttemptRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring TTL index on login attempts expiresAt field...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login attempts getCollection when ensuring indexes")
+		return errors.New("failed to get login attempts getCollection")
+	}
+
+	ttlModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, ttlModel); err != nil {
+		log.Printf("ERROR: Failed to create login attempts TTL index: %v", err)
+		return err
+	}
+
+	log.Printf("Login attempts TTL index created successfully")
+	return nil
+}
+
+func (m *mongoLoginAttemptRepository) FindByKey(ctx context.Context, key string) (models.LoginAttempt, bool, error) {
+	log.Printf("Repository FindByKey called for login attempt key: %s", key)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login attempts getCollection in FindByKey")
+		return models.LoginAttempt{}, false, errors.New("failed to get login attempts getCollection")
+	}
+
+	var attempt models.LoginAttempt
+	err := coll.FindOne(ctx, bson.M{"_id": key}).Decode(&attempt)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.LoginAttempt{}, false, nil
+		}
+		log.Printf("ERROR: Failed to find login attempt %s: %v", key, err)
+		return models.LoginAttempt{}, false, err
+	}
+
+	return attempt, true, nil
+}
+
+// RecordFailure increments the failure counter for key and returns the updated record. The
+// record expires once no failure happens for a whole window, which resets the counter.
+func (m *mongoLoginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (models.LoginAttempt, error) {
+	log.Printf("Repository RecordFailure called for login attempt key: %s", key)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login attempts getCollection in RecordFailure")
+		return models.LoginAttempt{}, errors.New("failed to get login attempts getCollection")
+	}
+	// The updated counter is read back, which an unacknowledged write cannot do.
+	coll = acknowledged(coll)
+
+	update := bson.M{
+		"$inc": bson.M{"failures": 1},
+		"$set": bson.M{
+			"lastFailureAt": now,
+			"expiresAt":     now.Add(window),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var attempt models.LoginAttempt
+	if err := coll.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&attempt); err != nil {
+		log.Printf("ERROR: Failed to record login failure for %s: %v", key, err)
+		return models.LoginAttempt{}, err
+	}
+
+	log.Printf("Recorded login failure %d for key: %s", attempt.Failures, key)
+	return attempt, nil
+}
+
+func (m *mongoLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time, window time.Duration) error {
+	log.Printf("Repository Lock called for login attempt key: %s, until: %v", key, until)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login attempts getCollection in Lock")
+		return errors.New("failed to get login attempts getCollection")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"lockedUntil": until,
+			"expiresAt":   until.Add(window),
+		},
+	}
+	if _, err := coll.UpdateOne(ctx, bson.M{"_id": key}, update); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for locking %s - treating as success since data was written to database", key)
+			return nil
+		}
+		log.Printf("ERROR: Failed to lock %s: %v", key, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	log.Printf("Repository Reset called for login attempt key: %s", key)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login attempts getCollection in Reset")
+		return errors.New("failed to get login attempts getCollection")
+	}
+
+	if _, err := coll.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for resetting %s - treating as success since data was written to database", key)
+			return nil
+		}
+		log.Printf("ERROR: Failed to reset login attempts for %s: %v", key, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoLoginAttemptRepository) Name() string {
+	return "login_attempts"
+}
+
+func (m *mongoLoginAttemptRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	attempt, found, err := m.FindByKey(ctx, models.UserAttemptKey(username))
+	if err != nil || !found {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func (m *mongoLoginAttemptRepository) DeleteByUsername(ctx context.Context, username string) error {
+	return m.Reset(ctx, models.UserAttemptKey(username))
+}
+
+// RenameUsername moves the username's counter to the new name, so a rename does not lift a
+// lockout.
+func (m *mongoLoginAttemptRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for login attempts, from: %s, to: %s", oldUsername, newUsername)
+
+	attempt, found, err := m.FindByKey(ctx, models.UserAttemptKey(oldUsername))
+	if err != nil || !found {
+		return err
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login attempts getCollection in RenameUsername")
+		return errors.New("failed to get login attempts getCollection")
+	}
+
+	attempt.Key = models.UserAttemptKey(newUsername)
+	opts := options.Replace().SetUpsert(true)
+	if _, err := coll.ReplaceOne(ctx, bson.M{"_id": attempt.Key}, attempt, opts); err != nil {
+		if !strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("ERROR: Failed to move login attempts of %s to %s: %v", oldUsername, newUsername, err)
+			return err
+		}
+	}
+	return m.Reset(ctx, models.UserAttemptKey(oldUsername))
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAttemptRepository_NilClient(t *testing.T) {
+	repo := NewLoginAttemptRepository(nil, "test", "login_attempts")
+	ctx := context.Background()
+
+	_, _, err := repo.FindByKey(ctx, "user:testuser")
+	assert.Error(t, err)
+
+	_, err = repo.RecordFailure(ctx, "user:testuser", time.Now(), time.Minute)
+	assert.Error(t, err)
+
+	assert.Error(t, repo.Lock(ctx, "user:testuser", time.Now(), time.Minute))
+	assert.Error(t, repo.Reset(ctx, "user:testuser"))
+}
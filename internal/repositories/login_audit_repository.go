@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginAuditRepository interface {
+	UserDataStore
+	Create(ctx context.Context, entry models.LoginAuditEntry) error
+	FindByUsername(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error)
+}
+
+type mongoLoginAuditRepository struct {
+	database string
+}
+
+func NewLoginAuditRepository(client *mongo.Client, dbName, collectionName string) LoginAuditRepository {
+	log.Printf("Creating new LoginAuditRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoLoginAuditRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoLoginAuditRepository) getCollection() *mongo.Collection {
+	return config.GetLoginAuditCollection(m.database)
+}
+
+func (m *mongoLoginAuditRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring index on login audit username and timestamp fields...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login audit getCollection when ensuring indexes")
+		return errors.New("failed to get login audit getCollection")
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}, {Key: "timestamp", Value: -1}},
+		Options: options.Index().SetCollation(usernameCollation),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create login audit index: %v", err)
+		return err
+	}
+
+	log.Printf("Login audit index created successfully")
+	return nil
+}
+
+func (m *mongoLoginAuditRepository) Create(ctx context.Context, entry models.LoginAuditEntry) error {
+	log.Printf("Repository Create called for login audit of username: %s, success: %t", entry.Username, entry.Success)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login audit getCollection in Create")
+		return errors.New("failed to get login audit getCollection")
+	}
+
+	if _, err := coll.InsertOne(ctx, entry); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for login audit of %s - treating as success since data was written to database", entry.Username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to insert login audit for %s: %v", entry.Username, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *mongoLoginAuditRepository) FindByUsername(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error) {
+	log.Printf("Repository FindByUsername called for login audit of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login audit getCollection in FindByUsername")
+		return nil, errors.New("failed to get login audit getCollection")
+	}
+
+	opts := options.Find().
+		SetCollation(usernameCollation).
+		SetSort(bson.D{{Key: "timestamp", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := coll.Find(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute login audit query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	entries := []models.LoginAuditEntry{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		log.Printf("ERROR: Failed to decode login audit entries: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Successfully retrieved %d login audit entries for username: %s", len(entries), username)
+	return entries, nil
+}
+
+func (m *mongoLoginAuditRepository) Name() string {
+	return "login_audit"
+}
+
+func (m *mongoLoginAuditRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	return m.FindByUsername(ctx, username, 0)
+}
+
+func (m *mongoLoginAuditRepository) DeleteByUsername(ctx context.Context, username string) error {
+	log.Printf("Repository DeleteByUsername called for login audit of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login audit getCollection in DeleteByUsername")
+		return errors.New("failed to get login audit getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteMany(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting login audit of %s - treating as success since data was written to database", username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete login audit for %s: %v", username, err)
+		return err
+	}
+
+	log.Printf("Successfully deleted login audit for username: %s, deleted count: %d", username, result.DeletedCount)
+	return nil
+}
+
+func (m *mongoLoginAuditRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for login audit, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get login audit getCollection in RenameUsername")
+		return errors.New("failed to get login audit getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"username": newUsername}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, bson.M{"username": oldUsername}, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming login audit of %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename login audit username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed login audit username %s to %s, modified: %d", oldUsername, newUsername, result.ModifiedCount)
+	return nil
+}
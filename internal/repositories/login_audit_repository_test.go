@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAuditRepository_Name(t *testing.T) {
+	repo := NewLoginAuditRepository(nil, "test", "login_audit")
+
+	assert.Equal(t, "login_audit", repo.Name())
+}
+
+func TestLoginAuditRepository_NilClient(t *testing.T) {
+	repo := NewLoginAuditRepository(nil, "test", "login_audit")
+	ctx := context.Background()
+
+	assert.Error(t, repo.Create(ctx, models.LoginAuditEntry{Username: "testuser"}))
+
+	entries, err := repo.FindByUsername(ctx, "testuser", 10)
+	assert.Error(t, err)
+	assert.Nil(t, entries)
+
+	assert.Error(t, repo.DeleteByUsername(ctx, "testuser"))
+	assert.Error(t, repo.RenameUsername(ctx, "old", "new"))
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MarketSnapshotRepository stores the daily market snapshots, one per date.
+type MarketSnapshotRepository interface {
+	Upsert(ctx context.Context, snapshot models.MarketSnapshot) error
+	Exists(ctx context.Context, date string) (bool, error)
+	FindRange(ctx context.Context, from, to string) ([]models.MarketSnapshot, error)
+}
+
+type mongoMarketSnapshotRepository struct {
+	database string
+}
+
+func NewMarketSnapshotRepository(client *mongo.Client, dbName, collectionName string) MarketSnapshotRepository {
+	log.Printf("Creating new MarketSnapshotRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoMarketSnapshotRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoMarketSnapshotRepository) getCollection() *mongo.Collection {
+	return config.GetMarketSnapshotsCollection(m.database)
+}
+
+func (m *mongoMarketSnapshotRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring unique index on market snapshots date field...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get market snapshots getCollection when ensuring indexes")
+		return errors.New("failed to get market snapshots getCollection")
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("date_unique"),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create market snapshots index: %v", err)
+		return err
+	}
+
+	log.Printf("Market snapshots index created successfully")
+	return nil
+}
+
+// Upsert stores the snapshot, replacing any earlier one taken on the same date.
+func (m *mongoMarketSnapshotRepository) Upsert(ctx context.Context, snapshot models.MarketSnapshot) error {
+	log.Printf("Repository Upsert called for market snapshot of %s", snapshot.Date)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get market snapshots getCollection in Upsert")
+		return errors.New("failed to get market snapshots getCollection")
+	}
+
+	// The stored document keeps its own _id.
+	snapshot.ID = primitive.NilObjectID
+	opts := options.Replace().SetUpsert(true)
+	if _, err := coll.ReplaceOne(ctx, bson.M{"date": snapshot.Date}, snapshot, opts); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for market snapshot of %s - treating as success since data was written to database", snapshot.Date)
+			return nil
+		}
+		log.Printf("ERROR: Failed to store market snapshot of %s: %v", snapshot.Date, err)
+		return err
+	}
+	return nil
+}
+
+func (m *mongoMarketSnapshotRepository) Exists(ctx context.Context, date string) (bool, error) {
+	log.Printf("Repository Exists called for market snapshot of %s", date)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get market snapshots getCollection in Exists")
+		return false, errors.New("failed to get market snapshots getCollection")
+	}
+
+	count, err := coll.CountDocuments(ctx, bson.M{"date": date}, options.Count().SetLimit(1))
+	if err != nil {
+		log.Printf("ERROR: Failed to look up market snapshot of %s: %v", date, err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindRange returns the snapshots between both dates, inclusive, oldest first.
+func (m *mongoMarketSnapshotRepository) FindRange(ctx context.Context, from, to string) ([]models.MarketSnapshot, error) {
+	log.Printf("Repository FindRange called for market snapshots from %s to %s", from, to)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get market snapshots getCollection in FindRange")
+		return nil, errors.New("failed to get market snapshots getCollection")
+	}
+
+	filter := bson.M{"date": bson.M{"$gte": from, "$lte": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute market snapshots query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	snapshots := []models.MarketSnapshot{}
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		log.Printf("ERROR: Failed to decode market snapshots: %v", err)
+		return nil, err
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketSnapshotRepository_NilClient(t *testing.T) {
+	repo := NewMarketSnapshotRepository(nil, "test", "market_snapshots")
+	ctx := context.Background()
+
+	assert.Error(t, repo.Upsert(ctx, models.MarketSnapshot{Date: "2026-06-01"}))
+	_, err := repo.Exists(ctx, "2026-06-01")
+	assert.Error(t, err)
+	_, err = repo.FindRange(ctx, "2026-05-01", "2026-06-01")
+	assert.Error(t, err)
+}
@@ -0,0 +1,444 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SavedSearchRepository stores saved searches. Every lookup is scoped to the owner, so a user
+// can never reach another user's search by ID.
+type SavedSearchRepository interface {
+	UserDataStore
+	Create(ctx context.Context, search models.SavedSearch) (models.SavedSearch, error)
+	FindAll(ctx context.Context) ([]models.SavedSearch, error)
+	FindByUsername(ctx context.Context, username string) ([]models.SavedSearch, error)
+	FindByID(ctx context.Context, username, id string) (models.SavedSearch, bool, error)
+	CountByUsername(ctx context.Context, username string) (int64, error)
+	ReserveSlot(ctx context.Context, username string, limit int) (bool, error)
+	ReleaseSlot(ctx context.Context, username string) error
+	Update(ctx context.Context, username, id string, request models.SavedSearchRequest) (bool, error)
+	MarkRun(ctx context.Context, username, id string, at time.Time) error
+	Delete(ctx context.Context, username, id string) (bool, error)
+}
+
+type mongoSavedSearchRepository struct {
+	database string
+}
+
+func NewSavedSearchRepository(client *mongo.Client, dbName, collectionName string) SavedSearchRepository {
+	log.Printf("Creating new SavedSearchRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoSavedSearchRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoSavedSearchRepository) getCollection() *mongo.Collection {
+	return config.GetSavedSearchesCollection(m.database)
+}
+
+// getCounterCollection holds one document per user with the number of saved searches they
+// hold, so the role limit can be enforced by a single atomic update.
+func (m *mongoSavedSearchRepository) getCounterCollection() *mongo.Collection {
+	return config.GetSavedSearchCountersCollection(m.database)
+}
+
+func (m *mongoSavedSearchRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring unique index on saved searches username and name fields...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection when ensuring indexes")
+		return errors.New("failed to get saved searches getCollection")
+	}
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetName("username_name_unique").
+			SetCollation(usernameCollation),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create saved searches index: %v", err)
+		return err
+	}
+
+	counters := m.getCounterCollection()
+	if counters == nil {
+		log.Printf("ERROR: Failed to get saved search counters getCollection when ensuring indexes")
+		return errors.New("failed to get saved search counters getCollection")
+	}
+	counterIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetName("username_unique").
+			SetCollation(usernameCollation),
+	}
+	if _, err := counters.Indexes().CreateOne(ctx, counterIndex); err != nil {
+		log.Printf("ERROR: Failed to create saved search counters index: %v", err)
+		return err
+	}
+
+	log.Printf("Saved searches index created successfully")
+	return nil
+}
+
+func (m *mongoSavedSearchRepository) Create(ctx context.Context, search models.SavedSearch) (models.SavedSearch, error) {
+	log.Printf("Repository Create called for saved search '%s' of username: %s", search.Name, search.Username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in Create")
+		return models.SavedSearch{}, errors.New("failed to get saved searches getCollection")
+	}
+
+	// A duplicate name must be reported so the caller can release the slot it reserved.
+	coll = acknowledged(coll)
+	search.ID = primitive.NewObjectID()
+	if _, err := coll.InsertOne(ctx, search); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("ERROR: Saved search '%s' already exists for username: %s", search.Name, search.Username)
+			return models.SavedSearch{}, &models.ConflictError{Field: "name", Value: search.Name}
+		}
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for saved search of %s - treating as success since data was written to database", search.Username)
+			return search, nil
+		}
+		log.Printf("ERROR: Failed to insert saved search for %s: %v", search.Username, err)
+		return models.SavedSearch{}, err
+	}
+
+	return search, nil
+}
+
+func (m *mongoSavedSearchRepository) FindAll(ctx context.Context) ([]models.SavedSearch, error) {
+	log.Printf("Repository FindAll called for saved searches")
+	return m.find(ctx, bson.M{})
+}
+
+func (m *mongoSavedSearchRepository) FindByUsername(ctx context.Context, username string) ([]models.SavedSearch, error) {
+	log.Printf("Repository FindByUsername called for saved searches of username: %s", username)
+	return m.find(ctx, bson.M{"username": username})
+}
+
+func (m *mongoSavedSearchRepository) FindByID(ctx context.Context, username, id string) (models.SavedSearch, bool, error) {
+	log.Printf("Repository FindByID called for saved search %s of username: %s", id, username)
+
+	filter, err := savedSearchFilter(username, id)
+	if err != nil {
+		return models.SavedSearch{}, false, err
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in FindByID")
+		return models.SavedSearch{}, false, errors.New("failed to get saved searches getCollection")
+	}
+
+	var search models.SavedSearch
+	opts := options.FindOne().SetCollation(usernameCollation)
+	if err := coll.FindOne(ctx, filter, opts).Decode(&search); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.SavedSearch{}, false, nil
+		}
+		log.Printf("ERROR: Failed to find saved search %s: %v", id, err)
+		return models.SavedSearch{}, false, err
+	}
+	return search, true, nil
+}
+
+func (m *mongoSavedSearchRepository) CountByUsername(ctx context.Context, username string) (int64, error) {
+	log.Printf("Repository CountByUsername called for saved searches of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in CountByUsername")
+		return 0, errors.New("failed to get saved searches getCollection")
+	}
+
+	opts := options.Count().SetCollation(usernameCollation)
+	count, err := coll.CountDocuments(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to count saved searches of %s: %v", username, err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// ReserveSlot takes one of the user's saved search slots, reporting false when all limit slots
+// are taken. The check and the increment are one update, so concurrent creates cannot both pass.
+// A user without a counter yet gets one seeded from the searches they already hold.
+func (m *mongoSavedSearchRepository) ReserveSlot(ctx context.Context, username string, limit int) (bool, error) {
+	log.Printf("Repository ReserveSlot called for saved searches of username: %s", username)
+
+	counters := m.getCounterCollection()
+	if counters == nil {
+		log.Printf("ERROR: Failed to get saved search counters getCollection in ReserveSlot")
+		return false, errors.New("failed to get saved search counters getCollection")
+	}
+	counters = acknowledged(counters)
+
+	reserved, err := incrementSavedSearchCounter(ctx, counters, username, limit)
+	if err != nil || reserved {
+		return reserved, err
+	}
+
+	count, err := m.CountByUsername(ctx, username)
+	if err != nil {
+		return false, err
+	}
+	update := bson.M{"$setOnInsert": bson.M{"username": username, "count": count}}
+	opts := options.Update().SetUpsert(true).SetCollation(usernameCollation)
+	result, err := counters.UpdateOne(ctx, bson.M{"username": username}, update, opts)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		log.Printf("ERROR: Failed to seed saved search counter of %s: %v", username, err)
+		return false, err
+	}
+	if err == nil && result.UpsertedCount == 0 {
+		// The counter already existed, so the limit really is reached.
+		return false, nil
+	}
+	return incrementSavedSearchCounter(ctx, counters, username, limit)
+}
+
+// ReleaseSlot gives back a slot taken by ReserveSlot for a search that was not stored.
+func (m *mongoSavedSearchRepository) ReleaseSlot(ctx context.Context, username string) error {
+	log.Printf("Repository ReleaseSlot called for saved searches of username: %s", username)
+
+	counters := m.getCounterCollection()
+	if counters == nil {
+		log.Printf("ERROR: Failed to get saved search counters getCollection in ReleaseSlot")
+		return errors.New("failed to get saved search counters getCollection")
+	}
+
+	filter := bson.M{"username": username, "count": bson.M{"$gt": 0}}
+	opts := options.Update().SetCollation(usernameCollation)
+	if _, err := counters.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"count": -1}}, opts); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			return nil
+		}
+		log.Printf("ERROR: Failed to release saved search slot of %s: %v", username, err)
+		return err
+	}
+	return nil
+}
+
+// dropCounter removes the user's counter; the next ReserveSlot seeds it again from the stored
+// searches.
+func (m *mongoSavedSearchRepository) dropCounter(ctx context.Context, username string) error {
+	counters := m.getCounterCollection()
+	if counters == nil {
+		log.Printf("ERROR: Failed to get saved search counters getCollection in dropCounter")
+		return errors.New("failed to get saved search counters getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	if _, err := counters.DeleteOne(ctx, bson.M{"username": username}, opts); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete saved search counter of %s: %v", username, err)
+		return err
+	}
+	return nil
+}
+
+// Update replaces the name and filter, reporting whether the search exists.
+func (m *mongoSavedSearchRepository) Update(ctx context.Context, username, id string, request models.SavedSearchRequest) (bool, error) {
+	log.Printf("Repository Update called for saved search %s of username: %s", id, username)
+
+	update := bson.M{"$set": bson.M{"name": request.Name, "filter": request.Filter}}
+	found, err := m.updateOne(ctx, username, id, update)
+	if mongo.IsDuplicateKeyError(err) {
+		log.Printf("ERROR: Saved search '%s' already exists for username: %s", request.Name, username)
+		return false, &models.ConflictError{Field: "name", Value: request.Name}
+	}
+	return found, err
+}
+
+func (m *mongoSavedSearchRepository) MarkRun(ctx context.Context, username, id string, at time.Time) error {
+	log.Printf("Repository MarkRun called for saved search %s of username: %s", id, username)
+
+	_, err := m.updateOne(ctx, username, id, bson.M{"$set": bson.M{"lastRunAt": at}})
+	return err
+}
+
+func (m *mongoSavedSearchRepository) Delete(ctx context.Context, username, id string) (bool, error) {
+	log.Printf("Repository Delete called for saved search %s of username: %s", id, username)
+
+	filter, err := savedSearchFilter(username, id)
+	if err != nil {
+		return false, err
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in Delete")
+		return false, errors.New("failed to get saved searches getCollection")
+	}
+
+	// The slot is only given back when a search was really deleted, so the count must be known.
+	coll = acknowledged(coll)
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteOne(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to delete saved search %s: %v", id, err)
+		return false, err
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+	if err := m.ReleaseSlot(ctx, username); err != nil {
+		log.Printf("WARNING: Saved search %s deleted but its slot was not released: %v", id, err)
+	}
+	return true, nil
+}
+
+func (m *mongoSavedSearchRepository) Name() string {
+	return "saved_searches"
+}
+
+func (m *mongoSavedSearchRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	log.Printf("Repository ExportByUsername called for saved searches of username: %s", username)
+	return m.find(ctx, bson.M{"username": username})
+}
+
+func (m *mongoSavedSearchRepository) DeleteByUsername(ctx context.Context, username string) error {
+	log.Printf("Repository DeleteByUsername called for saved searches of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in DeleteByUsername")
+		return errors.New("failed to get saved searches getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteMany(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting saved searches of %s - treating as success since data was written to database", username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete saved searches of %s: %v", username, err)
+		return err
+	}
+
+	log.Printf("Successfully deleted %d saved searches of %s", result.DeletedCount, username)
+	return m.dropCounter(ctx, username)
+}
+
+func (m *mongoSavedSearchRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for saved searches, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in RenameUsername")
+		return errors.New("failed to get saved searches getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"username": newUsername}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, bson.M{"username": oldUsername}, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming saved searches of %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename saved searches username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed saved searches username %s to %s, modified: %d", oldUsername, newUsername, result.ModifiedCount)
+	return m.dropCounter(ctx, oldUsername)
+}
+
+func (m *mongoSavedSearchRepository) find(ctx context.Context, filter bson.M) ([]models.SavedSearch, error) {
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in find")
+		return nil, errors.New("failed to get saved searches getCollection")
+	}
+
+	opts := options.Find().SetCollation(usernameCollation).SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute saved searches query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	searches := []models.SavedSearch{}
+	if err = cursor.All(ctx, &searches); err != nil {
+		log.Printf("ERROR: Failed to decode saved searches: %v", err)
+		return nil, err
+	}
+	return searches, nil
+}
+
+func (m *mongoSavedSearchRepository) updateOne(ctx context.Context, username, id string, update bson.M) (bool, error) {
+	filter, err := savedSearchFilter(username, id)
+	if err != nil {
+		return false, err
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get saved searches getCollection in updateOne")
+		return false, errors.New("failed to get saved searches getCollection")
+	}
+
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for saved search %s - treating as success since data was written to database", id)
+			return true, nil
+		}
+		log.Printf("ERROR: Failed to update saved search %s: %v", id, err)
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// incrementSavedSearchCounter takes a slot when the user's counter exists and is below limit.
+func incrementSavedSearchCounter(ctx context.Context, counters *mongo.Collection, username string, limit int) (bool, error) {
+	filter := bson.M{"username": username, "count": bson.M{"$lt": limit}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := counters.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"count": 1}}, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to reserve saved search slot of %s: %v", username, err)
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func savedSearchFilter(username, id string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Printf("ERROR: Invalid ObjectID format for saved search ID %s: %v", id, err)
+		return nil, errors.New("invalid saved search ID format")
+	}
+	return bson.M{"_id": objectID, "username": username}, nil
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchRepository_Name(t *testing.T) {
+	repo := NewSavedSearchRepository(nil, "test", "saved_searches")
+
+	assert.Equal(t, "saved_searches", repo.Name())
+}
+
+func TestSavedSearchRepository_InvalidID(t *testing.T) {
+	repo := NewSavedSearchRepository(nil, "test", "saved_searches")
+
+	_, _, err := repo.FindByID(context.Background(), "testuser", "not-an-id")
+	assert.EqualError(t, err, "invalid saved search ID format")
+}
+
+func TestSavedSearchRepository_NilClient(t *testing.T) {
+	repo := NewSavedSearchRepository(nil, "test", "saved_searches")
+	ctx := context.Background()
+	id := "68e462f868efefe99e226a8b"
+
+	_, err := repo.Create(ctx, models.SavedSearch{Username: "testuser", Name: "Go"})
+	assert.Error(t, err)
+	_, err = repo.FindAll(ctx)
+	assert.Error(t, err)
+	_, err = repo.FindByUsername(ctx, "testuser")
+	assert.Error(t, err)
+	_, _, err = repo.FindByID(ctx, "testuser", id)
+	assert.Error(t, err)
+	_, err = repo.CountByUsername(ctx, "testuser")
+	assert.Error(t, err)
+	_, err = repo.ReserveSlot(ctx, "testuser", 3)
+	assert.Error(t, err)
+	assert.Error(t, repo.ReleaseSlot(ctx, "testuser"))
+	_, err = repo.Update(ctx, "testuser", id, models.SavedSearchRequest{Name: "Go"})
+	assert.Error(t, err)
+	assert.Error(t, repo.MarkRun(ctx, "testuser", id, time.Now()))
+	_, err = repo.Delete(ctx, "testuser", id)
+	assert.Error(t, err)
+
+	assert.Error(t, repo.DeleteByUsername(ctx, "testuser"))
+	assert.Error(t, repo.RenameUsername(ctx, "old", "new"))
+	_, err = repo.ExportByUsername(ctx, "testuser")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"jboard-go-crud/internal/config"
 	"jboard-go-crud/internal/models"
 	"log"
@@ -10,17 +11,24 @@ import (
 	"github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var skillValidate = validator.New()
 
 type SkillRepository interface {
+	UserDataStore
 	FindAll(ctx context.Context) ([]models.Skill, error)
 	FindByUsername(ctx context.Context, username string) (models.Skill, bool, error)
 	Create(ctx context.Context, skillRequest models.SkillRequest) error
 	AddSkill(ctx context.Context, skillRequest models.SkillRequest) error
 	RemoveSkill(ctx context.Context, skillRequest models.SkillRequest) error
-	DeleteByUsername(ctx context.Context, username string) error
+	SetSkills(ctx context.Context, username string, skills []models.SkillEntry) ([]models.SkillEntry, error)
+	SetSkillsIfUnchanged(ctx context.Context, username string, expected, skills []models.SkillEntry) (bool, error)
+	FindDuplicateUsernames(ctx context.Context) ([]models.DuplicateUsername, error)
+	EnsureUsernameIndex(ctx context.Context) error
+	MigrateLegacySkills(ctx context.Context) (int64, error)
+	CountUsersBySkill(ctx context.Context) (map[string]int, error)
 }
 
 type mongoSkillRepository struct {
@@ -30,16 +38,39 @@ type mongoSkillRepository struct {
 
 func NewSkillRepository(client *mongo.Client, dbName, collectionName string) SkillRepository {
 	log.Printf("Creating new SkillRepository with database: %s, getCollection: %s", dbName, collectionName)
-	return &mongoSkillRepository{
+	repo := &mongoSkillRepository{
 		database: dbName,
 		client:   client,
 	}
+	if client == nil {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
 }
 
 func (r *mongoSkillRepository) getCollection() *mongo.Collection {
 	return config.GetSkillsCollection(r.database)
 }
 
+// EnsureUsernameIndex is run from a migration rather than the constructor because the unique
+// index cannot be built while duplicate usernames exist.
+func (r *mongoSkillRepository) EnsureUsernameIndex(ctx context.Context) error {
+	log.Printf("Repository EnsureUsernameIndex called for skills")
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in EnsureUsernameIndex")
+		return errors.New("failed to get skills getCollection")
+	}
+
+	if err := ensureUsernameIndex(ctx, coll); err != nil {
+		return err
+	}
+
+	log.Printf("Skills username index created successfully")
+	return nil
+}
+
 func (r *mongoSkillRepository) FindAll(ctx context.Context) ([]models.Skill, error) {
 	log.Printf("Repository FindAll called for skills")
 
@@ -69,7 +100,8 @@ func (r *mongoSkillRepository) FindByUsername(ctx context.Context, username stri
 
 	var skill models.Skill
 	filter := bson.M{"username": username}
-	err := r.getCollection().FindOne(ctx, filter).Decode(&skill)
+	opts := options.FindOne().SetCollation(usernameCollation)
+	err := r.getCollection().FindOne(ctx, filter, opts).Decode(&skill)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			log.Printf("Skills not found for username: %s", username)
@@ -92,12 +124,24 @@ func (r *mongoSkillRepository) Create(ctx context.Context, skillRequest models.S
 	}
 	log.Printf("Validation passed for username: %s", skillRequest.Username)
 
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in Create")
+		return errors.New("failed to get skills getCollection")
+	}
+	// AddSkill falls back to appending when this reports a conflict, which needs an acknowledged write.
+	coll = acknowledged(coll)
+
 	skill := models.Skill{
 		Username: skillRequest.Username,
-		Skills:   []string{skillRequest.Skill},
+		Skills:   []models.SkillEntry{skillRequest.Entry()},
 	}
-	_, err := r.getCollection().InsertOne(ctx, skill)
+	_, err := coll.InsertOne(ctx, skill)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("ERROR: Skills document already exists for username: %s", skillRequest.Username)
+			return &models.ConflictError{Field: "username", Value: skillRequest.Username}
+		}
 		if strings.Contains(err.Error(), "unacknowledged write") {
 			log.Printf("Unacknowledged write for skill user %s - treating as success since data was written to database", skillRequest.Username)
 			return nil
@@ -110,14 +154,25 @@ func (r *mongoSkillRepository) Create(ctx context.Context, skillRequest models.S
 	return nil
 }
 
+// AddSkill replaces the entry with the same name (case-insensitively) or appends a new one.
 func (r *mongoSkillRepository) AddSkill(ctx context.Context, skillRequest models.SkillRequest) error {
 	log.Printf("Repository AddSkill called for username: %s, skill: %s", skillRequest.Username, skillRequest.Skill)
 
-	filter := bson.M{"username": skillRequest.Username}
+	entry := skillRequest.Entry()
+	opts := options.Update().SetCollation(usernameCollation)
+
+	filter := bson.M{"username": skillRequest.Username, "skills.name": entry.Name}
 	update := bson.M{
-		"$addToSet": bson.M{"skills": skillRequest.Skill},
+		"$set": bson.M{"skills.$": entry},
+	}
+	result, err := r.getCollection().UpdateOne(ctx, filter, update, opts)
+	if err == nil && result.MatchedCount == 0 {
+		filter = bson.M{"username": skillRequest.Username, "skills.name": bson.M{"$ne": entry.Name}}
+		update = bson.M{
+			"$push": bson.M{"skills": entry},
+		}
+		result, err = r.getCollection().UpdateOne(ctx, filter, update, opts)
 	}
-	result, err := r.getCollection().UpdateOne(ctx, filter, update)
 	if err != nil {
 		if strings.Contains(err.Error(), "unacknowledged write") {
 			log.Printf("Unacknowledged write for adding skill to user %s - treating as success since data was written to database", skillRequest.Username)
@@ -136,9 +191,10 @@ func (r *mongoSkillRepository) RemoveSkill(ctx context.Context, skillRequest mod
 
 	filter := bson.M{"username": skillRequest.Username}
 	update := bson.M{
-		"$pull": bson.M{"skills": skillRequest.Skill},
+		"$pull": bson.M{"skills": bson.M{"name": skillRequest.Skill}},
 	}
-	result, err := r.getCollection().UpdateOne(ctx, filter, update)
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := r.getCollection().UpdateOne(ctx, filter, update, opts)
 	if err != nil {
 		if strings.Contains(err.Error(), "unacknowledged write") {
 			log.Printf("Unacknowledged write for removing skill from user %s - treating as success since data was written to database", skillRequest.Username)
@@ -152,11 +208,91 @@ func (r *mongoSkillRepository) RemoveSkill(ctx context.Context, skillRequest mod
 	return nil
 }
 
+// SetSkills atomically replaces the user's skill list, creating the document when needed, and
+// returns the list it replaced.
+func (r *mongoSkillRepository) SetSkills(ctx context.Context, username string, skills []models.SkillEntry) ([]models.SkillEntry, error) {
+	log.Printf("Repository SetSkills called for username: %s with %d skills", username, len(skills))
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in SetSkills")
+		return nil, errors.New("failed to get skills getCollection")
+	}
+	// The replaced list is read back, which an unacknowledged write cannot do.
+	coll = acknowledged(coll)
+
+	filter := bson.M{"username": username}
+	update := bson.M{
+		"$set": bson.M{"skills": skills},
+	}
+	opts := options.FindOneAndUpdate().
+		SetCollation(usernameCollation).
+		SetUpsert(true).
+		SetReturnDocument(options.Before)
+
+	var previous models.Skill
+	if err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("Created skills document for username: %s", username)
+			return []models.SkillEntry{}, nil
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("ERROR: Skills document created concurrently for username: %s", username)
+			return nil, &models.ConflictError{Field: "username", Value: username}
+		}
+		log.Printf("ERROR: Failed to set skills for username %s: %v", username, err)
+		return nil, err
+	}
+
+	log.Printf("Successfully set skills for username: %s, previously %d skills", username, len(previous.Skills))
+	return previous.Skills, nil
+}
+
+// SetSkillsIfUnchanged replaces the user's skill list only while the stored list still equals
+// expected, creating the document when expected is nil and none exists. It reports false when
+// another write changed the list in the meantime; a document created concurrently surfaces as
+// a duplicate key on the unique username index and is reported the same way.
+func (r *mongoSkillRepository) SetSkillsIfUnchanged(ctx context.Context, username string, expected, skills []models.SkillEntry) (bool, error) {
+	log.Printf("Repository SetSkillsIfUnchanged called for username: %s with %d skills", username, len(skills))
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in SetSkillsIfUnchanged")
+		return false, errors.New("failed to get skills getCollection")
+	}
+	// Whether the list matched is only known from an acknowledged write.
+	coll = acknowledged(coll)
+
+	filter := bson.M{"username": username, "skills": expected}
+	update := bson.M{
+		"$set": bson.M{"skills": skills},
+	}
+	// Only a missing document may be created; one deleted since it was read stays deleted.
+	opts := options.Update().SetCollation(usernameCollation).SetUpsert(expected == nil)
+	result, err := coll.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("Skills of username %s changed concurrently", username)
+			return false, nil
+		}
+		log.Printf("ERROR: Failed to set skills for username %s: %v", username, err)
+		return false, err
+	}
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		log.Printf("Skills of username %s changed concurrently", username)
+		return false, nil
+	}
+
+	log.Printf("Successfully set skills for username: %s, matched: %d, upserted: %d", username, result.MatchedCount, result.UpsertedCount)
+	return true, nil
+}
+
 func (r *mongoSkillRepository) DeleteByUsername(ctx context.Context, username string) error {
 	log.Printf("Repository DeleteByUsername called for username: %s", username)
 
 	filter := bson.M{"username": username}
-	result, err := r.getCollection().DeleteOne(ctx, filter)
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := r.getCollection().DeleteMany(ctx, filter, opts)
 	if err != nil {
 		if strings.Contains(err.Error(), "unacknowledged write") {
 			log.Printf("Unacknowledged write for deleting skills of user %s - treating as success since data was written to database", username)
@@ -169,3 +305,153 @@ func (r *mongoSkillRepository) DeleteByUsername(ctx context.Context, username st
 	log.Printf("Successfully deleted skills for username: %s, deleted count: %d", username, result.DeletedCount)
 	return nil
 }
+
+func (r *mongoSkillRepository) Name() string {
+	return "skills"
+}
+
+func (r *mongoSkillRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	log.Printf("Repository ExportByUsername called for skills of username: %s", username)
+
+	skill, found, err := r.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []models.SkillEntry{}, nil
+	}
+	return skill.Skills, nil
+}
+
+func (r *mongoSkillRepository) FindDuplicateUsernames(ctx context.Context) ([]models.DuplicateUsername, error) {
+	log.Printf("Repository FindDuplicateUsernames called for skills")
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in FindDuplicateUsernames")
+		return nil, errors.New("failed to get skills getCollection")
+	}
+
+	duplicates, err := findDuplicateUsernames(ctx, coll)
+	if err != nil {
+		log.Printf("ERROR: Failed to look up duplicate usernames in skills: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Found %d duplicate username groups in skills", len(duplicates))
+	return duplicates, nil
+}
+
+func (r *mongoSkillRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for skills, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in RenameUsername")
+		return errors.New("failed to get skills getCollection")
+	}
+	coll = acknowledged(coll)
+
+	filter := bson.M{"username": oldUsername}
+	update := bson.M{
+		"$set": bson.M{"username": newUsername},
+	}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("ERROR: Skills document already exists for username: %s", newUsername)
+			return &models.ConflictError{Field: "username", Value: newUsername}
+		}
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming skills of user %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename skills username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed skills username %s to %s, matched: %d, modified: %d", oldUsername, newUsername, result.MatchedCount, result.ModifiedCount)
+	return nil
+}
+
+// MigrateLegacySkills rewrites skill lists still stored as plain strings into entries that
+// carry only the name. Documents already converted are not matched, so it is safe to rerun.
+func (r *mongoSkillRepository) MigrateLegacySkills(ctx context.Context) (int64, error) {
+	log.Printf("Repository MigrateLegacySkills called")
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in MigrateLegacySkills")
+		return 0, errors.New("failed to get skills getCollection")
+	}
+
+	filter := bson.M{"skills": bson.M{"$type": "string"}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"skills": bson.M{"$map": bson.M{
+				"input": "$skills",
+				"as":    "skill",
+				"in": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{bson.M{"$type": "$$skill"}, "string"}},
+					bson.M{"name": "$$skill"},
+					"$$skill",
+				}},
+			}},
+		}}},
+	}
+	result, err := coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for migrating legacy skills - treating as success since data was written to database")
+			return 0, nil
+		}
+		log.Printf("ERROR: Failed to migrate legacy skills: %v", err)
+		return 0, err
+	}
+
+	log.Printf("Successfully migrated legacy skills, modified: %d", result.ModifiedCount)
+	return result.ModifiedCount, nil
+}
+
+// CountUsersBySkill returns, per stored skill name, how many users list it.
+func (r *mongoSkillRepository) CountUsersBySkill(ctx context.Context) (map[string]int, error) {
+	log.Printf("Repository CountUsersBySkill called")
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skills getCollection in CountUsersBySkill")
+		return nil, errors.New("failed to get skills getCollection")
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$skills"}},
+		{{Key: "$group", Value: bson.M{"_id": "$skills.name", "users": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("ERROR: Failed to aggregate skill counts: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var rows []struct {
+		Name  string `bson:"_id"`
+		Users int    `bson:"users"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		log.Printf("ERROR: Failed to decode skill counts: %v", err)
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Name] += row.Users
+	}
+	log.Printf("Counted users for %d skill names", len(counts))
+	return counts, nil
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,3 +26,68 @@ func TestSkillRepository_Collection(t *testing.T) {
 	assert.NotNil(t, repo)
 	assert.Equal(t, "test", repo.database)
 }
+
+func TestSkillRepository_FindDuplicateUsernames_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	duplicates, err := repo.FindDuplicateUsernames(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, duplicates)
+}
+
+func TestSkillRepository_EnsureUsernameIndex_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	assert.Error(t, repo.EnsureUsernameIndex(context.Background()))
+}
+
+func TestSkillRepository_Name(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	assert.Equal(t, "skills", repo.Name())
+}
+
+func TestSkillRepository_RenameUsername_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	err := repo.RenameUsername(context.Background(), "old", "new")
+
+	assert.Error(t, err)
+}
+
+func TestSkillRepository_MigrateLegacySkills_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	migrated, err := repo.MigrateLegacySkills(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, migrated)
+}
+
+func TestSkillRepository_CountUsersBySkill_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	counts, err := repo.CountUsersBySkill(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, counts)
+}
+
+func TestSkillRepository_SetSkills_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	previous, err := repo.SetSkills(context.Background(), "testuser", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, previous)
+}
+
+func TestSkillRepository_SetSkillsIfUnchanged_NilClient(t *testing.T) {
+	repo := NewSkillRepository(nil, "test", "skills")
+
+	stored, err := repo.SetSkillsIfUnchanged(context.Background(), "testuser", nil, nil)
+
+	assert.Error(t, err)
+	assert.False(t, stored)
+}
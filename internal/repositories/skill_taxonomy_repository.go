@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SkillTaxonomyRepository interface {
+	FindAll(ctx context.Context, category enums.SkillCategoryEnum) ([]models.CanonicalSkill, error)
+	FindByAlias(ctx context.Context, name string) (models.CanonicalSkill, bool, error)
+	Upsert(ctx context.Context, skill models.CanonicalSkill) error
+	Delete(ctx context.Context, key string) error
+	SeedDefaults(ctx context.Context, skills []models.CanonicalSkill) (int64, error)
+}
+
+type mongoSkillTaxonomyRepository struct {
+	database string
+}
+
+func NewSkillTaxonomyRepository(client *mongo.Client, dbName, collectionName string) SkillTaxonomyRepository {
+	log.Printf("Creating new SkillTaxonomyRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoSkillTaxonomyRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (r *mongoSkillTaxonomyRepository) getCollection() *mongo.Collection {
+	return config.GetSkillTaxonomyCollection(r.database)
+}
+
+// ensureIndexes makes every alias resolve to a single canonical skill.
+func (r *mongoSkillTaxonomyRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring unique index on skill taxonomy aliases field...")
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skill taxonomy getCollection when ensuring indexes")
+		return errors.New("failed to get skill taxonomy getCollection")
+	}
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "aliases", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("aliases_unique"),
+		},
+		{
+			Keys: bson.D{{Key: "category", Value: 1}, {Key: "name", Value: 1}},
+		},
+	}
+	if _, err := coll.Indexes().CreateMany(ctx, indexModels); err != nil {
+		log.Printf("ERROR: Failed to create skill taxonomy indexes: %v", err)
+		return err
+	}
+
+	log.Printf("Skill taxonomy indexes created successfully")
+	return nil
+}
+
+func (r *mongoSkillTaxonomyRepository) FindAll(ctx context.Context, category enums.SkillCategoryEnum) ([]models.CanonicalSkill, error) {
+	log.Printf("Repository FindAll called for skill taxonomy, category: '%s'", category)
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skill taxonomy getCollection in FindAll")
+		return nil, errors.New("failed to get skill taxonomy getCollection")
+	}
+
+	filter := bson.M{}
+	if category != "" {
+		filter["category"] = category
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "category", Value: 1}, {Key: "name", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute skill taxonomy query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	skills := []models.CanonicalSkill{}
+	if err = cursor.All(ctx, &skills); err != nil {
+		log.Printf("ERROR: Failed to decode skill taxonomy: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Successfully retrieved %d canonical skills", len(skills))
+	return skills, nil
+}
+
+// FindByAlias resolves any spelling of a skill to its canonical entry.
+func (r *mongoSkillTaxonomyRepository) FindByAlias(ctx context.Context, name string) (models.CanonicalSkill, bool, error) {
+	log.Printf("Repository FindByAlias called for name: %s", name)
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skill taxonomy getCollection in FindByAlias")
+		return models.CanonicalSkill{}, false, errors.New("failed to get skill taxonomy getCollection")
+	}
+
+	var skill models.CanonicalSkill
+	if err := coll.FindOne(ctx, bson.M{"aliases": models.SkillKey(name)}).Decode(&skill); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.CanonicalSkill{}, false, nil
+		}
+		log.Printf("ERROR: Failed to find canonical skill for %s: %v", name, err)
+		return models.CanonicalSkill{}, false, err
+	}
+	return skill, true, nil
+}
+
+func (r *mongoSkillTaxonomyRepository) Upsert(ctx context.Context, skill models.CanonicalSkill) error {
+	log.Printf("Repository Upsert called for canonical skill: %s", skill.Key)
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skill taxonomy getCollection in Upsert")
+		return errors.New("failed to get skill taxonomy getCollection")
+	}
+
+	// A merge relies on the duplicate-key error to restore what it absorbed, so it must be reported.
+	coll = acknowledged(coll)
+	opts := options.Replace().SetUpsert(true)
+	if _, err := coll.ReplaceOne(ctx, bson.M{"_id": skill.Key}, skill, opts); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			log.Printf("ERROR: An alias of %s already belongs to another canonical skill", skill.Key)
+			return &models.ConflictError{Field: "alias", Value: strings.Join(skill.Aliases, ", ")}
+		}
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for canonical skill %s - treating as success since data was written to database", skill.Key)
+			return nil
+		}
+		log.Printf("ERROR: Failed to upsert canonical skill %s: %v", skill.Key, err)
+		return err
+	}
+
+	log.Printf("Successfully upserted canonical skill: %s", skill.Key)
+	return nil
+}
+
+func (r *mongoSkillTaxonomyRepository) Delete(ctx context.Context, key string) error {
+	log.Printf("Repository Delete called for canonical skill: %s", key)
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skill taxonomy getCollection in Delete")
+		return errors.New("failed to get skill taxonomy getCollection")
+	}
+
+	if _, err := coll.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting canonical skill %s - treating as success since data was written to database", key)
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete canonical skill %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+// SeedDefaults inserts the skills that are not in the collection yet and never overwrites
+// entries an admin has edited. Skills whose aliases are already taken are skipped.
+func (r *mongoSkillTaxonomyRepository) SeedDefaults(ctx context.Context, skills []models.CanonicalSkill) (int64, error) {
+	log.Printf("Repository SeedDefaults called with %d canonical skills", len(skills))
+
+	coll := r.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get skill taxonomy getCollection in SeedDefaults")
+		return 0, errors.New("failed to get skill taxonomy getCollection")
+	}
+
+	var inserted int64
+	opts := options.Update().SetUpsert(true)
+	for _, skill := range skills {
+		update := bson.M{"$setOnInsert": skill}
+		result, err := coll.UpdateOne(ctx, bson.M{"_id": skill.Key}, update, opts)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				log.Printf("WARNING: Skipping default skill %s, one of its aliases is already in use", skill.Key)
+				continue
+			}
+			if strings.Contains(err.Error(), "unacknowledged write") {
+				continue
+			}
+			log.Printf("ERROR: Failed to seed canonical skill %s: %v", skill.Key, err)
+			return inserted, err
+		}
+		if result.UpsertedCount > 0 {
+			inserted++
+		}
+	}
+
+	log.Printf("Seeded %d canonical skills", inserted)
+	return inserted, nil
+}
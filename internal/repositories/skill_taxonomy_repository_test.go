@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkillTaxonomyRepository_NilClient(t *testing.T) {
+	repo := NewSkillTaxonomyRepository(nil, "test", "skill_taxonomy")
+	ctx := context.Background()
+
+	skills, err := repo.FindAll(ctx, "")
+	assert.Error(t, err)
+	assert.Nil(t, skills)
+
+	_, found, err := repo.FindByAlias(ctx, "golang")
+	assert.Error(t, err)
+	assert.False(t, found)
+
+	assert.Error(t, repo.Upsert(ctx, models.CanonicalSkill{Key: "go", Name: "Go"}))
+	assert.Error(t, repo.Delete(ctx, "go"))
+
+	seeded, err := repo.SeedDefaults(ctx, models.DefaultSkillTaxonomy())
+	assert.Error(t, err)
+	assert.Zero(t, seeded)
+}
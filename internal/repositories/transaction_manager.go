@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// illegalOperationCode is returned by standalone servers, which do not support transactions.
+const illegalOperationCode = 20
+
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type mongoTransactionManager struct {
+	client *mongo.Client
+}
+
+func NewTransactionManager(client *mongo.Client) TransactionManager {
+	log.Printf("Creating new TransactionManager")
+	return &mongoTransactionManager{
+		client: client,
+	}
+}
+
+// WithTransaction runs fn inside a MongoDB transaction when the deployment supports one and
+// falls back to running it without a transaction otherwise. Repositories join the transaction
+// through the context passed to fn.
+func (m *mongoTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.client == nil {
+		log.Printf("WARNING: MongoDB client is nil, running without transaction")
+		return fn(ctx)
+	}
+
+	session, err := m.client.StartSession()
+	if err != nil {
+		log.Printf("WARNING: Failed to start session, running without transaction: %v", err)
+		return fn(ctx)
+	}
+	defer session.EndSession(ctx)
+
+	// Transactions do not accept the w=0 write concern used by the connection string.
+	txnOpts := options.Transaction().SetWriteConcern(writeconcern.Majority())
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	}, txnOpts)
+	if err != nil && transactionsUnsupported(err) {
+		log.Printf("WARNING: Transactions not supported by deployment, running without transaction: %v", err)
+		return fn(ctx)
+	}
+	if err != nil {
+		log.Printf("ERROR: Transaction failed: %v", err)
+		return err
+	}
+
+	log.Printf("Transaction committed successfully")
+	return nil
+}
+
+func transactionsUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == illegalOperationCode {
+		return true
+	}
+	return strings.Contains(err.Error(), "Transaction numbers are only allowed")
+}
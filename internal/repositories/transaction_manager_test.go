@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestTransactionManager_NilClientRunsFunction(t *testing.T) {
+	manager := NewTransactionManager(nil)
+
+	called := false
+	err := manager.WithTransaction(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if !called {
+		t.Error("Expected function to be called")
+	}
+}
+
+func TestTransactionManager_NilClientReturnsFunctionError(t *testing.T) {
+	manager := NewTransactionManager(nil)
+
+	err := manager.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return errors.New("operation failed")
+	})
+
+	if err == nil || err.Error() != "operation failed" {
+		t.Errorf("Expected 'operation failed', got %v", err)
+	}
+}
+
+func TestTransactionsUnsupported(t *testing.T) {
+	if !transactionsUnsupported(mongo.CommandError{Code: illegalOperationCode, Message: "not supported"}) {
+		t.Error("Expected IllegalOperation to be treated as unsupported")
+	}
+	if !transactionsUnsupported(errors.New("Transaction numbers are only allowed on a replica set member or mongos")) {
+		t.Error("Expected standalone server message to be treated as unsupported")
+	}
+	if transactionsUnsupported(errors.New("write conflict")) {
+		t.Error("Expected other errors to be reported")
+	}
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "context"
+
+// UserDataStore is implemented by every repository that holds documents owned by a user,
+// so that account deletion, renames and data export requests cover all of them.
+type UserDataStore interface {
+	Name() string
+	ExportByUsername(ctx context.Context, username string) (any, error)
+	DeleteByUsername(ctx context.Context, username string) error
+	RenameUsername(ctx context.Context, oldUsername, newUsername string) error
+}
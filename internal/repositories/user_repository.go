@@ -5,13 +5,17 @@ import (
 	"errors"
 	"jboard-go-crud/internal/config"
 	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
 	"log"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var userValidate = validator.New()
@@ -20,8 +24,17 @@ type UserRepository interface {
 	Create(ctx context.Context, user models.User) error
 	FindByID(ctx context.Context, id string) (models.User, bool, error)
 	FindByUsername(ctx context.Context, username string) (models.User, bool, error)
+	FindByEmail(ctx context.Context, email string) (models.User, bool, error)
 	UpdateByID(ctx context.Context, id string, user models.User) error
+	UpdateFields(ctx context.Context, id string, user models.User, fields ...string) error
 	DeleteByID(ctx context.Context, id string) error
+	UpdateSubscription(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error)
+	UpdateProfile(ctx context.Context, id string, profile models.UserProfile) error
+	FindExpiredSubscriptions(ctx context.Context, now time.Time) ([]models.User, error)
+	FindDuplicateUsernames(ctx context.Context) ([]models.DuplicateUsername, error)
+	EnsureUsernameIndex(ctx context.Context) error
+	FindUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error)
+	CountUsersByRole(ctx context.Context, filter models.UserFilter) (map[enums.RoleEnum]int64, error)
 }
 
 type mongoUserRepository struct {
@@ -46,8 +59,10 @@ func (m *mongoUserRepository) getCollection() *mongo.Collection {
 	return config.GetUsersCollection(m.database)
 }
 
+// ensureIndexes leaves out the unique username index, which cannot be built while duplicate
+// usernames exist; EnsureUsernameIndex creates it from a migration once they are resolved.
 func (m *mongoUserRepository) ensureIndexes(ctx context.Context) error {
-	log.Printf("Ensuring unique index on username field...")
+	log.Printf("Ensuring unique index on email field...")
 
 	coll := m.getCollection()
 	if coll == nil {
@@ -55,12 +70,30 @@ func (m *mongoUserRepository) ensureIndexes(ctx context.Context) error {
 		return errors.New("failed to get users getCollection")
 	}
 
-	indexModel := mongo.IndexModel{
-		Keys: bson.D{{Key: "username", Value: 1}},
+	// Sparse, so the many accounts created before emails existed do not collide on a missing value.
+	emailModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetName(emailIndexName).SetUnique(true).SetSparse(true),
 	}
-	_, err := coll.Indexes().CreateOne(ctx, indexModel)
-	if err != nil {
-		log.Printf("ERROR: Failed to create username index: %v", err)
+	if _, err := coll.Indexes().CreateOne(ctx, emailModel); err != nil {
+		log.Printf("ERROR: Failed to create unique email index: %v", err)
+		return err
+	}
+
+	log.Printf("Email index created successfully")
+	return nil
+}
+
+func (m *mongoUserRepository) EnsureUsernameIndex(ctx context.Context) error {
+	log.Printf("Repository EnsureUsernameIndex called for users")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in EnsureUsernameIndex")
+		return errors.New("failed to get users getCollection")
+	}
+
+	if err := ensureUsernameIndex(ctx, coll); err != nil {
 		return err
 	}
 
@@ -68,6 +101,18 @@ func (m *mongoUserRepository) ensureIndexes(ctx context.Context) error {
 	return nil
 }
 
+// emailIndexName is matched against duplicate key errors to tell email and username conflicts apart.
+const emailIndexName = "email_unique"
+
+func userConflictError(err error, user models.User) error {
+	if strings.Contains(err.Error(), emailIndexName) {
+		log.Printf("ERROR: Email already exists for user: %s", user.Username)
+		return &models.ConflictError{Field: "email", Value: user.Email}
+	}
+	log.Printf("ERROR: Username already exists for user: %s", user.Username)
+	return &models.ConflictError{Field: "username", Value: user.Username}
+}
+
 func (m *mongoUserRepository) Create(ctx context.Context, user models.User) error {
 	log.Printf("Repository Create called for user ID: %s", user.ID)
 
@@ -82,12 +127,13 @@ func (m *mongoUserRepository) Create(ctx context.Context, user models.User) erro
 		log.Printf("ERROR: Failed to get users getCollection in Create")
 		return errors.New("failed to get users getCollection")
 	}
+	// Duplicate username and email errors are only reported for acknowledged writes.
+	coll = acknowledged(coll)
 
 	_, err := coll.InsertOne(ctx, user)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
-			log.Printf("ERROR: Username already exists for user: %s", user.Username)
-			return errors.New("username already exists")
+		if mongo.IsDuplicateKeyError(err) {
+			return userConflictError(err, user)
 		}
 		if strings.Contains(err.Error(), "unacknowledged write") {
 			log.Printf("Unacknowledged write for user ID %s - treating as success since data was written to database", user.ID)
@@ -141,7 +187,8 @@ func (m *mongoUserRepository) FindByUsername(ctx context.Context, username strin
 	}
 
 	var result models.User
-	err := coll.FindOne(ctx, bson.M{"username": username}).Decode(&result)
+	opts := options.FindOne().SetCollation(usernameCollation)
+	err := coll.FindOne(ctx, bson.M{"username": username}, opts).Decode(&result)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			log.Printf("User not found for username: %s", username)
@@ -155,6 +202,30 @@ func (m *mongoUserRepository) FindByUsername(ctx context.Context, username strin
 	return result, true, nil
 }
 
+func (m *mongoUserRepository) FindByEmail(ctx context.Context, email string) (models.User, bool, error) {
+	log.Printf("Repository FindByEmail called")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in FindByEmail")
+		return models.User{}, false, errors.New("failed to get users getCollection")
+	}
+
+	var result models.User
+	err := coll.FindOne(ctx, bson.M{"email": email}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("User not found for email")
+			return models.User{}, false, nil
+		}
+		log.Printf("ERROR: Failed to find user by email: %v", err)
+		return models.User{}, false, err
+	}
+
+	log.Printf("Successfully found user by email: %s", result.Username)
+	return result, true, nil
+}
+
 func (m *mongoUserRepository) UpdateByID(ctx context.Context, id string, user models.User) error {
 	log.Printf("Repository UpdateByID called for user ID: %s", id)
 
@@ -176,15 +247,15 @@ func (m *mongoUserRepository) UpdateByID(ctx context.Context, id string, user mo
 		log.Printf("ERROR: Failed to get users getCollection in UpdateByID")
 		return errors.New("failed to get users getCollection")
 	}
+	coll = acknowledged(coll)
 
 	// Ensure the user model has the correct ID
 	user.ID = objectID
 
 	result, err := coll.ReplaceOne(ctx, bson.M{"_id": objectID}, user)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") {
-			log.Printf("ERROR: Username already exists when updating user: %s", user.Username)
-			return errors.New("username already exists")
+		if mongo.IsDuplicateKeyError(err) {
+			return userConflictError(err, user)
 		}
 		if strings.Contains(err.Error(), "unacknowledged write") {
 			log.Printf("Unacknowledged write for user ID %s - treating as success since data was written to database", id)
@@ -199,6 +270,80 @@ func (m *mongoUserRepository) UpdateByID(ctx context.Context, id string, user mo
 	return nil
 }
 
+// UpdateFields writes only the named fields of user ("password", "role", "email",
+// "emailVerified" or "profile"), so concurrent changes to the other fields are kept. An empty
+// email or a nil profile is removed.
+func (m *mongoUserRepository) UpdateFields(ctx context.Context, id string, user models.User, fields ...string) error {
+	log.Printf("Repository UpdateFields called for user ID: %s, fields: %v", id, fields)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Printf("ERROR: Invalid ObjectID format for ID %s: %v", id, err)
+		return errors.New("invalid ID format")
+	}
+
+	set, unset := bson.M{}, bson.M{}
+	for _, field := range fields {
+		switch field {
+		case "password":
+			set["password"] = user.Password
+		case "role":
+			set["role"] = user.Role
+		case "emailVerified":
+			set["emailVerified"] = user.EmailVerified
+		case "email":
+			if user.Email == "" {
+				unset["email"] = ""
+			} else {
+				set["email"] = user.Email
+			}
+		case "profile":
+			if user.Profile == nil {
+				unset["profile"] = ""
+			} else {
+				set["profile"] = user.Profile
+			}
+		default:
+			log.Printf("ERROR: Field %s cannot be updated on user ID %s", field, id)
+			return errors.New("invalid field: " + field + " cannot be updated")
+		}
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	if len(update) == 0 {
+		return nil
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in UpdateFields")
+		return errors.New("failed to get users getCollection")
+	}
+	// Duplicate emails are only reported for acknowledged writes.
+	coll = acknowledged(coll)
+
+	result, err := coll.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return userConflictError(err, user)
+		}
+		log.Printf("ERROR: Failed to update fields of user ID %s: %v", id, err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		log.Printf("User not found with ID: %s", id)
+		return errors.New("user not found")
+	}
+
+	log.Printf("Successfully updated fields %v of user ID: %s, modified: %d", fields, id, result.ModifiedCount)
+	return nil
+}
+
 func (m *mongoUserRepository) DeleteByID(ctx context.Context, id string) error {
 	log.Printf("Repository DeleteByID called for user ID: %s", id)
 
@@ -229,3 +374,248 @@ func (m *mongoUserRepository) DeleteByID(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// UpdateSubscription stores the role and subscription only while the stored subscription is still
+// the expected one (nil for a user without a subscription), compared on its expiry and last
+// applied payment event. It reports false when another write changed the subscription in the
+// meantime.
+func (m *mongoUserRepository) UpdateSubscription(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+	log.Printf("Repository UpdateSubscription called for user ID: %s, role: %s, status: %s", id, role, subscription.Status)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Printf("ERROR: Invalid ObjectID format for ID %s: %v", id, err)
+		return false, errors.New("invalid ID format")
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in UpdateSubscription")
+		return false, errors.New("failed to get users getCollection")
+	}
+	// Whether the subscription matched is only known from an acknowledged write.
+	coll = acknowledged(coll)
+
+	filter := bson.M{"_id": objectID}
+	if expected == nil {
+		filter["subscription"] = nil
+	} else {
+		filter["subscription.expiresAt"] = expected.ExpiresAt
+		if expected.LastEventID == "" {
+			filter["subscription.lastEventId"] = bson.M{"$in": bson.A{nil, ""}}
+		} else {
+			filter["subscription.lastEventId"] = expected.LastEventID
+		}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"role":         role,
+			"subscription": subscription,
+		},
+	}
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("ERROR: Failed to update subscription for user ID %s: %v", id, err)
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		log.Printf("Subscription of user ID %s changed concurrently, not updated", id)
+		return false, nil
+	}
+
+	log.Printf("Successfully updated subscription for user ID: %s, modified: %d", id, result.ModifiedCount)
+	return true, nil
+}
+
+func (m *mongoUserRepository) UpdateProfile(ctx context.Context, id string, profile models.UserProfile) error {
+	log.Printf("Repository UpdateProfile called for user ID: %s", id)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		log.Printf("ERROR: Invalid ObjectID format for ID %s: %v", id, err)
+		return errors.New("invalid ID format")
+	}
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in UpdateProfile")
+		return errors.New("failed to get users getCollection")
+	}
+
+	result, err := coll.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"profile": profile}})
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for user ID %s - treating as success since data was written to database", id)
+		} else {
+			log.Printf("ERROR: Failed to update profile for user ID %s: %v", id, err)
+			return err
+		}
+	} else {
+		log.Printf("Successfully updated profile for user ID: %s, matched: %d, modified: %d", id, result.MatchedCount, result.ModifiedCount)
+	}
+
+	return nil
+}
+
+func (m *mongoUserRepository) FindExpiredSubscriptions(ctx context.Context, now time.Time) ([]models.User, error) {
+	log.Printf("Repository FindExpiredSubscriptions called for reference time: %v", now)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in FindExpiredSubscriptions")
+		return nil, errors.New("failed to get users getCollection")
+	}
+
+	filter := bson.M{
+		"role":                   enums.Premium,
+		"subscription.expiresAt": bson.M{"$lte": now},
+	}
+	cursor, err := coll.Find(ctx, filter)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute expired subscriptions query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		log.Printf("ERROR: Failed to decode users from cursor: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Found %d users with expired subscriptions", len(users))
+	return users, nil
+}
+
+func (m *mongoUserRepository) FindDuplicateUsernames(ctx context.Context) ([]models.DuplicateUsername, error) {
+	log.Printf("Repository FindDuplicateUsernames called for users")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in FindDuplicateUsernames")
+		return nil, errors.New("failed to get users getCollection")
+	}
+
+	duplicates, err := findDuplicateUsernames(ctx, coll)
+	if err != nil {
+		log.Printf("ERROR: Failed to look up duplicate usernames: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Found %d duplicate username groups in users", len(duplicates))
+	return duplicates, nil
+}
+
+// userFilterQuery translates a listing filter into a MongoDB query. Creation dates are matched
+// through the ObjectID timestamp, which every user document has.
+func userFilterQuery(filter models.UserFilter, includeRole bool) bson.M {
+	query := bson.M{}
+	if includeRole && filter.Role != "" {
+		query["role"] = filter.Role
+	}
+	if filter.UsernamePrefix != "" {
+		query["username"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.UsernamePrefix), Options: "i"}
+	}
+	idRange := bson.M{}
+	if !filter.CreatedFrom.IsZero() {
+		idRange["$gte"] = primitive.NewObjectIDFromTimestamp(filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		idRange["$lt"] = primitive.NewObjectIDFromTimestamp(filter.CreatedTo)
+	}
+	if len(idRange) > 0 {
+		query["_id"] = idRange
+	}
+	return query
+}
+
+func (m *mongoUserRepository) FindUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error) {
+	log.Printf("Repository FindUsers called with filter: %+v", filter)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in FindUsers")
+		return nil, 0, errors.New("failed to get users getCollection")
+	}
+
+	query := userFilterQuery(filter, true)
+	total, err := coll.CountDocuments(ctx, query)
+	if err != nil {
+		log.Printf("ERROR: Failed to count users: %v", err)
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.PageSize)).
+		SetLimit(int64(filter.PageSize))
+	cursor, err := coll.Find(ctx, query, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute users listing query: %v", err)
+		return nil, 0, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		log.Printf("ERROR: Failed to decode users from cursor: %v", err)
+		return nil, 0, err
+	}
+
+	log.Printf("Successfully retrieved %d of %d users", len(users), total)
+	return users, total, nil
+}
+
+// CountUsersByRole counts users per role, applying every filter except the role itself.
+func (m *mongoUserRepository) CountUsersByRole(ctx context.Context, filter models.UserFilter) (map[enums.RoleEnum]int64, error) {
+	log.Printf("Repository CountUsersByRole called with filter: %+v", filter)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get users getCollection in CountUsersByRole")
+		return nil, errors.New("failed to get users getCollection")
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: userFilterQuery(filter, false)}},
+		{{Key: "$group", Value: bson.M{"_id": "$role", "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Printf("ERROR: Failed to aggregate users by role: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var groups []struct {
+		Role  enums.RoleEnum `bson:"_id"`
+		Count int64          `bson:"count"`
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		log.Printf("ERROR: Failed to decode role counts: %v", err)
+		return nil, err
+	}
+
+	counts := make(map[enums.RoleEnum]int64, len(enums.GetAllRoles()))
+	for _, role := range enums.GetAllRoles() {
+		counts[role] = 0
+	}
+	for _, group := range groups {
+		counts[group.Role] = group.Count
+	}
+
+	log.Printf("Successfully counted users by role: %v", counts)
+	return counts, nil
+}
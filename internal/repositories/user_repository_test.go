@@ -2,9 +2,12 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"jboard-go-crud/internal/models"
 	"jboard-go-crud/internal/models/enums"
+	"strings"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -499,3 +502,172 @@ func TestUserRepository_Database_NilHandling(t *testing.T) {
 		t.Error("Expected error due to nil MongoDB client, got nil")
 	}
 }
+
+func TestUserRepository_UpdateSubscription_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	testID := primitive.NewObjectID()
+	subscription := models.Subscription{Plan: enums.Premium, Status: enums.Active}
+
+	_, err := repo.UpdateSubscription(context.Background(), testID.Hex(), nil, enums.Premium, subscription)
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+
+	if err.Error() != "failed to get users getCollection" {
+		t.Errorf("Expected 'failed to get users getCollection' error, got %v", err)
+	}
+}
+
+func TestUserRepository_UpdateSubscription_InvalidID(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	_, err := repo.UpdateSubscription(context.Background(), "invalid-id", nil, enums.Free, models.Subscription{})
+
+	if err == nil {
+		t.Error("Expected error for invalid ID format, got nil")
+	}
+
+	if err.Error() != "invalid ID format" {
+		t.Errorf("Expected 'invalid ID format' error, got %v", err)
+	}
+}
+
+func TestUserRepository_FindExpiredSubscriptions_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	users, err := repo.FindExpiredSubscriptions(context.Background(), time.Now())
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+
+	if users != nil {
+		t.Errorf("Expected nil users, got %v", users)
+	}
+}
+
+func TestUserRepository_FindDuplicateUsernames_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	duplicates, err := repo.FindDuplicateUsernames(context.Background())
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+
+	if duplicates != nil {
+		t.Errorf("Expected nil duplicates, got %v", duplicates)
+	}
+}
+
+func TestUserRepository_EnsureUsernameIndex_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	if err := repo.EnsureUsernameIndex(context.Background()); err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+}
+
+func TestUserRepository_FindUsers_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	users, total, err := repo.FindUsers(context.Background(), models.UserFilter{Page: 1, PageSize: 20})
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+	if users != nil || total != 0 {
+		t.Errorf("Expected no results, got %v (%d)", users, total)
+	}
+}
+
+func TestUserRepository_CountUsersByRole_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	_, err := repo.CountUsersByRole(context.Background(), models.UserFilter{})
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+}
+
+func TestUserRepository_UpdateProfile(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	if err := repo.UpdateProfile(context.Background(), "invalid-id", models.UserProfile{}); err == nil || err.Error() != "invalid ID format" {
+		t.Errorf("Expected 'invalid ID format', got %v", err)
+	}
+	if err := repo.UpdateProfile(context.Background(), primitive.NewObjectID().Hex(), models.UserProfile{}); err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+}
+
+func TestUserRepository_UpdateFields(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+	id := primitive.NewObjectID().Hex()
+
+	if err := repo.UpdateFields(context.Background(), "invalid-id", models.User{}, "password"); err == nil || err.Error() != "invalid ID format" {
+		t.Errorf("Expected 'invalid ID format', got %v", err)
+	}
+	if err := repo.UpdateFields(context.Background(), id, models.User{}, "username"); err == nil || !strings.Contains(err.Error(), "cannot be updated") {
+		t.Errorf("Expected unknown fields to be rejected, got %v", err)
+	}
+	if err := repo.UpdateFields(context.Background(), id, models.User{}); err != nil {
+		t.Errorf("Expected no fields to be a no-op, got %v", err)
+	}
+	if err := repo.UpdateFields(context.Background(), id, models.User{Password: "newpassword1"}, "password"); err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+}
+
+func TestUserRepository_FindByEmail_NilClient(t *testing.T) {
+	repo := NewUserRepository(nil, "testdb", "users")
+
+	_, found, err := repo.FindByEmail(context.Background(), "user@example.com")
+
+	if err == nil {
+		t.Error("Expected error due to nil MongoDB client, got nil")
+	}
+	if found {
+		t.Error("Expected user not to be found")
+	}
+}
+
+func TestUserConflictError(t *testing.T) {
+	user := models.User{Username: "testuser", Email: "user@example.com"}
+
+	var conflict *models.ConflictError
+	err := userConflictError(errors.New("E11000 duplicate key error collection: jboard.users index: email_unique dup key"), user)
+	if !errors.As(err, &conflict) || conflict.Field != "email" {
+		t.Errorf("Expected email conflict, got %v", err)
+	}
+
+	err = userConflictError(errors.New("E11000 duplicate key error collection: jboard.users index: username_unique_ci dup key"), user)
+	if !errors.As(err, &conflict) || conflict.Field != "username" {
+		t.Errorf("Expected username conflict, got %v", err)
+	}
+}
+
+func TestUserFilterQuery(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.UserFilter{Role: enums.Premium, UsernamePrefix: "a.b", CreatedFrom: from}
+
+	query := userFilterQuery(filter, true)
+
+	if query["role"] != enums.Premium {
+		t.Errorf("Expected role filter, got %v", query["role"])
+	}
+	regex, ok := query["username"].(primitive.Regex)
+	if !ok || regex.Pattern != `^a\.b` || regex.Options != "i" {
+		t.Errorf("Expected escaped case-insensitive prefix regex, got %v", query["username"])
+	}
+	if _, ok := query["_id"]; !ok {
+		t.Error("Expected creation date filter on _id")
+	}
+
+	if _, ok := userFilterQuery(filter, false)["role"]; ok {
+		t.Error("Expected role filter to be skipped")
+	}
+}
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/config"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserTokenRepository interface {
+	UserDataStore
+	Create(ctx context.Context, token models.UserToken) error
+	Consume(ctx context.Context, hash string, purpose enums.TokenPurposeEnum, now time.Time) (models.UserToken, bool, error)
+	DeleteByUsernameAndPurpose(ctx context.Context, username string, purpose enums.TokenPurposeEnum) error
+}
+
+type mongoUserTokenRepository struct {
+	database string
+}
+
+func NewUserTokenRepository(client *mongo.Client, dbName, collectionName string) UserTokenRepository {
+	log.Printf("Creating new UserTokenRepository with database: %s, getCollection: %s", dbName, collectionName)
+	repo := &mongoUserTokenRepository{
+		database: dbName,
+	}
+	if client != nil {
+		log.Printf("MongoDB client is available, ensuring indexes...")
+		_ = repo.ensureIndexes(context.Background())
+	} else {
+		log.Printf("WARNING: MongoDB client is nil")
+	}
+	return repo
+}
+
+func (m *mongoUserTokenRepository) getCollection() *mongo.Collection {
+	return config.GetUserTokensCollection(m.database)
+}
+
+func (m *mongoUserTokenRepository) ensureIndexes(ctx context.Context) error {
+	log.Printf("Ensuring indexes on user tokens username and expiresAt fields...")
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get user tokens getCollection when ensuring indexes")
+		return errors.New("failed to get user tokens getCollection")
+	}
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys:    bson.D{{Key: "username", Value: 1}, {Key: "purpose", Value: 1}},
+			Options: options.Index().SetCollation(usernameCollation),
+		},
+	}
+	if _, err := coll.Indexes().CreateMany(ctx, indexModels); err != nil {
+		log.Printf("ERROR: Failed to create user tokens indexes: %v", err)
+		return err
+	}
+
+	log.Printf("User tokens indexes created successfully")
+	return nil
+}
+
+func (m *mongoUserTokenRepository) Create(ctx context.Context, token models.UserToken) error {
+	log.Printf("Repository Create called for %s token of username: %s", token.Purpose, token.Username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get user tokens getCollection in Create")
+		return errors.New("failed to get user tokens getCollection")
+	}
+
+	if _, err := coll.InsertOne(ctx, token); err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for %s token of %s - treating as success since data was written to database", token.Purpose, token.Username)
+			return nil
+		}
+		log.Printf("ERROR: Failed to insert %s token for %s: %v", token.Purpose, token.Username, err)
+		return err
+	}
+
+	return nil
+}
+
+// Consume atomically deletes and returns an unexpired token, so each token works only once
+// even under concurrent requests.
+func (m *mongoUserTokenRepository) Consume(ctx context.Context, hash string, purpose enums.TokenPurposeEnum, now time.Time) (models.UserToken, bool, error) {
+	log.Printf("Repository Consume called for %s token", purpose)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get user tokens getCollection in Consume")
+		return models.UserToken{}, false, errors.New("failed to get user tokens getCollection")
+	}
+	// The deleted token is read back, which an unacknowledged write cannot do.
+	coll = acknowledged(coll)
+
+	filter := bson.M{
+		"_id":       hash,
+		"purpose":   purpose,
+		"expiresAt": bson.M{"$gt": now},
+	}
+	var token models.UserToken
+	if err := coll.FindOneAndDelete(ctx, filter).Decode(&token); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.UserToken{}, false, nil
+		}
+		log.Printf("ERROR: Failed to consume %s token: %v", purpose, err)
+		return models.UserToken{}, false, err
+	}
+
+	log.Printf("Consumed %s token for username: %s", purpose, token.Username)
+	return token, true, nil
+}
+
+func (m *mongoUserTokenRepository) DeleteByUsernameAndPurpose(ctx context.Context, username string, purpose enums.TokenPurposeEnum) error {
+	log.Printf("Repository DeleteByUsernameAndPurpose called for username: %s, purpose: %s", username, purpose)
+	return m.deleteMany(ctx, bson.M{"username": username, "purpose": purpose})
+}
+
+func (m *mongoUserTokenRepository) Name() string {
+	return "user_tokens"
+}
+
+// ExportByUsername lists pending tokens; the hashes are never serialized.
+func (m *mongoUserTokenRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	log.Printf("Repository ExportByUsername called for user tokens of username: %s", username)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get user tokens getCollection in ExportByUsername")
+		return nil, errors.New("failed to get user tokens getCollection")
+	}
+
+	opts := options.Find().SetCollation(usernameCollation)
+	cursor, err := coll.Find(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		log.Printf("ERROR: Failed to execute user tokens query: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	tokens := []models.UserToken{}
+	if err = cursor.All(ctx, &tokens); err != nil {
+		log.Printf("ERROR: Failed to decode user tokens: %v", err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (m *mongoUserTokenRepository) DeleteByUsername(ctx context.Context, username string) error {
+	log.Printf("Repository DeleteByUsername called for user tokens of username: %s", username)
+	return m.deleteMany(ctx, bson.M{"username": username})
+}
+
+func (m *mongoUserTokenRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	log.Printf("Repository RenameUsername called for user tokens, from: %s, to: %s", oldUsername, newUsername)
+
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get user tokens getCollection in RenameUsername")
+		return errors.New("failed to get user tokens getCollection")
+	}
+
+	update := bson.M{"$set": bson.M{"username": newUsername}}
+	opts := options.Update().SetCollation(usernameCollation)
+	result, err := coll.UpdateMany(ctx, bson.M{"username": oldUsername}, update, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for renaming user tokens of %s - treating as success since data was written to database", oldUsername)
+			return nil
+		}
+		log.Printf("ERROR: Failed to rename user tokens username %s: %v", oldUsername, err)
+		return err
+	}
+
+	log.Printf("Successfully renamed user tokens username %s to %s, modified: %d", oldUsername, newUsername, result.ModifiedCount)
+	return nil
+}
+
+func (m *mongoUserTokenRepository) deleteMany(ctx context.Context, filter bson.M) error {
+	coll := m.getCollection()
+	if coll == nil {
+		log.Printf("ERROR: Failed to get user tokens getCollection in deleteMany")
+		return errors.New("failed to get user tokens getCollection")
+	}
+
+	opts := options.Delete().SetCollation(usernameCollation)
+	result, err := coll.DeleteMany(ctx, filter, opts)
+	if err != nil {
+		if strings.Contains(err.Error(), "unacknowledged write") {
+			log.Printf("Unacknowledged write for deleting user tokens - treating as success since data was written to database")
+			return nil
+		}
+		log.Printf("ERROR: Failed to delete user tokens: %v", err)
+		return err
+	}
+
+	log.Printf("Successfully deleted %d user tokens", result.DeletedCount)
+	return nil
+}
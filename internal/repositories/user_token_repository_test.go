@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserTokenRepository_Name(t *testing.T) {
+	repo := NewUserTokenRepository(nil, "test", "user_tokens")
+
+	assert.Equal(t, "user_tokens", repo.Name())
+}
+
+func TestUserTokenRepository_NilClient(t *testing.T) {
+	repo := NewUserTokenRepository(nil, "test", "user_tokens")
+	ctx := context.Background()
+
+	assert.Error(t, repo.Create(ctx, models.UserToken{Hash: "h", Username: "testuser", Purpose: enums.PasswordReset}))
+
+	_, found, err := repo.Consume(ctx, "h", enums.PasswordReset, time.Now())
+	assert.Error(t, err)
+	assert.False(t, found)
+
+	assert.Error(t, repo.DeleteByUsernameAndPurpose(ctx, "testuser", enums.PasswordReset))
+	assert.Error(t, repo.DeleteByUsername(ctx, "testuser"))
+	assert.Error(t, repo.RenameUsername(ctx, "old", "new"))
+
+	_, err = repo.ExportByUsername(ctx, "testuser")
+	assert.Error(t, err)
+}
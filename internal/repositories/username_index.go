@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	usernameIndexName       = "username_unique_ci"
+	legacyUsernameIndexName = "username_1"
+)
+
+// usernameCollation makes username comparisons case-insensitive. Queries on username must pass
+// it too, otherwise MongoDB can neither use the index nor match the unique constraint.
+var usernameCollation = &options.Collation{Locale: "en", Strength: 2}
+
+func ensureUsernameIndex(ctx context.Context, coll *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().
+			SetName(usernameIndexName).
+			SetUnique(true).
+			SetCollation(usernameCollation),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		log.Printf("ERROR: Failed to create unique username index on %s (existing duplicates must be resolved first): %v", coll.Name(), err)
+		return err
+	}
+
+	// The unique index supersedes the old non-unique one, which would otherwise linger.
+	if _, err := coll.Indexes().DropOne(ctx, legacyUsernameIndexName); err == nil {
+		log.Printf("Dropped legacy username index on %s", coll.Name())
+	}
+
+	return nil
+}
+
+// findDuplicateUsernames groups documents by lower-cased username and returns the groups
+// that would violate the unique username index.
+func findDuplicateUsernames(ctx context.Context, coll *mongo.Collection) ([]models.DuplicateUsername, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$toLower": "$username"},
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			log.Printf("WARNING: Error closing cursor: %v", closeErr)
+		}
+	}()
+
+	var duplicates []models.DuplicateUsername
+	if err := cursor.All(ctx, &duplicates); err != nil {
+		return nil, err
+	}
+	return duplicates, nil
+}
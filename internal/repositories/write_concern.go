@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"log"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// acknowledged returns coll with a majority write concern. The connection string uses w=0,
+// under which find-and-modify commands fail with ErrUnacknowledgedWrite and duplicate key
+// errors are never reported, so writes whose outcome the caller relies on must go through it.
+func acknowledged(coll *mongo.Collection) *mongo.Collection {
+	if coll == nil {
+		return nil
+	}
+	clone, err := coll.Clone(options.Collection().SetWriteConcern(writeconcern.Majority()))
+	if err != nil {
+		log.Printf("ERROR: Failed to apply acknowledged write concern to %s: %v", coll.Name(), err)
+		return coll
+	}
+	return clone
+}
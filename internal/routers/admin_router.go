@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"jboard-go-crud/internal/controllers"
+	"net/http"
+)
+
+// NewAdminController serves back-office routes. Access to /v1/admin is restricted at the API gateway.
+func NewAdminController(userHandler *controllers.UserHandler, authHandler *controllers.AuthHandler, skillHandler *controllers.SkillHandler, jobHandler *controllers.JobHandler, insightsHandler *controllers.InsightsHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/admin/users", userHandler.ListUsers)
+	mux.HandleFunc("GET /v1/admin/users/logins", authHandler.GetUserLoginHistory)
+	mux.HandleFunc("PATCH /v1/admin/users/{id}", userHandler.AdminPatchUser)
+	mux.HandleFunc("DELETE /v1/admin/users/{username}", userHandler.AdminDeleteUser)
+	mux.HandleFunc("PUT /v1/admin/skills/catalog", skillHandler.UpsertCatalogSkill)
+	mux.HandleFunc("POST /v1/admin/skills/merge", skillHandler.MergeSkills)
+	mux.HandleFunc("POST /v1/admin/jobs/retag", jobHandler.RetagJobs)
+	mux.HandleFunc("GET /v1/admin/insights/trends", insightsHandler.AdminGetTrends)
+	mux.HandleFunc("POST /v1/admin/insights/snapshots", insightsHandler.RecordSnapshot)
+	return mux
+}
@@ -0,0 +1,120 @@
+package routers
+
+import (
+	"jboard-go-crud/internal/controllers"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewAdminController_ListUsersRoute(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users?role=FREE", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewAdminController_InvalidRoute(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/unknown", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestNewAdminController_LoginHistoryRoute(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users/logins?username=testuser", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewAdminController_PatchUserRoute(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/admin/users/68e462f868efefe99e226a8b", strings.NewReader(`{"role":"PREMIUM"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewAdminController_MergeSkillsRoute(t *testing.T) {
+	mockService := new(MockSkillService)
+	request := models.SkillMergeRequest{Sources: []string{"go-lang"}, Target: "Go"}
+	mockService.On("MergeSkills", mock.Anything, request).Return(models.SkillMergeResult{UsersUpdated: 2}, nil)
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(mockService), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/skills/merge", strings.NewReader(`{"sources":["go-lang"],"target":"Go"}`))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestNewAdminController_RetagJobsRoute(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/jobs/retag", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"scanned":2`)
+}
+
+func TestNewAdminController_InsightsRoutes(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/insights/trends?metric=jobs", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/admin/insights/snapshots", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+}
+
+func TestNewAdminController_DeleteUserRoute(t *testing.T) {
+	handler := NewAdminController(controllers.NewUserHandler(&mockUserService{}), controllers.NewAuthHandler(&mockAuthService{}), controllers.NewSkillHandler(new(MockSkillService)), controllers.NewJobHandler(&mockJobService{}), controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/admin/users/testuser", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d, got %d", http.StatusNoContent, rr.Code)
+	}
+}
@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"jboard-go-crud/internal/controllers"
+	"net/http"
+)
+
+func NewAuthController(authHandler *controllers.AuthHandler, accountHandler *controllers.AccountHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/login", authHandler.Login)
+	mux.HandleFunc("GET /v1/auth/logins", authHandler.GetMyLoginHistory)
+	mux.HandleFunc("PUT /v1/auth/email", accountHandler.SetMyEmail)
+	mux.HandleFunc("POST /v1/auth/email/verification", accountHandler.ResendVerification)
+	mux.HandleFunc("POST /v1/auth/email/verify", accountHandler.VerifyEmail)
+	mux.HandleFunc("POST /v1/auth/password/forgot", accountHandler.ForgotPassword)
+	mux.HandleFunc("POST /v1/auth/password/reset", accountHandler.ResetPassword)
+	return mux
+}
@@ -0,0 +1,99 @@
+package routers
+
+import (
+	"bytes"
+	"context"
+	"jboard-go-crud/internal/controllers"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mockAuthService struct{}
+
+func (m *mockAuthService) Login(_ context.Context, req models.LoginRequest) (models.UserSummary, error) {
+	return models.UserSummary{Username: req.Username}, nil
+}
+
+func (m *mockAuthService) GetLoginHistory(_ context.Context, username string, _ int) ([]models.LoginAuditEntry, error) {
+	return []models.LoginAuditEntry{{Username: username, Success: true}}, nil
+}
+
+type mockAccountService struct{}
+
+func (m *mockAccountService) SetEmail(_ context.Context, username, email string) (models.User, error) {
+	return models.User{Username: username, Email: email}, nil
+}
+
+func (m *mockAccountService) RequestEmailVerification(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockAccountService) VerifyEmail(_ context.Context, _ string) (models.User, error) {
+	return models.User{EmailVerified: true}, nil
+}
+
+func (m *mockAccountService) RequestPasswordReset(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockAccountService) ResetPassword(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func TestNewAuthController_LoginRoute(t *testing.T) {
+	handler := NewAuthController(controllers.NewAuthHandler(&mockAuthService{}), controllers.NewAccountHandler(&mockAccountService{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewBufferString(`{"username":"testuser","password":"password123"}`))
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewAuthController_LoginHistoryRoute(t *testing.T) {
+	handler := NewAuthController(controllers.NewAuthHandler(&mockAuthService{}), controllers.NewAccountHandler(&mockAccountService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/logins", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewAuthController_AccountRoutes(t *testing.T) {
+	handler := NewAuthController(controllers.NewAuthHandler(&mockAuthService{}), controllers.NewAccountHandler(&mockAccountService{}))
+
+	tests := []struct {
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{http.MethodPut, "/v1/auth/email", `{"email":"user@example.com"}`, http.StatusOK},
+		{http.MethodPost, "/v1/auth/email/verification", "", http.StatusAccepted},
+		{http.MethodPost, "/v1/auth/email/verify", `{"token":"abc"}`, http.StatusOK},
+		{http.MethodPost, "/v1/auth/password/forgot", `{"email":"user@example.com"}`, http.StatusAccepted},
+		{http.MethodPost, "/v1/auth/password/reset", `{"token":"abc","password":"password123"}`, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != tt.expected {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.expected, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"jboard-go-crud/internal/controllers"
+	"net/http"
+)
+
+func NewInsightsController(insightsHandler *controllers.InsightsHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/insights/trends", insightsHandler.GetTrends)
+	mux.HandleFunc("GET /v1/insights/salaries", insightsHandler.GetSalaryInsights)
+
+	return mux
+}
@@ -0,0 +1,70 @@
+package routers
+
+import (
+	"context"
+	"jboard-go-crud/internal/controllers"
+	"jboard-go-crud/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInsightsService struct{}
+
+func (m *mockInsightsService) RecordSnapshot(_ context.Context) (models.MarketSnapshot, error) {
+	return models.MarketSnapshot{Date: "2026-06-01"}, nil
+}
+
+func (m *mockInsightsService) EnsureDailySnapshot(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+func (m *mockInsightsService) Trends(_ context.Context, query models.TrendQuery) (models.TrendReport, error) {
+	return models.TrendReport{Metric: query.Metric, Series: []models.TrendSeries{}}, nil
+}
+
+func (m *mockInsightsService) TrendsForUser(ctx context.Context, _ string, query models.TrendQuery) (models.TrendReport, error) {
+	return m.Trends(ctx, query)
+}
+
+func (m *mockInsightsService) SalaryInsights(_ context.Context, _ string, _ models.JobFilter, currency string) (models.SalaryInsights, error) {
+	return models.SalaryInsights{Currency: currency}, nil
+}
+
+func TestNewInsightsController_TrendsRoute(t *testing.T) {
+	handler := NewInsightsController(controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/insights/trends?metric=skill", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"metric":"skill"`)
+}
+
+func TestNewInsightsController_InvalidRoute(t *testing.T) {
+	handler := NewInsightsController(controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/insights/trends", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestNewInsightsController_SalariesRoute(t *testing.T) {
+	handler := NewInsightsController(controllers.NewInsightsHandler(&mockInsightsService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/insights/salaries?currency=BRL", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"currency":"BRL"`)
+}
@@ -10,6 +10,9 @@ func NewJobsController(jobHandler *controllers.JobHandler) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /v1/jobs", jobHandler.CreateJob)
 	mux.HandleFunc("GET /v1/jobs", jobHandler.GetAllJobs)
+	mux.HandleFunc("GET /v1/jobs/stats", jobHandler.GetJobStats)
+	mux.HandleFunc("GET /v1/jobs/feed.rss", jobHandler.GetJobsRSS)
+	mux.HandleFunc("GET /v1/jobs/feed.atom", jobHandler.GetJobsAtom)
 	mux.HandleFunc("GET /v1/health", healthCheck)
 	return mux
 }
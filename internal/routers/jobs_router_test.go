@@ -23,6 +23,32 @@ func (m *mockJobService) FindAll(_ context.Context) ([]models.Job, error) {
 	}, nil
 }
 
+func (m *mockJobService) FindJobs(ctx context.Context, _ string, _ models.JobFilter, _ bool) ([]models.Job, error) {
+	return m.FindAll(ctx)
+}
+
+func (m *mockJobService) JobStats(_ context.Context, _ string, _ models.JobFilter, _ bool) (models.JobStats, error) {
+	return models.JobStats{Total: 2}, nil
+}
+
+func (m *mockJobService) RecentJobs(ctx context.Context, _ models.JobFilter, _ int) ([]models.Job, error) {
+	return m.FindAll(ctx)
+}
+
+func (m *mockJobService) StreamJobs(ctx context.Context, _ string, _ models.JobFilter, _ bool, fn func(models.Job) error) error {
+	jobs, _ := m.FindAll(ctx)
+	for _, job := range jobs {
+		if err := fn(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockJobService) RetagJobs(_ context.Context) (models.JobRetagResult, error) {
+	return models.JobRetagResult{Scanned: 2}, nil
+}
+
 func TestNewJobsController(t *testing.T) {
 	mockService := &mockJobService{}
 	jobHandler := controllers.NewJobHandler(mockService)
@@ -160,3 +186,34 @@ func TestGetAllJobsRoute(t *testing.T) {
 		t.Errorf("Expected Content-Type application/json, got %s", contentType)
 	}
 }
+
+func TestNewJobsController_StatsRoute(t *testing.T) {
+	handler := NewJobsController(controllers.NewJobHandler(&mockJobService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs/stats?field=Engineering", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewJobsController_FeedRoutes(t *testing.T) {
+	handler := NewJobsController(controllers.NewJobHandler(&mockJobService{}))
+
+	for path, contentType := range map[string]string{
+		"/v1/jobs/feed.rss?skills=Go":  "application/rss+xml; charset=utf-8",
+		"/v1/jobs/feed.atom?skills=Go": "application/atom+xml; charset=utf-8",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != contentType {
+			t.Errorf("GET %s: expected %d with %s, got %d with %s", path, http.StatusOK, contentType, rr.Code, rr.Header().Get("Content-Type"))
+		}
+	}
+}
@@ -11,6 +11,10 @@ func NewSkillsController(skillHandler *controllers.SkillHandler) http.Handler {
 	mux.HandleFunc("POST /v1/skills", skillHandler.AddSkill)
 	mux.HandleFunc("PUT /v1/skills", skillHandler.RemoveSkill)
 	mux.HandleFunc("DELETE /v1/skills", skillHandler.DeleteUserSkills)
+	mux.HandleFunc("GET /v1/skills/catalog", skillHandler.GetCatalog)
+	mux.HandleFunc("GET /v1/skills/suggest", skillHandler.SuggestSkills)
+	mux.HandleFunc("PUT /v1/skills/{username}", skillHandler.ReplaceUserSkills)
+	mux.HandleFunc("POST /v1/skills/{username}/batch", skillHandler.AddUserSkillsBatch)
 
 	return mux
 }
@@ -38,11 +38,41 @@ func (m *MockSkillService) DeleteUserSkills(ctx context.Context, username string
 	return args.Error(0)
 }
 
+func (m *MockSkillService) GetCatalog(ctx context.Context, category string) ([]models.CanonicalSkill, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).([]models.CanonicalSkill), args.Error(1)
+}
+
+func (m *MockSkillService) UpsertCatalogSkill(ctx context.Context, skill models.CanonicalSkill) (models.CanonicalSkill, error) {
+	args := m.Called(ctx, skill)
+	return args.Get(0).(models.CanonicalSkill), args.Error(1)
+}
+
+func (m *MockSkillService) MergeSkills(ctx context.Context, request models.SkillMergeRequest) (models.SkillMergeResult, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(models.SkillMergeResult), args.Error(1)
+}
+
+func (m *MockSkillService) SuggestSkills(ctx context.Context, prefix string, limit int) ([]models.SkillSuggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]models.SkillSuggestion), args.Error(1)
+}
+
+func (m *MockSkillService) ReplaceUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error) {
+	args := m.Called(ctx, username, skills)
+	return args.Get(0).(models.SkillSetChanges), args.Error(1)
+}
+
+func (m *MockSkillService) AddUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error) {
+	args := m.Called(ctx, username, skills)
+	return args.Get(0).(models.SkillSetChanges), args.Error(1)
+}
+
 func TestSkillRouter_GetAllSkills(t *testing.T) {
 	mockService := new(MockSkillService)
 	expectedSkill := models.Skill{
 		Username: "testuser",
-		Skills:   []string{"java", "python"},
+		Skills:   []models.SkillEntry{{Name: "java"}, {Name: "python"}},
 	}
 	mockService.On("GetAllSkills", mock.Anything, "testuser").Return(expectedSkill, nil)
 
@@ -118,3 +148,60 @@ func TestSkillRouter_DeleteUserSkills(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestSkillRouter_GetCatalog(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := controllers.NewSkillHandler(mockService)
+	router := NewSkillsController(handler)
+
+	mockService.On("GetCatalog", mock.Anything, "CLOUD").Return([]models.CanonicalSkill{{Key: "aws", Name: "AWS"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/skills/catalog?category=CLOUD", nil)
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillRouter_SuggestSkills(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := controllers.NewSkillHandler(mockService)
+	router := NewSkillsController(handler)
+
+	mockService.On("SuggestSkills", mock.Anything, "kub", 0).Return([]models.SkillSuggestion{{Name: "Kubernetes"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/v1/skills/suggest?prefix=kub", nil)
+	rr := httptest.NewRecorder()
+
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSkillRouter_SkillSetRoutes(t *testing.T) {
+	mockService := new(MockSkillService)
+	handler := controllers.NewSkillHandler(mockService)
+	router := NewSkillsController(handler)
+
+	skills := []models.SkillEntry{{Name: "java"}}
+	mockService.On("ReplaceUserSkills", mock.Anything, "testuser", skills).Return(models.SkillSetChanges{Skills: skills}, nil)
+	mockService.On("AddUserSkills", mock.Anything, "testuser", skills).Return(models.SkillSetChanges{Skills: skills}, nil)
+
+	body, _ := json.Marshal(models.SkillSetRequest{Skills: skills})
+	req, _ := http.NewRequest("PUT", "/v1/skills/testuser", bytes.NewBuffer(body))
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	req, _ = http.NewRequest("POST", "/v1/skills/testuser/batch", bytes.NewBuffer(body))
+	req.Header.Set("X-Username", "testuser")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"jboard-go-crud/internal/controllers"
+	"net/http"
+)
+
+func NewSubscriptionsController(subscriptionHandler *controllers.SubscriptionHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/subscriptions", subscriptionHandler.GetSubscription)
+	mux.HandleFunc("DELETE /v1/subscriptions", subscriptionHandler.CancelSubscription)
+	mux.HandleFunc("POST /v1/webhooks/payments", subscriptionHandler.PaymentWebhook)
+	return mux
+}
@@ -0,0 +1,66 @@
+package routers
+
+import (
+	"context"
+	"jboard-go-crud/internal/controllers"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type mockSubscriptionService struct{}
+
+func (m *mockSubscriptionService) GetSubscription(_ context.Context, _ string) (models.Subscription, error) {
+	return models.Subscription{Plan: enums.Premium, Status: enums.Active}, nil
+}
+
+func (m *mockSubscriptionService) CancelSubscription(_ context.Context, _ string) (models.Subscription, error) {
+	return models.Subscription{Plan: enums.Premium, Status: enums.Cancelled}, nil
+}
+
+func (m *mockSubscriptionService) HandlePaymentEvent(_ context.Context, _ models.PaymentEvent) error {
+	return nil
+}
+
+func (m *mockSubscriptionService) DowngradeExpired(_ context.Context) (int, error) {
+	return 0, nil
+}
+
+func TestNewSubscriptionsController_Routes(t *testing.T) {
+	handler := NewSubscriptionsController(controllers.NewSubscriptionHandler(&mockSubscriptionService{}, "secret"))
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/v1/subscriptions?username=testuser"},
+		{http.MethodDelete, "/v1/subscriptions?username=testuser"},
+		{http.MethodPost, "/v1/webhooks/payments"},
+	}
+
+	for _, route := range routes {
+		req := httptest.NewRequest(route.method, route.path, nil)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code == http.StatusNotFound || rr.Code == http.StatusMethodNotAllowed {
+			t.Errorf("%s %s route should be registered, got %d", route.method, route.path, rr.Code)
+		}
+	}
+}
+
+func TestNewSubscriptionsController_InvalidRoute(t *testing.T) {
+	handler := NewSubscriptionsController(controllers.NewSubscriptionHandler(&mockSubscriptionService{}, "secret"))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/webhooks/payments", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
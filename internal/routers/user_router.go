@@ -5,11 +5,33 @@ import (
 	"net/http"
 )
 
-func NewUsersController(userHandler *controllers.UserHandler) http.Handler {
+func NewUsersController(userHandler *controllers.UserHandler, matchHandler *controllers.MatchHandler, savedSearchHandler *controllers.SavedSearchHandler, bookmarkHandler *controllers.BookmarkHandler, applicationHandler *controllers.ApplicationHandler) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /v1/users", userHandler.CreateUser)
 	mux.HandleFunc("GET /v1/users", userHandler.GetUserHandler)
 	mux.HandleFunc("PUT /v1/users", userHandler.UpdateUser)
 	mux.HandleFunc("DELETE /v1/users", userHandler.DeleteUser)
+	mux.HandleFunc("POST /v1/users/rename", userHandler.RenameUser)
+	mux.HandleFunc("PATCH /v1/users/{id}", userHandler.PatchUser)
+	mux.HandleFunc("GET /v1/users/me/export", userHandler.ExportUserData)
+	mux.HandleFunc("GET /v1/users/me/profile", userHandler.GetMyProfile)
+	mux.HandleFunc("PATCH /v1/users/me/profile", userHandler.PatchMyProfile)
+	mux.HandleFunc("GET /v1/users/me/searches", savedSearchHandler.ListSearches)
+	mux.HandleFunc("POST /v1/users/me/searches", savedSearchHandler.CreateSearch)
+	mux.HandleFunc("GET /v1/users/me/searches/{id}", savedSearchHandler.GetSearch)
+	mux.HandleFunc("PUT /v1/users/me/searches/{id}", savedSearchHandler.UpdateSearch)
+	mux.HandleFunc("DELETE /v1/users/me/searches/{id}", savedSearchHandler.DeleteSearch)
+	mux.HandleFunc("GET /v1/users/me/searches/{id}/results", savedSearchHandler.RunSearch)
+	mux.HandleFunc("GET /v1/users/me/bookmarks", bookmarkHandler.ListBookmarks)
+	mux.HandleFunc("POST /v1/users/me/bookmarks/{jobId}", bookmarkHandler.AddBookmark)
+	mux.HandleFunc("DELETE /v1/users/me/bookmarks/{jobId}", bookmarkHandler.RemoveBookmark)
+	mux.HandleFunc("GET /v1/users/me/applications", applicationHandler.GetBoard)
+	mux.HandleFunc("POST /v1/users/me/applications", applicationHandler.CreateApplication)
+	mux.HandleFunc("GET /v1/users/me/applications/reminders", applicationHandler.GetDueReminders)
+	mux.HandleFunc("GET /v1/users/me/applications/{id}", applicationHandler.GetApplication)
+	mux.HandleFunc("PATCH /v1/users/me/applications/{id}", applicationHandler.UpdateApplication)
+	mux.HandleFunc("DELETE /v1/users/me/applications/{id}", applicationHandler.DeleteApplication)
+	mux.HandleFunc("GET /v1/users/{username}/matches", matchHandler.GetJobMatches)
+	mux.HandleFunc("GET /v1/users/{username}/skill-gaps", matchHandler.GetSkillGaps)
 	return mux
 }
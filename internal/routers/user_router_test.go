@@ -1,12 +1,14 @@
 package routers
 
 import (
+	"bytes"
 	"context"
 	"jboard-go-crud/internal/controllers"
 	"jboard-go-crud/internal/models"
 	"jboard-go-crud/internal/models/enums"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -37,11 +39,113 @@ func (m *mockUserService) DeleteUser(_ context.Context, _ string) error {
 	return nil
 }
 
+func (m *mockUserService) ExportUserData(_ context.Context, username string) (models.UserDataExport, error) {
+	return models.UserDataExport{User: models.User{Username: username}}, nil
+}
+
+func (m *mockUserService) RenameUser(_ context.Context, _, newUsername string) (models.User, error) {
+	testID, _ := primitive.ObjectIDFromHex("68e462f868efefe99e226a8b")
+	return models.User{ID: testID, Username: newUsername, Role: enums.Free}, nil
+}
+
+func (m *mockUserService) ListUsers(_ context.Context, filter models.UserFilter) (models.UserPage, error) {
+	return models.UserPage{Page: 1, PageSize: 20, RoleCounts: map[enums.RoleEnum]int64{enums.Free: 0, enums.Premium: 0}}, nil
+}
+
+func (m *mockUserService) PatchUser(_ context.Context, actor models.UserPatchActor, id string, _ map[string]any) (models.User, error) {
+	testID, _ := primitive.ObjectIDFromHex(id)
+	return models.User{ID: testID, Username: actor.Username, Role: enums.Free}, nil
+}
+
+func (m *mockUserService) GetProfile(_ context.Context, _ string) (models.UserProfile, error) {
+	return models.UserProfile{DisplayName: "Test User"}, nil
+}
+
+func (m *mockUserService) UpdateProfile(_ context.Context, _ string, patch models.UserProfilePatch) (models.UserProfile, error) {
+	return patch.Apply(models.UserProfile{}), nil
+}
+
+type mockMatchService struct{}
+
+func (m *mockMatchService) MatchJobs(_ context.Context, _ string, _ int) ([]models.JobMatch, error) {
+	return []models.JobMatch{{Job: models.Job{ID: "test-1"}, Score: 80, MatchedSkills: []string{"Go"}, MissingSkills: []string{}}}, nil
+}
+
+func (m *mockMatchService) SkillGaps(_ context.Context, _ string, _ int) (models.SkillGapReport, error) {
+	return models.SkillGapReport{Gaps: []models.SkillGap{{Name: "Kubernetes", Demand: 2}}}, nil
+}
+
+type mockSavedSearchService struct{}
+
+func (m *mockSavedSearchService) ListSearches(_ context.Context, username string) ([]models.SavedSearch, error) {
+	return []models.SavedSearch{{Username: username, Name: "Go remote"}}, nil
+}
+
+func (m *mockSavedSearchService) GetSearch(_ context.Context, username, _ string) (models.SavedSearch, error) {
+	return models.SavedSearch{Username: username, Name: "Go remote"}, nil
+}
+
+func (m *mockSavedSearchService) CreateSearch(_ context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+	return models.SavedSearch{Username: username, Name: request.Name, Filter: request.Filter}, nil
+}
+
+func (m *mockSavedSearchService) UpdateSearch(_ context.Context, username, _ string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+	return models.SavedSearch{Username: username, Name: request.Name, Filter: request.Filter}, nil
+}
+
+func (m *mockSavedSearchService) DeleteSearch(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockSavedSearchService) RunSearch(_ context.Context, username, _ string) (models.SavedSearchResults, error) {
+	return models.SavedSearchResults{Search: models.SavedSearch{Username: username}, Jobs: []models.SavedSearchJob{}}, nil
+}
+
+type mockBookmarkService struct{}
+
+func (m *mockBookmarkService) ListBookmarks(_ context.Context, username string) ([]models.Bookmark, error) {
+	return []models.Bookmark{{Username: username, JobID: "test-1"}}, nil
+}
+
+func (m *mockBookmarkService) AddBookmark(_ context.Context, username, jobID string) (models.Bookmark, bool, error) {
+	return models.Bookmark{Username: username, JobID: jobID}, true, nil
+}
+
+func (m *mockBookmarkService) RemoveBookmark(_ context.Context, _, _ string) error {
+	return nil
+}
+
+type mockApplicationService struct{}
+
+func (m *mockApplicationService) GetBoard(_ context.Context, _ string) (models.ApplicationBoard, error) {
+	return models.ApplicationBoard{Columns: []models.ApplicationColumn{}}, nil
+}
+
+func (m *mockApplicationService) GetApplication(_ context.Context, username, _ string) (models.Application, error) {
+	return models.Application{Username: username}, nil
+}
+
+func (m *mockApplicationService) CreateApplication(_ context.Context, username string, request models.ApplicationRequest) (models.Application, error) {
+	return models.Application{Username: username, JobID: request.JobID}, nil
+}
+
+func (m *mockApplicationService) UpdateApplication(_ context.Context, username, _ string, _ models.ApplicationPatch) (models.Application, error) {
+	return models.Application{Username: username}, nil
+}
+
+func (m *mockApplicationService) DeleteApplication(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockApplicationService) DueReminders(_ context.Context, username string) ([]models.Application, error) {
+	return []models.Application{{Username: username}}, nil
+}
+
 func TestNewUsersController(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	if handler == nil {
 		t.Error("Expected handler to be created, got nil")
@@ -52,7 +156,7 @@ func TestNewUsersController_PostRoute(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/users", nil)
 	rr := httptest.NewRecorder()
@@ -68,7 +172,7 @@ func TestNewUsersController_GetRoute(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
 	rr := httptest.NewRecorder()
@@ -84,7 +188,7 @@ func TestNewUsersController_PutRoute(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodPut, "/v1/users", nil)
 	rr := httptest.NewRecorder()
@@ -100,7 +204,7 @@ func TestNewUsersController_DeleteRoute(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/users", nil)
 	rr := httptest.NewRecorder()
@@ -116,9 +220,9 @@ func TestNewUsersController_InvalidRoute(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/users/invalid", nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/invalid/route", nil)
 	rr := httptest.NewRecorder()
 
 	handler.ServeHTTP(rr, req)
@@ -132,7 +236,7 @@ func TestNewUsersController_PatchMethodNotAllowed(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodPatch, "/v1/users", nil)
 	rr := httptest.NewRecorder()
@@ -148,9 +252,10 @@ func TestNewUsersController_HeadMethodNotAllowed(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodHead, "/v1/users?id=test-id", nil)
+	req.Header.Set("X-Username", "testuser")
 	rr := httptest.NewRecorder()
 
 	handler.ServeHTTP(rr, req)
@@ -164,7 +269,7 @@ func TestNewUsersController_OptionsMethodNotAllowed(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodOptions, "/v1/users", nil)
 	rr := httptest.NewRecorder()
@@ -177,7 +282,7 @@ func TestNewUsersController_OptionsMethodNotAllowed(t *testing.T) {
 }
 
 func TestNewUsersController_WithNilHandler(t *testing.T) {
-	handler := NewUsersController(nil)
+	handler := NewUsersController(nil, nil, nil, nil, nil)
 
 	if handler == nil {
 		t.Error("Expected handler to be created even with nil userHandler, got nil")
@@ -188,7 +293,7 @@ func TestNewUsersController_RoutePathSensitivity(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/V1/USERS", nil)
 	rr := httptest.NewRecorder()
@@ -204,7 +309,7 @@ func TestNewUsersController_RouteWithQueryParameters(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/users?id=123", nil)
 	rr := httptest.NewRecorder()
@@ -220,7 +325,7 @@ func TestNewUsersController_RouteWithTrailingSlash(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/users/", nil)
 	rr := httptest.NewRecorder()
@@ -236,7 +341,7 @@ func TestNewUsersController_EmptyPath(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	rr := httptest.NewRecorder()
@@ -252,7 +357,7 @@ func TestNewUsersController_WrongVersionPath(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/v2/users", nil)
 	rr := httptest.NewRecorder()
@@ -268,7 +373,7 @@ func TestNewUsersController_MultipleRequestsToSameRoute(t *testing.T) {
 	mockService := &mockUserService{}
 	userHandler := controllers.NewUserHandler(mockService)
 
-	handler := NewUsersController(userHandler)
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
 
 	for i := 0; i < 3; i++ {
 		req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
@@ -281,3 +386,158 @@ func TestNewUsersController_MultipleRequestsToSameRoute(t *testing.T) {
 		}
 	}
 }
+
+func TestNewUsersController_ExportRoute(t *testing.T) {
+	mockService := &mockUserService{}
+	userHandler := controllers.NewUserHandler(mockService)
+
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/me/export", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewUsersController_RenameRoute(t *testing.T) {
+	mockService := &mockUserService{}
+	userHandler := controllers.NewUserHandler(mockService)
+
+	handler := NewUsersController(userHandler, controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/rename", bytes.NewBufferString(`{"newUsername":"fixed"}`))
+	req.Header.Set("X-Username", "typo")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewUsersController_PatchUserRoute(t *testing.T) {
+	handler := NewUsersController(controllers.NewUserHandler(&mockUserService{}), controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/users/68e462f868efefe99e226a8b", strings.NewReader(`{"password":"password123"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewUsersController_MatchesRoute(t *testing.T) {
+	handler := NewUsersController(controllers.NewUserHandler(&mockUserService{}), controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/testuser/matches", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), `"score":80`) {
+		t.Errorf("Expected match in response, got %s", rr.Body.String())
+	}
+}
+
+func TestNewUsersController_SkillGapsRoute(t *testing.T) {
+	handler := NewUsersController(controllers.NewUserHandler(&mockUserService{}), controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/testuser/skill-gaps", nil)
+	req.Header.Set("X-Username", "testuser")
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestNewUsersController_SavedSearchRoutes(t *testing.T) {
+	handler := NewUsersController(controllers.NewUserHandler(&mockUserService{}), controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+	}{
+		{http.MethodGet, "/v1/users/me/searches", "", http.StatusOK},
+		{http.MethodPost, "/v1/users/me/searches", `{"name":"Go remote","filter":{"skills":["Go"]}}`, http.StatusCreated},
+		{http.MethodGet, "/v1/users/me/searches/68e462f868efefe99e226a8b", "", http.StatusOK},
+		{http.MethodPut, "/v1/users/me/searches/68e462f868efefe99e226a8b", `{"name":"Go","filter":{"skills":["Go"]}}`, http.StatusOK},
+		{http.MethodDelete, "/v1/users/me/searches/68e462f868efefe99e226a8b", "", http.StatusNoContent},
+		{http.MethodGet, "/v1/users/me/searches/68e462f868efefe99e226a8b/results", "", http.StatusOK},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != tc.status {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.path, tc.status, rr.Code)
+		}
+	}
+}
+
+func TestNewUsersController_BookmarkRoutes(t *testing.T) {
+	handler := NewUsersController(controllers.NewUserHandler(&mockUserService{}), controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+	}{
+		{http.MethodGet, "/v1/users/me/bookmarks", http.StatusOK},
+		{http.MethodPost, "/v1/users/me/bookmarks/test-1", http.StatusCreated},
+		{http.MethodDelete, "/v1/users/me/bookmarks/test-1", http.StatusNoContent},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != tc.status {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.path, tc.status, rr.Code)
+		}
+	}
+}
+
+func TestNewUsersController_ApplicationRoutes(t *testing.T) {
+	handler := NewUsersController(controllers.NewUserHandler(&mockUserService{}), controllers.NewMatchHandler(&mockMatchService{}), controllers.NewSavedSearchHandler(&mockSavedSearchService{}), controllers.NewBookmarkHandler(&mockBookmarkService{}), controllers.NewApplicationHandler(&mockApplicationService{}))
+
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+	}{
+		{http.MethodGet, "/v1/users/me/applications", "", http.StatusOK},
+		{http.MethodPost, "/v1/users/me/applications", `{"jobId":"test-1"}`, http.StatusCreated},
+		{http.MethodGet, "/v1/users/me/applications/reminders", "", http.StatusOK},
+		{http.MethodGet, "/v1/users/me/applications/68e462f868efefe99e226a8b", "", http.StatusOK},
+		{http.MethodPatch, "/v1/users/me/applications/68e462f868efefe99e226a8b", `{"status":"APPLIED"}`, http.StatusOK},
+		{http.MethodDelete, "/v1/users/me/applications/68e462f868efefe99e226a8b", "", http.StatusNoContent},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("X-Username", "testuser")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != tc.status {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.path, tc.status, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+type Task func(ctx context.Context) error
+
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	log.Printf("Creating new Scheduler")
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Every runs task once per interval until Stop is called. The first run happens after one interval.
+func (s *Scheduler) Every(name string, interval time.Duration, task Task) {
+	log.Printf("Scheduling task '%s' every %v", name, interval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				log.Printf("Task '%s' stopped", name)
+				return
+			case <-ticker.C:
+				log.Printf("Running scheduled task '%s'", name)
+				if err := task(s.ctx); err != nil {
+					log.Printf("ERROR: Scheduled task '%s' failed: %v", name, err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Stop() {
+	log.Printf("Stopping scheduler...")
+	s.cancel()
+	s.wg.Wait()
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Every_RunsTask(t *testing.T) {
+	s := NewScheduler()
+
+	var runs atomic.Int32
+	s.Every("counter", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	s.Stop()
+
+	if runs.Load() == 0 {
+		t.Error("Expected task to run at least once, got 0 runs")
+	}
+}
+
+func TestScheduler_Every_KeepsRunningAfterError(t *testing.T) {
+	s := NewScheduler()
+
+	var runs atomic.Int32
+	s.Every("failing", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("task failed")
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	s.Stop()
+
+	if runs.Load() < 2 {
+		t.Errorf("Expected task to keep running after errors, got %d runs", runs.Load())
+	}
+}
+
+func TestScheduler_Stop_StopsTasks(t *testing.T) {
+	s := NewScheduler()
+
+	var runs atomic.Int32
+	s.Every("stopped", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	s.Stop()
+
+	after := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+
+	if runs.Load() != after {
+		t.Errorf("Expected no runs after Stop, got %d more", runs.Load()-after)
+	}
+}
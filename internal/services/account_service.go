@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/mailer"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type AccountService interface {
+	SetEmail(ctx context.Context, username, email string) (models.User, error)
+	RequestEmailVerification(ctx context.Context, username string) error
+	VerifyEmail(ctx context.Context, token string) (models.User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type accountService struct {
+	userRepo       repositories.UserRepository
+	tokenRepo      repositories.UserTokenRepository
+	attemptRepo    repositories.LoginAttemptRepository
+	mailer         mailer.Mailer
+	passwordPolicy models.PasswordPolicy
+	tokenPolicy    models.TokenPolicy
+	now            func() time.Time
+}
+
+func NewAccountService(userRepo repositories.UserRepository, tokenRepo repositories.UserTokenRepository, attemptRepo repositories.LoginAttemptRepository, m mailer.Mailer, passwordPolicy models.PasswordPolicy, tokenPolicy models.TokenPolicy) AccountService {
+	log.Printf("Creating new AccountService")
+	return &accountService{
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		attemptRepo:    attemptRepo,
+		mailer:         m,
+		passwordPolicy: passwordPolicy,
+		tokenPolicy:    tokenPolicy,
+		now:            time.Now,
+	}
+}
+
+// SetEmail replaces the user's address, marks it unverified and mails a verification token.
+func (s *accountService) SetEmail(ctx context.Context, username, email string) (models.User, error) {
+	log.Printf("Service SetEmail called for username: %s", username)
+
+	if strings.TrimSpace(username) == "" {
+		return models.User{}, errors.New("username cannot be empty")
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user, err := s.findUser(ctx, username)
+	if err != nil {
+		return models.User{}, err
+	}
+	if user.Email == email && user.EmailVerified {
+		log.Printf("Email unchanged and already verified for username: %s", username)
+		user.Password = ""
+		return user, nil
+	}
+
+	owner, exists, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		log.Printf("Repository error checking existing email: %v", err)
+		return models.User{}, err
+	}
+	if exists && owner.ID != user.ID {
+		log.Printf("Email already in use, requested by username: %s", username)
+		return models.User{}, &models.ConflictError{Field: "email", Value: email}
+	}
+
+	user.Email = email
+	user.EmailVerified = false
+	if err := s.userRepo.UpdateFields(ctx, user.ID.Hex(), user, "email", "emailVerified"); err != nil {
+		log.Printf("Repository error in SetEmail: %v", err)
+		return models.User{}, err
+	}
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		return models.User{}, err
+	}
+
+	user.Password = ""
+	log.Printf("Successfully set email for username: %s", username)
+	return user, nil
+}
+
+func (s *accountService) RequestEmailVerification(ctx context.Context, username string) error {
+	log.Printf("Service RequestEmailVerification called for username: %s", username)
+
+	if strings.TrimSpace(username) == "" {
+		return errors.New("username cannot be empty")
+	}
+
+	user, err := s.findUser(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return errors.New("invalid request: no email address set")
+	}
+	if user.EmailVerified {
+		return errors.New("invalid request: email already verified")
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) (models.User, error) {
+	log.Printf("Service VerifyEmail called")
+
+	stored, err := s.consume(ctx, token, enums.EmailVerification)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user, found, err := s.userRepo.FindByUsername(ctx, stored.Username)
+	if err != nil {
+		log.Printf("Repository error in VerifyEmail: %v", err)
+		return models.User{}, err
+	}
+	// A token only proves ownership of the address it was mailed to.
+	if !found || user.Email != stored.Email {
+		log.Printf("Verification token no longer matches the account of username: %s", stored.Username)
+		return models.User{}, errors.New("invalid or expired token")
+	}
+
+	user.EmailVerified = true
+	if err := s.userRepo.UpdateFields(ctx, user.ID.Hex(), user, "emailVerified"); err != nil {
+		log.Printf("Repository error in VerifyEmail: %v", err)
+		return models.User{}, err
+	}
+
+	user.Password = ""
+	log.Printf("Successfully verified email for username: %s", user.Username)
+	return user, nil
+}
+
+// RequestPasswordReset mails a reset token to a verified address. It reports success for unknown
+// or unverified addresses too, so the endpoint cannot be used to discover accounts.
+func (s *accountService) RequestPasswordReset(ctx context.Context, email string) error {
+	log.Printf("Service RequestPasswordReset called")
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, found, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		log.Printf("Repository error in RequestPasswordReset: %v", err)
+		return err
+	}
+	if !found || !user.EmailVerified {
+		log.Printf("Password reset requested for an unknown or unverified email, ignoring")
+		return nil
+	}
+
+	if err := s.issueToken(ctx, user, enums.PasswordReset, "Reset your JBoard password",
+		"Use the token below to choose a new password. If you did not ask for a reset, ignore this email."); err != nil {
+		log.Printf("ERROR: Failed to send password reset to username %s: %v", user.Username, err)
+	}
+	return nil
+}
+
+func (s *accountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	log.Printf("Service ResetPassword called")
+
+	if strings.TrimSpace(newPassword) == "" {
+		return errors.New("password cannot be empty")
+	}
+	// Checked before the token is consumed so that a rejected password does not burn it.
+	if err := s.passwordPolicy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	stored, err := s.consume(ctx, token, enums.PasswordReset)
+	if err != nil {
+		return err
+	}
+
+	user, found, err := s.userRepo.FindByUsername(ctx, stored.Username)
+	if err != nil {
+		log.Printf("Repository error in ResetPassword: %v", err)
+		return err
+	}
+	if !found || user.Email != stored.Email {
+		log.Printf("Reset token no longer matches the account of username: %s", stored.Username)
+		return errors.New("invalid or expired token")
+	}
+
+	user.Password = newPassword
+	if err := s.userRepo.UpdateFields(ctx, user.ID.Hex(), user, "password"); err != nil {
+		log.Printf("Repository error in ResetPassword: %v", err)
+		return err
+	}
+
+	// The owner just proved control of the mailbox, so lift any lockout on the username.
+	if err := s.attemptRepo.Reset(ctx, models.UserAttemptKey(user.Username)); err != nil {
+		log.Printf("ERROR: Failed to reset login attempts for %s: %v", user.Username, err)
+	}
+
+	log.Printf("Successfully reset password for username: %s", user.Username)
+	return nil
+}
+
+func (s *accountService) findUser(ctx context.Context, username string) (models.User, error) {
+	user, found, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("Repository error finding user %s: %v", username, err)
+		return models.User{}, err
+	}
+	if !found {
+		log.Printf("User not found with username: %s", username)
+		return models.User{}, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (s *accountService) sendVerification(ctx context.Context, user models.User) error {
+	if err := s.issueToken(ctx, user, enums.EmailVerification, "Verify your JBoard email",
+		"Use the token below to confirm this email address."); err != nil {
+		log.Printf("ERROR: Failed to send verification email to username %s: %v", user.Username, err)
+		return err
+	}
+	return nil
+}
+
+// issueToken replaces any pending token of the same purpose with a new one and mails it.
+func (s *accountService) issueToken(ctx context.Context, user models.User, purpose enums.TokenPurposeEnum, subject, intro string) error {
+	secret, err := newTokenSecret()
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.DeleteByUsernameAndPurpose(ctx, user.Username, purpose); err != nil {
+		return err
+	}
+
+	now := s.now()
+	ttl := s.tokenPolicy.TTL(purpose)
+	token := models.UserToken{
+		Hash:      hashToken(secret),
+		Username:  user.Username,
+		Email:     user.Email,
+		Purpose:   purpose,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    fmt.Sprintf("Hi %s,\n\n%s\n\n%s\n\nThis token expires in %v and can be used only once.\n", user.Username, intro, secret, ttl),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("Issued %s token for username: %s", purpose, user.Username)
+	return nil
+}
+
+func (s *accountService) consume(ctx context.Context, token string, purpose enums.TokenPurposeEnum) (models.UserToken, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return models.UserToken{}, errors.New("token cannot be empty")
+	}
+
+	stored, found, err := s.tokenRepo.Consume(ctx, hashToken(token), purpose, s.now())
+	if err != nil {
+		log.Printf("Repository error consuming %s token: %v", purpose, err)
+		return models.UserToken{}, err
+	}
+	if !found {
+		log.Printf("Unknown, used or expired %s token", purpose)
+		return models.UserToken{}, errors.New("invalid or expired token")
+	}
+	return stored, nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", errors.New("email cannot be empty")
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", errors.New("invalid email address")
+	}
+	return email, nil
+}
+
+func newTokenSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("ERROR: Failed to generate token: %v", err)
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/mailer"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeUserTokenRepository struct {
+	tokens map[string]models.UserToken
+}
+
+func newFakeUserTokenRepository() *fakeUserTokenRepository {
+	return &fakeUserTokenRepository{tokens: map[string]models.UserToken{}}
+}
+
+func (f *fakeUserTokenRepository) Create(_ context.Context, token models.UserToken) error {
+	f.tokens[token.Hash] = token
+	return nil
+}
+
+func (f *fakeUserTokenRepository) Consume(_ context.Context, hash string, purpose enums.TokenPurposeEnum, now time.Time) (models.UserToken, bool, error) {
+	token, found := f.tokens[hash]
+	if !found || token.Purpose != purpose || !token.ExpiresAt.After(now) {
+		return models.UserToken{}, false, nil
+	}
+	delete(f.tokens, hash)
+	return token, true, nil
+}
+
+func (f *fakeUserTokenRepository) DeleteByUsernameAndPurpose(_ context.Context, username string, purpose enums.TokenPurposeEnum) error {
+	for hash, token := range f.tokens {
+		if token.Username == username && token.Purpose == purpose {
+			delete(f.tokens, hash)
+		}
+	}
+	return nil
+}
+
+func (f *fakeUserTokenRepository) Name() string {
+	return "user_tokens"
+}
+
+func (f *fakeUserTokenRepository) ExportByUsername(_ context.Context, _ string) (any, error) {
+	return nil, nil
+}
+
+func (f *fakeUserTokenRepository) DeleteByUsername(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *fakeUserTokenRepository) RenameUsername(_ context.Context, _, _ string) error {
+	return nil
+}
+
+type accountTestEnv struct {
+	service  *accountService
+	users    map[string]models.User
+	tokens   *fakeUserTokenRepository
+	attempts *fakeLoginAttemptRepository
+	mailer   *mailer.MemoryMailer
+	now      time.Time
+}
+
+// newAccountTestEnv backs the user repository mock with a map keyed by username.
+func newAccountTestEnv(users ...models.User) *accountTestEnv {
+	env := &accountTestEnv{
+		users:    map[string]models.User{},
+		tokens:   newFakeUserTokenRepository(),
+		attempts: newFakeLoginAttemptRepository(),
+		mailer:   mailer.NewMemoryMailer(),
+		now:      time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for _, user := range users {
+		env.users[user.Username] = user
+	}
+
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			user, found := env.users[username]
+			return user, found, nil
+		},
+		findByEmailFunc: func(ctx context.Context, email string) (models.User, bool, error) {
+			for _, user := range env.users {
+				if user.Email == email {
+					return user, true, nil
+				}
+			}
+			return models.User{}, false, nil
+		},
+		updateByIDFunc: func(ctx context.Context, id string, user models.User) error {
+			env.users[user.Username] = user
+			return nil
+		},
+		updateFieldsFunc: func(ctx context.Context, id string, user models.User, fields ...string) error {
+			stored := env.users[user.Username]
+			applyUserFields(&stored, user, fields)
+			env.users[user.Username] = stored
+			return nil
+		},
+	}
+
+	env.service = NewAccountService(userRepo, env.tokens, env.attempts, env.mailer, models.DefaultPasswordPolicy(), models.DefaultTokenPolicy()).(*accountService)
+	env.service.now = func() time.Time { return env.now }
+	return env
+}
+
+// lastToken extracts the secret from the most recent email.
+func (env *accountTestEnv) lastToken(t *testing.T) string {
+	messages := env.mailer.Messages()
+	if len(messages) == 0 {
+		t.Fatal("Expected an email to be sent")
+	}
+	lines := strings.Split(messages[len(messages)-1].Body, "\n")
+	for i, line := range lines {
+		if line == "" && i+1 < len(lines) && len(lines[i+1]) == 43 {
+			return lines[i+1]
+		}
+	}
+	t.Fatalf("No token found in email: %q", messages[len(messages)-1].Body)
+	return ""
+}
+
+func accountTestUser() models.User {
+	return models.User{ID: primitive.NewObjectID(), Username: "alice", Password: "password123", Role: enums.Free}
+}
+
+func TestAccountService_SetEmail_SendsVerification(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+
+	user, err := env.service.SetEmail(context.Background(), "alice", "  Alice@Example.com ")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Email != "alice@example.com" || user.EmailVerified || user.Password != "" {
+		t.Errorf("Expected normalized unverified email without password, got %+v", user)
+	}
+	if env.users["alice"].Email != "alice@example.com" {
+		t.Error("Expected email to be persisted")
+	}
+	messages := env.mailer.Messages()
+	if len(messages) != 1 || messages[0].To != "alice@example.com" {
+		t.Fatalf("Expected one verification email, got %+v", messages)
+	}
+	for _, token := range env.tokens.tokens {
+		if token.Purpose != enums.EmailVerification || !token.ExpiresAt.Equal(env.now.Add(24*time.Hour)) {
+			t.Errorf("Unexpected stored token: %+v", token)
+		}
+		if strings.Contains(messages[0].Body, token.Hash) {
+			t.Error("Expected the stored hash not to be mailed")
+		}
+	}
+}
+
+func TestAccountService_SetEmail_InvalidEmail(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+
+	for _, email := range []string{"not-an-email", "Alice <alice@example.com>", ""} {
+		if _, err := env.service.SetEmail(context.Background(), "alice", email); err == nil {
+			t.Errorf("Expected error for %q, got nil", email)
+		}
+	}
+}
+
+func TestAccountService_SetEmail_Conflict(t *testing.T) {
+	bob := models.User{ID: primitive.NewObjectID(), Username: "bob", Email: "shared@example.com", EmailVerified: true}
+	env := newAccountTestEnv(accountTestUser(), bob)
+
+	_, err := env.service.SetEmail(context.Background(), "alice", "shared@example.com")
+
+	var conflict *models.ConflictError
+	if !errors.As(err, &conflict) || conflict.Field != "email" {
+		t.Errorf("Expected email conflict, got %v", err)
+	}
+}
+
+func TestAccountService_SetEmail_UserNotFound(t *testing.T) {
+	env := newAccountTestEnv()
+
+	_, err := env.service.SetEmail(context.Background(), "ghost", "ghost@example.com")
+
+	if err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected 'user not found', got %v", err)
+	}
+}
+
+func TestAccountService_VerifyEmail_SingleUse(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+	_, _ = env.service.SetEmail(context.Background(), "alice", "alice@example.com")
+	token := env.lastToken(t)
+
+	user, err := env.service.VerifyEmail(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !user.EmailVerified || !env.users["alice"].EmailVerified {
+		t.Error("Expected email to be verified")
+	}
+
+	if _, err := env.service.VerifyEmail(context.Background(), token); err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("Expected reused token to be rejected, got %v", err)
+	}
+}
+
+func TestAccountService_VerifyEmail_Expired(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+	_, _ = env.service.SetEmail(context.Background(), "alice", "alice@example.com")
+	token := env.lastToken(t)
+
+	env.now = env.now.Add(25 * time.Hour)
+	_, err := env.service.VerifyEmail(context.Background(), token)
+
+	if err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("Expected expired token to be rejected, got %v", err)
+	}
+}
+
+func TestAccountService_VerifyEmail_EmailChangedSinceIssue(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+	_, _ = env.service.SetEmail(context.Background(), "alice", "old@example.com")
+	token := env.lastToken(t)
+
+	alice := env.users["alice"]
+	alice.Email = "new@example.com"
+	env.users["alice"] = alice
+
+	_, err := env.service.VerifyEmail(context.Background(), token)
+
+	if err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("Expected token for a replaced address to be rejected, got %v", err)
+	}
+}
+
+func TestAccountService_RequestEmailVerification_ReplacesPendingToken(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+	_, _ = env.service.SetEmail(context.Background(), "alice", "alice@example.com")
+	first := env.lastToken(t)
+
+	if err := env.service.RequestEmailVerification(context.Background(), "alice"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := env.service.VerifyEmail(context.Background(), first); err == nil {
+		t.Error("Expected the superseded token to be rejected")
+	}
+	if _, err := env.service.VerifyEmail(context.Background(), env.lastToken(t)); err != nil {
+		t.Errorf("Expected the latest token to work, got %v", err)
+	}
+}
+
+func TestAccountService_RequestEmailVerification_NoEmail(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+
+	err := env.service.RequestEmailVerification(context.Background(), "alice")
+
+	if err == nil || !strings.Contains(err.Error(), "no email address set") {
+		t.Errorf("Expected missing email error, got %v", err)
+	}
+}
+
+func TestAccountService_PasswordReset_Flow(t *testing.T) {
+	user := accountTestUser()
+	user.Email = "alice@example.com"
+	user.EmailVerified = true
+	env := newAccountTestEnv(user)
+	env.attempts.attempts["user:alice"] = models.LoginAttempt{Failures: 5, LockedUntil: env.now.Add(time.Hour)}
+
+	if err := env.service.RequestPasswordReset(context.Background(), "ALICE@example.com"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	token := env.lastToken(t)
+
+	if err := env.service.ResetPassword(context.Background(), token, "short"); err == nil {
+		t.Fatal("Expected password policy error, got nil")
+	}
+	if err := env.service.ResetPassword(context.Background(), token, "newpassword1"); err != nil {
+		t.Fatalf("Expected rejected password not to burn the token, got %v", err)
+	}
+
+	if env.users["alice"].Password != "newpassword1" {
+		t.Error("Expected password to be updated")
+	}
+	if _, locked := env.attempts.attempts["user:alice"]; locked {
+		t.Error("Expected username lockout to be cleared")
+	}
+	if err := env.service.ResetPassword(context.Background(), token, "anotherpass1"); err == nil {
+		t.Error("Expected reused reset token to be rejected")
+	}
+}
+
+func TestAccountService_RequestPasswordReset_UnknownOrUnverifiedEmail(t *testing.T) {
+	user := accountTestUser()
+	user.Email = "alice@example.com"
+	env := newAccountTestEnv(user)
+
+	if err := env.service.RequestPasswordReset(context.Background(), "nobody@example.com"); err != nil {
+		t.Errorf("Expected unknown email to look like success, got %v", err)
+	}
+	if err := env.service.RequestPasswordReset(context.Background(), "alice@example.com"); err != nil {
+		t.Errorf("Expected unverified email to look like success, got %v", err)
+	}
+	if len(env.mailer.Messages()) != 0 {
+		t.Error("Expected no email to be sent")
+	}
+}
+
+func TestAccountService_ResetPassword_VerificationTokenRejected(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+	_, _ = env.service.SetEmail(context.Background(), "alice", "alice@example.com")
+
+	err := env.service.ResetPassword(context.Background(), env.lastToken(t), "newpassword1")
+
+	if err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("Expected verification token not to reset passwords, got %v", err)
+	}
+}
+
+func TestAccountService_ResetPassword_EmptyToken(t *testing.T) {
+	env := newAccountTestEnv(accountTestUser())
+
+	err := env.service.ResetPassword(context.Background(), " ", "newpassword1")
+
+	if err == nil || err.Error() != "token cannot be empty" {
+		t.Errorf("Expected 'token cannot be empty', got %v", err)
+	}
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/notifier"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// skillsAlertReason is the reason recorded when a job fits the user's skills rather than one
+// of their saved searches.
+const skillsAlertReason = "skills"
+
+// alertMatchBatchSize caps how many queued jobs MatchQueued loads at a time.
+const alertMatchBatchSize = 100
+
+// AlertService turns newly ingested jobs into queued alerts and delivers the due ones. New jobs
+// are only queued by JobCreated; MatchQueued matches them in batches, off the ingest path.
+type AlertService interface {
+	JobListener
+	MatchQueued(ctx context.Context) (int, error)
+	DispatchDue(ctx context.Context) (models.AlertDispatchResult, error)
+}
+
+type alertService struct {
+	alertRepository       repositories.JobAlertRepository
+	queueRepository       repositories.JobAlertQueueRepository
+	jobRepository         repositories.JobRepository
+	savedSearchRepository repositories.SavedSearchRepository
+	skillRepository       repositories.SkillRepository
+	userRepository        repositories.UserRepository
+	taxonomyRepository    repositories.SkillTaxonomyRepository
+	channels              []notifier.Channel
+	policy                models.AlertPolicy
+	now                   func() time.Time
+}
+
+func NewAlertService(alertRepository repositories.JobAlertRepository, queueRepository repositories.JobAlertQueueRepository, jobRepository repositories.JobRepository, savedSearchRepository repositories.SavedSearchRepository, skillRepository repositories.SkillRepository, userRepository repositories.UserRepository, taxonomyRepository repositories.SkillTaxonomyRepository, channels []notifier.Channel, policy models.AlertPolicy) AlertService {
+	return &alertService{
+		alertRepository:       alertRepository,
+		queueRepository:       queueRepository,
+		jobRepository:         jobRepository,
+		savedSearchRepository: savedSearchRepository,
+		skillRepository:       skillRepository,
+		userRepository:        userRepository,
+		taxonomyRepository:    taxonomyRepository,
+		channels:              channels,
+		policy:                policy,
+		now:                   time.Now,
+	}
+}
+
+// JobCreated queues job to be matched by the next MatchQueued run.
+func (s *alertService) JobCreated(ctx context.Context, job models.Job) error {
+	log.Printf("Service JobCreated called for job: %s", job.ID)
+
+	if err := s.queueRepository.Push(ctx, job.ID, s.now()); err != nil {
+		log.Printf("ERROR: Repository error queuing job %s for alerts: %v", job.ID, err)
+		return err
+	}
+	return nil
+}
+
+// alertCandidates is what queued jobs are matched against. It is loaded once per MatchQueued
+// run rather than once per job.
+type alertCandidates struct {
+	names    map[string]string
+	searches []models.SavedSearch
+	skills   []models.Skill
+	users    map[string]models.User
+}
+
+// MatchQueued queues an alert for every user with a saved search matching a queued job, or
+// whose skills score at least the policy's MinMatchScore against it, and returns how many jobs
+// it matched. A job leaves the queue once its whole batch is matched; queuing an alert twice
+// only merges its reasons, so a batch interrupted by an error is safely matched again.
+func (s *alertService) MatchQueued(ctx context.Context) (int, error) {
+	log.Printf("Service MatchQueued called")
+
+	var candidates *alertCandidates
+	matched := 0
+	for {
+		jobIDs, err := s.queueRepository.FindBatch(ctx, alertMatchBatchSize)
+		if err != nil {
+			log.Printf("ERROR: Repository error listing queued jobs: %v", err)
+			return matched, err
+		}
+		if len(jobIDs) == 0 {
+			break
+		}
+		if candidates == nil {
+			if candidates, err = s.loadCandidates(ctx); err != nil {
+				return matched, err
+			}
+		}
+
+		jobs, err := s.jobRepository.FindByIDs(ctx, jobIDs)
+		if err != nil {
+			log.Printf("ERROR: Repository error loading queued jobs: %v", err)
+			return matched, err
+		}
+		for _, job := range jobs {
+			if err := s.matchJob(ctx, job, candidates); err != nil {
+				return matched, err
+			}
+			matched++
+		}
+		if err := s.queueRepository.DeleteByIDs(ctx, jobIDs); err != nil {
+			log.Printf("ERROR: Failed to remove matched jobs from the alert queue: %v", err)
+			return matched, err
+		}
+		if len(jobIDs) < alertMatchBatchSize {
+			break
+		}
+	}
+
+	log.Printf("Matched %d queued jobs for alerts", matched)
+	return matched, nil
+}
+
+func (s *alertService) loadCandidates(ctx context.Context) (*alertCandidates, error) {
+	catalog, err := s.taxonomyRepository.FindAll(ctx, "")
+	if err != nil {
+		log.Printf("ERROR: Repository error loading skill catalog in MatchQueued: %v", err)
+		return nil, err
+	}
+	searches, err := s.savedSearchRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing saved searches in MatchQueued: %v", err)
+		return nil, err
+	}
+	skills, err := s.skillRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing skills in MatchQueued: %v", err)
+		return nil, err
+	}
+	return &alertCandidates{
+		names:    skillNamesByAlias(catalog),
+		searches: searches,
+		skills:   skills,
+		users:    map[string]models.User{},
+	}, nil
+}
+
+// matchJob queues the alerts of one job.
+func (s *alertService) matchJob(ctx context.Context, job models.Job, candidates *alertCandidates) error {
+	reasons, order := savedSearchReasons(job, candidates.searches, candidates.names)
+
+	users := candidates.users
+	for _, skill := range candidates.skills {
+		userSkills := canonicalSkillLevels(skill.Skills, candidates.names)
+		// The profile only adds to the score, so a job failing without it can be skipped
+		// before the user is loaded.
+		if _, ok := scoreJobMatch(job, userSkills, models.UserProfile{}); !ok {
+			continue
+		}
+		user, found, err := s.findUser(ctx, users, skill.Username)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		match, _ := scoreJobMatch(job, userSkills, userProfile(user))
+		if match.Score < s.policy.MinMatchScore {
+			continue
+		}
+		key := strings.ToLower(user.Username)
+		if _, seen := reasons[key]; !seen {
+			order = append(order, key)
+		}
+		reasons[key] = append(reasons[key], skillsAlertReason)
+	}
+
+	now := s.now()
+	queued := 0
+	for _, key := range order {
+		user, found, err := s.findUser(ctx, users, key)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		frequency := enums.AlertFrequencyFor(user.Role)
+		alert := models.JobAlert{
+			Username:     user.Username,
+			JobID:        job.ID,
+			Title:        job.Title,
+			Company:      job.Company,
+			Url:          job.Url,
+			Reasons:      reasons[key],
+			Frequency:    frequency,
+			CreatedAt:    now,
+			DeliverAfter: s.policy.NextDelivery(frequency, now),
+		}
+		if err := s.alertRepository.Enqueue(ctx, alert); err != nil {
+			log.Printf("ERROR: Failed to queue alert of job %s for %s: %v", job.ID, user.Username, err)
+			return err
+		}
+		queued++
+	}
+
+	log.Printf("Queued %d alerts for job: %s", queued, job.ID)
+	return nil
+}
+
+// savedSearchReasons collects, per lower-cased username, the names of the saved searches
+// matching job. The returned order lists each user once, in the order first matched.
+func savedSearchReasons(job models.Job, searches []models.SavedSearch, names map[string]string) (map[string][]string, []string) {
+	reasons := map[string][]string{}
+	order := []string{}
+	for _, search := range searches {
+		filter := search.Filter
+		filter.Skills = canonicalSkillNames(filter.Skills, names)
+		if !filter.Matches(job) {
+			continue
+		}
+		key := strings.ToLower(search.Username)
+		if _, seen := reasons[key]; !seen {
+			order = append(order, key)
+		}
+		reasons[key] = append(reasons[key], search.Name)
+	}
+	return reasons, order
+}
+
+func (s *alertService) findUser(ctx context.Context, cache map[string]models.User, username string) (models.User, bool, error) {
+	key := strings.ToLower(username)
+	if user, cached := cache[key]; cached {
+		return user, true, nil
+	}
+	user, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding user %s for job alerts: %v", username, err)
+		return models.User{}, false, err
+	}
+	if found {
+		cache[key] = user
+	}
+	return user, found, nil
+}
+
+// DispatchDue sends every due alert, one digest per user through every channel. Each alert
+// remembers the channels that accepted it: it is removed once all of them did, and otherwise
+// rescheduled with a growing delay so that the retry only goes to the channels that failed.
+func (s *alertService) DispatchDue(ctx context.Context) (models.AlertDispatchResult, error) {
+	log.Printf("Service DispatchDue called")
+
+	now := s.now()
+	alerts, err := s.alertRepository.FindDue(ctx, now)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding due alerts: %v", err)
+		return models.AlertDispatchResult{}, err
+	}
+
+	digests := map[string]*models.AlertDigest{}
+	order := []string{}
+	for _, alert := range alerts {
+		key := strings.ToLower(alert.Username)
+		digest, seen := digests[key]
+		if !seen {
+			digest = &models.AlertDigest{Username: alert.Username}
+			digests[key] = digest
+			order = append(order, key)
+		}
+		digest.Alerts = append(digest.Alerts, alert)
+	}
+
+	result := models.AlertDispatchResult{}
+	for _, key := range order {
+		digest := digests[key]
+		user, found, err := s.userRepository.FindByUsername(ctx, digest.Username)
+		if err != nil {
+			log.Printf("ERROR: Repository error finding user %s in DispatchDue: %v", digest.Username, err)
+			result.Failed++
+			continue
+		}
+		if !found {
+			log.Printf("User %s no longer exists, dropping %d alerts", digest.Username, len(digest.Alerts))
+			if err := s.alertRepository.DeleteByIDs(ctx, alertIDs(digest.Alerts)); err != nil {
+				log.Printf("ERROR: Failed to remove alerts of %s: %v", digest.Username, err)
+				result.Failed++
+			}
+			continue
+		}
+		if user.EmailVerified {
+			digest.Email = user.Email
+		}
+
+		delivered, err := s.deliver(ctx, *digest)
+		if err != nil {
+			result.Failed++
+			s.reschedule(ctx, digest.Alerts, delivered, now)
+			continue
+		}
+		if err := s.alertRepository.DeleteByIDs(ctx, alertIDs(digest.Alerts)); err != nil {
+			log.Printf("ERROR: Failed to remove delivered alerts of %s: %v", digest.Username, err)
+			result.Failed++
+			continue
+		}
+		result.Users++
+		result.Alerts += len(digest.Alerts)
+	}
+
+	log.Printf("Dispatched %d alerts to %d users, %d failed", result.Alerts, result.Users, result.Failed)
+	return result, nil
+}
+
+// deliver sends each channel the alerts of digest it has not accepted yet. It returns the
+// channels that accepted each alert during this call, and the joined errors of the others.
+func (s *alertService) deliver(ctx context.Context, digest models.AlertDigest) (map[primitive.ObjectID][]string, error) {
+	delivered := map[primitive.ObjectID][]string{}
+	var failed []error
+	for _, channel := range s.channels {
+		pending := models.AlertDigest{Username: digest.Username, Email: digest.Email}
+		for _, alert := range digest.Alerts {
+			if !slices.Contains(alert.DeliveredTo, channel.Name()) {
+				pending.Alerts = append(pending.Alerts, alert)
+			}
+		}
+		if len(pending.Alerts) == 0 {
+			continue
+		}
+		if err := channel.Deliver(ctx, pending); err != nil {
+			log.Printf("ERROR: Channel %s failed to deliver alerts to %s: %v", channel.Name(), digest.Username, err)
+			failed = append(failed, err)
+			continue
+		}
+		for _, alert := range pending.Alerts {
+			delivered[alert.ID] = append(delivered[alert.ID], channel.Name())
+		}
+	}
+	return delivered, errors.Join(failed...)
+}
+
+// reschedule keeps alerts that some channel failed to deliver, recording the channels that
+// accepted them and backing off on every attempt.
+func (s *alertService) reschedule(ctx context.Context, alerts []models.JobAlert, delivered map[primitive.ObjectID][]string, now time.Time) {
+	for _, alert := range alerts {
+		retryAt := now.Add(s.policy.RetryDelay(alert.Attempts + 1))
+		if err := s.alertRepository.Reschedule(ctx, alert.ID, delivered[alert.ID], retryAt); err != nil {
+			log.Printf("ERROR: Failed to reschedule alert %s of %s: %v", alert.ID.Hex(), alert.Username, err)
+		}
+	}
+}
+
+func alertIDs(alerts []models.JobAlert) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(alerts))
+	for _, alert := range alerts {
+		ids = append(ids, alert.ID)
+	}
+	return ids
+}
+
+func canonicalSkillNames(skills []string, names map[string]string) []string {
+	canonical := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		if name, known := names[models.SkillKey(skill)]; known {
+			skill = name
+		}
+		canonical = append(canonical, skill)
+	}
+	return canonical
+}
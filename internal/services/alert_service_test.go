@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/notifier"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeJobAlertRepository struct {
+	alerts []models.JobAlert
+}
+
+func (f *fakeJobAlertRepository) Name() string { return "job_alerts" }
+
+func (f *fakeJobAlertRepository) ExportByUsername(_ context.Context, username string) (any, error) {
+	return nil, nil
+}
+
+func (f *fakeJobAlertRepository) DeleteByUsername(_ context.Context, username string) error {
+	return nil
+}
+
+func (f *fakeJobAlertRepository) RenameUsername(_ context.Context, oldUsername, newUsername string) error {
+	return nil
+}
+
+func (f *fakeJobAlertRepository) Enqueue(_ context.Context, alert models.JobAlert) error {
+	for i, pending := range f.alerts {
+		if strings.EqualFold(pending.Username, alert.Username) && pending.JobID == alert.JobID {
+			for _, reason := range alert.Reasons {
+				if !slices.Contains(pending.Reasons, reason) {
+					f.alerts[i].Reasons = append(f.alerts[i].Reasons, reason)
+				}
+			}
+			return nil
+		}
+	}
+	alert.ID = primitive.NewObjectID()
+	f.alerts = append(f.alerts, alert)
+	return nil
+}
+
+func (f *fakeJobAlertRepository) FindDue(_ context.Context, now time.Time) ([]models.JobAlert, error) {
+	due := []models.JobAlert{}
+	for _, alert := range f.alerts {
+		if !alert.DeliverAfter.After(now) {
+			due = append(due, alert)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeJobAlertRepository) DeleteByIDs(_ context.Context, ids []primitive.ObjectID) error {
+	f.alerts = slices.DeleteFunc(f.alerts, func(alert models.JobAlert) bool {
+		return slices.Contains(ids, alert.ID)
+	})
+	return nil
+}
+
+func (f *fakeJobAlertRepository) Reschedule(_ context.Context, id primitive.ObjectID, deliveredTo []string, deliverAfter time.Time) error {
+	for i, alert := range f.alerts {
+		if alert.ID == id {
+			for _, channel := range deliveredTo {
+				if !slices.Contains(alert.DeliveredTo, channel) {
+					f.alerts[i].DeliveredTo = append(f.alerts[i].DeliveredTo, channel)
+				}
+			}
+			f.alerts[i].Attempts++
+			f.alerts[i].DeliverAfter = deliverAfter
+		}
+	}
+	return nil
+}
+
+type fakeJobAlertQueueRepository struct {
+	jobIDs []string
+}
+
+func (f *fakeJobAlertQueueRepository) Push(_ context.Context, jobID string, queuedAt time.Time) error {
+	if !slices.Contains(f.jobIDs, jobID) {
+		f.jobIDs = append(f.jobIDs, jobID)
+	}
+	return nil
+}
+
+func (f *fakeJobAlertQueueRepository) FindBatch(_ context.Context, limit int) ([]string, error) {
+	return slices.Clone(f.jobIDs[:min(limit, len(f.jobIDs))]), nil
+}
+
+func (f *fakeJobAlertQueueRepository) DeleteByIDs(_ context.Context, jobIDs []string) error {
+	f.jobIDs = slices.DeleteFunc(f.jobIDs, func(id string) bool { return slices.Contains(jobIDs, id) })
+	return nil
+}
+
+type failingChannel struct{}
+
+func (failingChannel) Name() string { return "failing" }
+
+func (failingChannel) Deliver(context.Context, models.AlertDigest) error {
+	return errors.New("endpoint unavailable")
+}
+
+var alertTestNow = time.Date(2026, 5, 1, 15, 30, 0, 0, time.UTC)
+
+func newAlertTestService(users map[string]models.User, skills []models.Skill, channels ...notifier.Channel) (*alertService, *fakeJobAlertRepository, *fakeSavedSearchRepository) {
+	alertRepo := &fakeJobAlertRepository{}
+	searchRepo := newFakeSavedSearchRepository()
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("FindAll", mock.Anything).Return(skills, nil)
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			user, found := users[strings.ToLower(username)]
+			return user, found, nil
+		},
+	}
+	service := NewAlertService(alertRepo, &fakeJobAlertQueueRepository{}, &mockJobRepository{}, searchRepo, skillRepo, userRepo, newJobSkillTaxonomy(), channels, models.DefaultAlertPolicy()).(*alertService)
+	service.now = func() time.Time { return alertTestNow }
+	return service, alertRepo, searchRepo
+}
+
+// createJobs reports jobs as created and then runs the matching task over them.
+func createJobs(t *testing.T, service *alertService, jobs ...models.Job) {
+	stored := map[string]models.Job{}
+	for _, job := range jobs {
+		stored[job.ID] = job
+		assert.NoError(t, service.JobCreated(context.Background(), job))
+	}
+	service.jobRepository = &mockJobRepository{
+		findByIDsFunc: func(ctx context.Context, ids []string) ([]models.Job, error) {
+			found := []models.Job{}
+			for _, id := range ids {
+				found = append(found, stored[id])
+			}
+			return found, nil
+		},
+	}
+
+	matched, err := service.MatchQueued(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, len(jobs), matched)
+	assert.Empty(t, service.queueRepository.(*fakeJobAlertQueueRepository).jobIDs)
+}
+
+func TestAlertService_JobCreated_QueuesBySavedSearchAndSkills(t *testing.T) {
+	users := map[string]models.User{
+		"premium": {Username: "Premium", Role: enums.Premium},
+		"free":    {Username: "free", Role: enums.Free, Profile: &models.UserProfile{Seniority: "Senior"}},
+		"novice":  {Username: "novice", Role: enums.Free},
+	}
+	skills := []models.Skill{
+		{Username: "free", Skills: []models.SkillEntry{{Name: "golang", Proficiency: enums.ProficiencyExpert}}},
+		{Username: "novice", Skills: []models.SkillEntry{{Name: "Go", Proficiency: enums.ProficiencyBeginner}}},
+	}
+	service, alertRepo, searchRepo := newAlertTestService(users, skills)
+	_, _ = searchRepo.Create(context.Background(), models.SavedSearch{Username: "Premium", Name: "Remote Go",
+		Filter: models.JobFilter{Skills: []string{"golang"}, WorkplaceTypes: []string{"remote"}}})
+	_, _ = searchRepo.Create(context.Background(), models.SavedSearch{Username: "free", Name: "Go",
+		Filter: models.JobFilter{Skills: []string{"Go"}}})
+	_, _ = searchRepo.Create(context.Background(), models.SavedSearch{Username: "novice", Name: "Design",
+		Filter: models.JobFilter{Fields: []string{"Design"}}})
+
+	job := models.Job{ID: "job-1", Title: "Go Developer", Company: "Acme", Skills: []string{"Go", "Kubernetes"},
+		WorkplaceType: "Remote", SeniorityLevel: "Senior"}
+	createJobs(t, service, job)
+
+	assert.Len(t, alertRepo.alerts, 2)
+	byUser := map[string]models.JobAlert{}
+	for _, alert := range alertRepo.alerts {
+		byUser[alert.Username] = alert
+	}
+
+	premium := byUser["Premium"]
+	assert.Equal(t, []string{"Remote Go"}, premium.Reasons)
+	assert.Equal(t, enums.AlertInstant, premium.Frequency)
+	assert.Equal(t, alertTestNow, premium.DeliverAfter)
+
+	// Expert Go covers half the job's skills (35) and the seniority matches (15): below 60, so
+	// only the saved search triggers.
+	free := byUser["free"]
+	assert.Equal(t, []string{"Go"}, free.Reasons)
+	assert.Equal(t, enums.AlertDaily, free.Frequency)
+	assert.Equal(t, time.Date(2026, 5, 2, 12, 0, 0, 0, time.UTC), free.DeliverAfter)
+}
+
+func TestAlertService_JobCreated_SkillsReason(t *testing.T) {
+	users := map[string]models.User{"free": {Username: "free", Role: enums.Free}}
+	skills := []models.Skill{{Username: "free", Skills: []models.SkillEntry{{Name: "k8s", Proficiency: enums.ProficiencyExpert}}}}
+	service, alertRepo, searchRepo := newAlertTestService(users, skills)
+	_, _ = searchRepo.Create(context.Background(), models.SavedSearch{Username: "free", Name: "Infra",
+		Filter: models.JobFilter{Skills: []string{"Kubernetes"}}})
+
+	createJobs(t, service, models.Job{ID: "job-1", Skills: []string{"Kubernetes"}}, models.Job{ID: "job-2", Skills: []string{"PostgreSQL"}})
+
+	assert.Len(t, alertRepo.alerts, 1)
+	assert.Equal(t, []string{"Infra", skillsAlertReason}, alertRepo.alerts[0].Reasons)
+}
+
+func TestAlertService_JobCreated_OnlyQueues(t *testing.T) {
+	service, alertRepo, _ := newAlertTestService(nil, nil)
+
+	assert.NoError(t, service.JobCreated(context.Background(), models.Job{ID: "job-1"}))
+	assert.NoError(t, service.JobCreated(context.Background(), models.Job{ID: "job-1"}))
+
+	assert.Equal(t, []string{"job-1"}, service.queueRepository.(*fakeJobAlertQueueRepository).jobIDs)
+	assert.Empty(t, alertRepo.alerts)
+}
+
+func TestAlertService_DispatchDue_DigestsPerUser(t *testing.T) {
+	users := map[string]models.User{
+		"premium":    {Username: "premium", Role: enums.Premium, Email: "p@example.com", EmailVerified: true},
+		"unverified": {Username: "unverified", Role: enums.Premium, Email: "u@example.com"},
+	}
+	channel := notifier.NewMemoryChannel()
+	service, alertRepo, _ := newAlertTestService(users, nil, channel)
+	alertRepo.alerts = []models.JobAlert{
+		{ID: primitive.NewObjectID(), Username: "premium", JobID: "job-1", DeliverAfter: alertTestNow},
+		{ID: primitive.NewObjectID(), Username: "unverified", JobID: "job-1", DeliverAfter: alertTestNow},
+		{ID: primitive.NewObjectID(), Username: "premium", JobID: "job-2", DeliverAfter: alertTestNow.Add(-time.Hour)},
+		{ID: primitive.NewObjectID(), Username: "premium", JobID: "job-3", DeliverAfter: alertTestNow.Add(time.Hour)},
+		{ID: primitive.NewObjectID(), Username: "deleted", JobID: "job-1", DeliverAfter: alertTestNow},
+	}
+
+	result, err := service.DispatchDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AlertDispatchResult{Users: 2, Alerts: 3}, result)
+	digests := channel.Digests()
+	assert.Len(t, digests, 2)
+	assert.Equal(t, "p@example.com", digests[0].Email)
+	assert.Len(t, digests[0].Alerts, 2)
+	assert.Empty(t, digests[1].Email)
+	assert.Len(t, alertRepo.alerts, 1)
+	assert.Equal(t, "job-3", alertRepo.alerts[0].JobID)
+}
+
+func TestAlertService_DispatchDue_KeepsAlertsWhenAChannelFails(t *testing.T) {
+	users := map[string]models.User{"premium": {Username: "premium", Role: enums.Premium}}
+	channel := notifier.NewMemoryChannel()
+	service, alertRepo, _ := newAlertTestService(users, nil, channel, failingChannel{})
+	alertRepo.alerts = []models.JobAlert{{ID: primitive.NewObjectID(), Username: "premium", JobID: "job-1", DeliverAfter: alertTestNow}}
+
+	result, err := service.DispatchDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AlertDispatchResult{Failed: 1}, result)
+	assert.Len(t, channel.Digests(), 1)
+	assert.Len(t, alertRepo.alerts, 1)
+	assert.Equal(t, []string{"memory"}, alertRepo.alerts[0].DeliveredTo)
+	assert.Equal(t, alertTestNow.Add(time.Minute), alertRepo.alerts[0].DeliverAfter)
+
+	// Not due yet, then retried only through the channel that failed, backing off again.
+	result, _ = service.DispatchDue(context.Background())
+	assert.Equal(t, models.AlertDispatchResult{}, result)
+	service.now = func() time.Time { return alertTestNow.Add(time.Minute) }
+	result, _ = service.DispatchDue(context.Background())
+	assert.Equal(t, models.AlertDispatchResult{Failed: 1}, result)
+	assert.Len(t, channel.Digests(), 1)
+	assert.Equal(t, 2, alertRepo.alerts[0].Attempts)
+	assert.Equal(t, alertTestNow.Add(3*time.Minute), alertRepo.alerts[0].DeliverAfter)
+}
+
+func TestAlertPolicy_RetryDelay(t *testing.T) {
+	policy := models.AlertPolicy{RetryBaseDelay: time.Minute, MaxRetryDelay: 5 * time.Minute}
+
+	assert.Equal(t, time.Minute, policy.RetryDelay(1))
+	assert.Equal(t, 4*time.Minute, policy.RetryDelay(3))
+	assert.Equal(t, 5*time.Minute, policy.RetryDelay(10))
+}
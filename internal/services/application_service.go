@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"time"
+)
+
+type ApplicationService interface {
+	GetBoard(ctx context.Context, username string) (models.ApplicationBoard, error)
+	GetApplication(ctx context.Context, username, id string) (models.Application, error)
+	CreateApplication(ctx context.Context, username string, request models.ApplicationRequest) (models.Application, error)
+	UpdateApplication(ctx context.Context, username, id string, patch models.ApplicationPatch) (models.Application, error)
+	DeleteApplication(ctx context.Context, username, id string) error
+	DueReminders(ctx context.Context, username string) ([]models.Application, error)
+}
+
+type applicationService struct {
+	applicationRepository repositories.ApplicationRepository
+	userRepository        repositories.UserRepository
+	jobRepository         repositories.JobRepository
+	now                   func() time.Time
+}
+
+func NewApplicationService(applicationRepository repositories.ApplicationRepository, userRepository repositories.UserRepository, jobRepository repositories.JobRepository) ApplicationService {
+	return &applicationService{
+		applicationRepository: applicationRepository,
+		userRepository:        userRepository,
+		jobRepository:         jobRepository,
+		now:                   time.Now,
+	}
+}
+
+// GetBoard groups the user's applications by status for a kanban view. Each column lists the
+// most recently updated applications first.
+func (s *applicationService) GetBoard(ctx context.Context, username string) (models.ApplicationBoard, error) {
+	log.Printf("Service GetBoard called for username: %s", username)
+
+	applications, err := s.applicationRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error in GetBoard for username %s: %v", username, err)
+		return models.ApplicationBoard{}, err
+	}
+
+	statuses := enums.GetAllApplicationStatuses()
+	board := models.ApplicationBoard{Total: len(applications), Columns: make([]models.ApplicationColumn, len(statuses))}
+	columns := make(map[enums.ApplicationStatusEnum]*models.ApplicationColumn, len(statuses))
+	for i, status := range statuses {
+		board.Columns[i] = models.ApplicationColumn{Status: status, Applications: []models.Application{}}
+		columns[status] = &board.Columns[i]
+	}
+	for _, application := range applications {
+		column, known := columns[application.Status]
+		if !known {
+			log.Printf("WARNING: Application %s has unknown status %s", application.ID.Hex(), application.Status)
+			continue
+		}
+		column.Applications = append(column.Applications, application)
+		column.Count++
+	}
+	return board, nil
+}
+
+func (s *applicationService) GetApplication(ctx context.Context, username, id string) (models.Application, error) {
+	log.Printf("Service GetApplication called for application %s of username: %s", id, username)
+
+	application, found, err := s.applicationRepository.FindByID(ctx, username, id)
+	if err != nil {
+		log.Printf("ERROR: Repository error in GetApplication for application %s: %v", id, err)
+		return models.Application{}, err
+	}
+	if !found {
+		return models.Application{}, errors.New("application not found")
+	}
+	return application, nil
+}
+
+// CreateApplication starts tracking a job. A job listed here fills in the URL, title and
+// company; any other job is tracked by the URL given.
+func (s *applicationService) CreateApplication(ctx context.Context, username string, request models.ApplicationRequest) (models.Application, error) {
+	log.Printf("Service CreateApplication called for username: %s", username)
+
+	request = request.Normalized()
+	if fieldErrors := request.FieldErrors(); len(fieldErrors) > 0 {
+		log.Printf("Validation error in CreateApplication for username %s: %v", username, fieldErrors)
+		return models.Application{}, &models.ValidationError{Fields: fieldErrors}
+	}
+
+	user, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding user %s in CreateApplication: %v", username, err)
+		return models.Application{}, err
+	}
+	if !found {
+		return models.Application{}, errors.New("user not found")
+	}
+
+	now := s.now()
+	application := models.Application{
+		Username:   user.Username,
+		JobID:      request.JobID,
+		JobUrl:     request.JobUrl,
+		Title:      request.Title,
+		Company:    request.Company,
+		Status:     request.Status,
+		Notes:      request.Notes,
+		AppliedAt:  request.AppliedAt,
+		ReminderAt: request.ReminderAt,
+		History:    []models.ApplicationStatusChange{{Status: request.Status, ChangedAt: now}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if request.JobID != "" {
+		job, found, err := s.jobRepository.FindByID(ctx, request.JobID)
+		if err != nil {
+			log.Printf("ERROR: Repository error finding job %s in CreateApplication: %v", request.JobID, err)
+			return models.Application{}, err
+		}
+		if !found {
+			return models.Application{}, errors.New("job not found")
+		}
+		if application.JobUrl == "" {
+			application.JobUrl = job.Url
+		}
+		if application.Title == "" {
+			application.Title = job.Title
+		}
+		if application.Company == "" {
+			application.Company = job.Company
+		}
+	}
+
+	if application.Status == enums.ApplicationApplied && application.AppliedAt == nil {
+		application.AppliedAt = &now
+	}
+	if application.Status.IsClosed() {
+		application.ReminderAt = nil
+	}
+
+	created, err := s.applicationRepository.Create(ctx, application)
+	if err != nil {
+		log.Printf("ERROR: Repository error in CreateApplication for username %s: %v", username, err)
+		return models.Application{}, err
+	}
+
+	log.Printf("Created application %s for username: %s", created.ID.Hex(), username)
+	return created, nil
+}
+
+func (s *applicationService) UpdateApplication(ctx context.Context, username, id string, patch models.ApplicationPatch) (models.Application, error) {
+	log.Printf("Service UpdateApplication called for application %s of username: %s", id, username)
+
+	current, err := s.GetApplication(ctx, username, id)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	patch = patch.Normalized()
+	if fieldErrors := patch.FieldErrors(current); len(fieldErrors) > 0 {
+		log.Printf("Validation error in UpdateApplication for application %s: %v", id, fieldErrors)
+		return models.Application{}, &models.ValidationError{Fields: fieldErrors}
+	}
+
+	updated := patch.Apply(current, s.now())
+	replaced, err := s.applicationRepository.Replace(ctx, current, updated)
+	if err != nil {
+		log.Printf("ERROR: Repository error in UpdateApplication for application %s: %v", id, err)
+		return models.Application{}, err
+	}
+	if !replaced {
+		// The transition was validated against current; applying it over a newer state could
+		// skip the state machine, so the caller must read the application again.
+		log.Printf("Application %s of %s changed during UpdateApplication", id, username)
+		if _, err := s.GetApplication(ctx, username, id); err != nil {
+			return models.Application{}, err
+		}
+		return models.Application{}, &models.ConflictError{
+			Field:  "status",
+			Value:  string(current.Status),
+			Reason: "application was changed by another request, try again",
+		}
+	}
+
+	if updated.Status != current.Status {
+		log.Printf("Application %s of %s moved from %s to %s", id, username, current.Status, updated.Status)
+	}
+	return updated, nil
+}
+
+func (s *applicationService) DeleteApplication(ctx context.Context, username, id string) error {
+	log.Printf("Service DeleteApplication called for application %s of username: %s", id, username)
+
+	found, err := s.applicationRepository.Delete(ctx, username, id)
+	if err != nil {
+		log.Printf("ERROR: Repository error in DeleteApplication for application %s: %v", id, err)
+		return err
+	}
+	if !found {
+		return errors.New("application not found")
+	}
+	return nil
+}
+
+// DueReminders lists the applications whose reminder time has come, soonest first.
+func (s *applicationService) DueReminders(ctx context.Context, username string) ([]models.Application, error) {
+	log.Printf("Service DueReminders called for username: %s", username)
+
+	applications, err := s.applicationRepository.FindDueReminders(ctx, username, s.now())
+	if err != nil {
+		log.Printf("ERROR: Repository error in DueReminders for username %s: %v", username, err)
+		return nil, err
+	}
+	return applications, nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeApplicationRepository struct {
+	applications map[string]models.Application
+	// staleRead, when set, is returned by the next FindByID to simulate a concurrent write.
+	staleRead *models.Application
+}
+
+func (f *fakeApplicationRepository) Name() string { return "applications" }
+
+func (f *fakeApplicationRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	return f.FindByUsername(ctx, username)
+}
+
+func (f *fakeApplicationRepository) DeleteByUsername(_ context.Context, username string) error {
+	return nil
+}
+
+func (f *fakeApplicationRepository) RenameUsername(_ context.Context, oldUsername, newUsername string) error {
+	return nil
+}
+
+func (f *fakeApplicationRepository) Create(_ context.Context, application models.Application) (models.Application, error) {
+	for _, other := range f.applications {
+		if strings.EqualFold(other.Username, application.Username) && other.JobUrl == application.JobUrl {
+			return models.Application{}, &models.ConflictError{Field: "jobUrl", Value: application.JobUrl}
+		}
+	}
+	application.ID = primitive.NewObjectID()
+	f.applications[application.ID.Hex()] = application
+	return application, nil
+}
+
+func (f *fakeApplicationRepository) FindByUsername(_ context.Context, username string) ([]models.Application, error) {
+	applications := []models.Application{}
+	for _, application := range f.applications {
+		if strings.EqualFold(application.Username, username) {
+			applications = append(applications, application)
+		}
+	}
+	slices.SortFunc(applications, func(a, b models.Application) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return applications, nil
+}
+
+func (f *fakeApplicationRepository) FindByID(_ context.Context, username, id string) (models.Application, bool, error) {
+	if f.staleRead != nil {
+		application := *f.staleRead
+		f.staleRead = nil
+		return application, true, nil
+	}
+	application, found := f.applications[id]
+	if !found || !strings.EqualFold(application.Username, username) {
+		return models.Application{}, false, nil
+	}
+	return application, true, nil
+}
+
+func (f *fakeApplicationRepository) FindDueReminders(ctx context.Context, username string, until time.Time) ([]models.Application, error) {
+	all, _ := f.FindByUsername(ctx, username)
+	return slices.DeleteFunc(all, func(application models.Application) bool {
+		return application.ReminderAt == nil || application.ReminderAt.After(until)
+	}), nil
+}
+
+func (f *fakeApplicationRepository) Replace(_ context.Context, current, updated models.Application) (bool, error) {
+	stored, found := f.applications[current.ID.Hex()]
+	if !found || stored.Status != current.Status || !stored.UpdatedAt.Equal(current.UpdatedAt) {
+		return false, nil
+	}
+	f.applications[updated.ID.Hex()] = updated
+	return true, nil
+}
+
+func (f *fakeApplicationRepository) Delete(ctx context.Context, username, id string) (bool, error) {
+	if _, found, _ := f.FindByID(ctx, username, id); !found {
+		return false, nil
+	}
+	delete(f.applications, id)
+	return true, nil
+}
+
+var applicationTestNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newApplicationTestService() (*applicationService, *fakeApplicationRepository) {
+	repo := &fakeApplicationRepository{applications: map[string]models.Application{}}
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username}, username == "testuser", nil
+		},
+	}
+	jobRepo := &mockJobRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.Job, bool, error) {
+			if id != "job-1" {
+				return models.Job{}, false, nil
+			}
+			return models.Job{ID: id, Title: "Go Developer", Company: "Acme", Url: "https://example.com/jobs/1"}, true, nil
+		},
+	}
+	service := NewApplicationService(repo, userRepo, jobRepo).(*applicationService)
+	service.now = func() time.Time { return applicationTestNow }
+	return service, repo
+}
+
+func TestApplicationService_CreateApplication_FromListedJob(t *testing.T) {
+	service, _ := newApplicationTestService()
+
+	application, err := service.CreateApplication(context.Background(), "testuser", models.ApplicationRequest{JobID: "job-1", Status: "applied"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/jobs/1", application.JobUrl)
+	assert.Equal(t, "Go Developer", application.Title)
+	assert.Equal(t, enums.ApplicationApplied, application.Status)
+	assert.Equal(t, applicationTestNow, *application.AppliedAt)
+	assert.Len(t, application.History, 1)
+
+	_, err = service.CreateApplication(context.Background(), "testuser", models.ApplicationRequest{JobUrl: "https://example.com/jobs/1"})
+	var conflict *models.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+}
+
+func TestApplicationService_CreateApplication_Validation(t *testing.T) {
+	service, _ := newApplicationTestService()
+
+	_, err := service.CreateApplication(context.Background(), "testuser", models.ApplicationRequest{JobUrl: "not a url", Status: "HIRED"})
+	var validation *models.ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Len(t, validation.Fields, 2)
+
+	_, err = service.CreateApplication(context.Background(), "testuser", models.ApplicationRequest{JobID: "ghost"})
+	assert.EqualError(t, err, "job not found")
+}
+
+func TestApplicationService_UpdateApplication_Transitions(t *testing.T) {
+	service, _ := newApplicationTestService()
+	reminder := applicationTestNow.Add(72 * time.Hour)
+	application, err := service.CreateApplication(context.Background(), "testuser", models.ApplicationRequest{
+		JobUrl: "https://example.com/jobs/2", ReminderAt: &reminder,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, enums.ApplicationInterested, application.Status)
+	id := application.ID.Hex()
+
+	offer := enums.ApplicationOffer
+	_, err = service.UpdateApplication(context.Background(), "testuser", id, models.ApplicationPatch{Status: &offer})
+	var validation *models.ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, "cannot change from INTERESTED to OFFER", validation.Fields[0].Message)
+
+	applied := enums.ApplicationStatusEnum("applied")
+	updated, err := service.UpdateApplication(context.Background(), "testuser", id, models.ApplicationPatch{Status: &applied})
+	assert.NoError(t, err)
+	assert.Equal(t, enums.ApplicationApplied, updated.Status)
+	assert.Equal(t, applicationTestNow, *updated.AppliedAt)
+	assert.Len(t, updated.History, 2)
+
+	withdrawn := enums.ApplicationWithdrawn
+	updated, err = service.UpdateApplication(context.Background(), "testuser", id, models.ApplicationPatch{Status: &withdrawn})
+	assert.NoError(t, err)
+	assert.Nil(t, updated.ReminderAt)
+
+	_, err = service.UpdateApplication(context.Background(), "testuser", id, models.ApplicationPatch{Status: &applied})
+	assert.ErrorAs(t, err, &validation)
+
+	_, err = service.UpdateApplication(context.Background(), "otheruser", id, models.ApplicationPatch{Status: &applied})
+	assert.EqualError(t, err, "application not found")
+}
+
+func TestApplicationService_UpdateApplication_ConcurrentChangeConflicts(t *testing.T) {
+	service, repo := newApplicationTestService()
+	application, err := service.CreateApplication(context.Background(), "testuser", models.ApplicationRequest{JobUrl: "https://example.com/jobs/3"})
+	assert.NoError(t, err)
+	id := application.ID.Hex()
+
+	// Another request withdraws the application between the read and the write.
+	concurrent := repo.applications[id]
+	concurrent.Status = enums.ApplicationWithdrawn
+	concurrent.UpdatedAt = applicationTestNow.Add(time.Minute)
+	stale := repo.applications[id]
+	repo.staleRead = &stale
+	repo.applications[id] = concurrent
+
+	applied := enums.ApplicationApplied
+	_, err = service.UpdateApplication(context.Background(), "testuser", id, models.ApplicationPatch{Status: &applied})
+
+	var conflict *models.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, enums.ApplicationWithdrawn, repo.applications[id].Status)
+}
+
+func TestApplicationService_GetBoard_GroupsByStatus(t *testing.T) {
+	service, repo := newApplicationTestService()
+	for i, status := range []enums.ApplicationStatusEnum{enums.ApplicationApplied, enums.ApplicationApplied, enums.ApplicationOffer} {
+		id := primitive.NewObjectID()
+		repo.applications[id.Hex()] = models.Application{ID: id, Username: "testuser", Status: status, UpdatedAt: applicationTestNow.Add(time.Duration(i) * time.Hour)}
+	}
+
+	board, err := service.GetBoard(context.Background(), "testuser")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, board.Total)
+	assert.Len(t, board.Columns, 6)
+	assert.Equal(t, enums.ApplicationInterested, board.Columns[0].Status)
+	assert.Empty(t, board.Columns[0].Applications)
+	assert.Equal(t, 2, board.Columns[1].Count)
+	assert.True(t, board.Columns[1].Applications[0].UpdatedAt.After(board.Columns[1].Applications[1].UpdatedAt))
+	assert.Equal(t, 1, board.Columns[3].Count)
+}
+
+func TestApplicationService_DueReminders(t *testing.T) {
+	service, repo := newApplicationTestService()
+	past, future := applicationTestNow.Add(-time.Hour), applicationTestNow.Add(time.Hour)
+	for _, reminder := range []*time.Time{&past, &future, nil} {
+		id := primitive.NewObjectID()
+		repo.applications[id.Hex()] = models.Application{ID: id, Username: "testuser", ReminderAt: reminder}
+	}
+
+	due, err := service.DueReminders(context.Background(), "testuser")
+
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, past, *due[0].ReminderAt)
+}
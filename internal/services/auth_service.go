@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLoginHistoryLimit = 50
+	maxLoginHistoryLimit     = 200
+)
+
+type AuthService interface {
+	Login(ctx context.Context, req models.LoginRequest) (models.UserSummary, error)
+	GetLoginHistory(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error)
+}
+
+type authService struct {
+	userRepo    repositories.UserRepository
+	attemptRepo repositories.LoginAttemptRepository
+	auditRepo   repositories.LoginAuditRepository
+	policy      models.LockoutPolicy
+	now         func() time.Time
+}
+
+func NewAuthService(userRepo repositories.UserRepository, attemptRepo repositories.LoginAttemptRepository, auditRepo repositories.LoginAuditRepository, policy models.LockoutPolicy) AuthService {
+	log.Printf("Creating new AuthService")
+	return &authService{
+		userRepo:    userRepo,
+		attemptRepo: attemptRepo,
+		auditRepo:   auditRepo,
+		policy:      policy,
+		now:         time.Now,
+	}
+}
+
+func (s *authService) Login(ctx context.Context, req models.LoginRequest) (models.UserSummary, error) {
+	log.Printf("Service Login called for username: %s, IP: %s", req.Username, req.IP)
+
+	if strings.TrimSpace(req.Username) == "" {
+		return models.UserSummary{}, errors.New("username cannot be empty")
+	}
+	if req.Password == "" {
+		return models.UserSummary{}, errors.New("password cannot be empty")
+	}
+
+	now := s.now()
+	keys := attemptKeys(req)
+
+	lockedUntil, err := s.lockedUntil(ctx, keys, now)
+	if err != nil {
+		return models.UserSummary{}, err
+	}
+	if !lockedUntil.IsZero() {
+		log.Printf("Login rejected for username %s from IP %s: locked until %v", req.Username, req.IP, lockedUntil)
+		s.audit(ctx, req, false, "locked", now)
+		return models.UserSummary{}, &models.AccountLockedError{Until: lockedUntil}
+	}
+
+	user, found, err := s.userRepo.FindByUsername(ctx, req.Username)
+	if err != nil {
+		log.Printf("Repository error in Login: %v", err)
+		return models.UserSummary{}, err
+	}
+	if !found || subtle.ConstantTimeCompare([]byte(user.Password), []byte(req.Password)) != 1 {
+		reason := "invalid password"
+		if !found {
+			reason = "unknown user"
+		}
+		if err := s.recordFailure(ctx, keys, now); err != nil {
+			return models.UserSummary{}, err
+		}
+		s.audit(ctx, req, false, reason, now)
+		return models.UserSummary{}, errors.New("invalid credentials")
+	}
+
+	// Only the username counter is cleared: one good login must not unlock a stuffing IP.
+	if err := s.attemptRepo.Reset(ctx, keys[0]); err != nil {
+		log.Printf("ERROR: Failed to reset login attempts for %s: %v", keys[0], err)
+	}
+	s.audit(ctx, req, true, "", now)
+
+	log.Printf("Successful login for username: %s", user.Username)
+	return models.NewUserSummary(user), nil
+}
+
+func (s *authService) GetLoginHistory(ctx context.Context, username string, limit int) ([]models.LoginAuditEntry, error) {
+	log.Printf("Service GetLoginHistory called for username: %s", username)
+
+	if strings.TrimSpace(username) == "" {
+		return nil, errors.New("username cannot be empty")
+	}
+	if limit <= 0 {
+		limit = defaultLoginHistoryLimit
+	}
+	if limit > maxLoginHistoryLimit {
+		limit = maxLoginHistoryLimit
+	}
+
+	entries, err := s.auditRepo.FindByUsername(ctx, username, limit)
+	if err != nil {
+		log.Printf("Repository error in GetLoginHistory: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// attemptKeys returns the lockout counters for a request; the username key always comes first.
+func attemptKeys(req models.LoginRequest) []string {
+	keys := []string{models.UserAttemptKey(req.Username)}
+	if req.IP != "" {
+		keys = append(keys, "ip:"+req.IP)
+	}
+	return keys
+}
+
+func (s *authService) lockedUntil(ctx context.Context, keys []string, now time.Time) (time.Time, error) {
+	var until time.Time
+	for _, key := range keys {
+		attempt, found, err := s.attemptRepo.FindByKey(ctx, key)
+		if err != nil {
+			log.Printf("Repository error checking lockout for %s: %v", key, err)
+			return time.Time{}, err
+		}
+		if found && attempt.LockedUntil.After(now) && attempt.LockedUntil.After(until) {
+			until = attempt.LockedUntil
+		}
+	}
+	return until, nil
+}
+
+func (s *authService) recordFailure(ctx context.Context, keys []string, now time.Time) error {
+	for _, key := range keys {
+		attempt, err := s.attemptRepo.RecordFailure(ctx, key, now, s.policy.Window)
+		if err != nil {
+			log.Printf("Repository error recording login failure for %s: %v", key, err)
+			return err
+		}
+		if lockout := s.policy.LockoutFor(attempt.Failures); lockout > 0 {
+			log.Printf("Locking %s for %v after %d failed logins", key, lockout, attempt.Failures)
+			if err := s.attemptRepo.Lock(ctx, key, now.Add(lockout), s.policy.Window); err != nil {
+				log.Printf("Repository error locking %s: %v", key, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// audit records the attempt; a failure to write the trail never blocks the login itself.
+func (s *authService) audit(ctx context.Context, req models.LoginRequest, success bool, reason string, now time.Time) {
+	entry := models.LoginAuditEntry{
+		Username:  req.Username,
+		IP:        req.IP,
+		UserAgent: req.UserAgent,
+		Success:   success,
+		Reason:    reason,
+		Timestamp: now,
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("ERROR: Failed to write login audit for %s: %v", req.Username, err)
+	}
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeLoginAttemptRepository struct {
+	attempts map[string]models.LoginAttempt
+	err      error
+}
+
+func newFakeLoginAttemptRepository() *fakeLoginAttemptRepository {
+	return &fakeLoginAttemptRepository{attempts: map[string]models.LoginAttempt{}}
+}
+
+func (f *fakeLoginAttemptRepository) FindByKey(_ context.Context, key string) (models.LoginAttempt, bool, error) {
+	if f.err != nil {
+		return models.LoginAttempt{}, false, f.err
+	}
+	attempt, found := f.attempts[key]
+	return attempt, found, nil
+}
+
+func (f *fakeLoginAttemptRepository) RecordFailure(_ context.Context, key string, now time.Time, window time.Duration) (models.LoginAttempt, error) {
+	attempt := f.attempts[key]
+	attempt.Key = key
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	attempt.ExpiresAt = now.Add(window)
+	f.attempts[key] = attempt
+	return attempt, nil
+}
+
+func (f *fakeLoginAttemptRepository) Lock(_ context.Context, key string, until time.Time, window time.Duration) error {
+	attempt := f.attempts[key]
+	attempt.LockedUntil = until
+	attempt.ExpiresAt = until.Add(window)
+	f.attempts[key] = attempt
+	return nil
+}
+
+func (f *fakeLoginAttemptRepository) Reset(_ context.Context, key string) error {
+	delete(f.attempts, key)
+	return nil
+}
+
+func (f *fakeLoginAttemptRepository) Name() string {
+	return "login_attempts"
+}
+
+func (f *fakeLoginAttemptRepository) ExportByUsername(_ context.Context, username string) (any, error) {
+	attempt, found := f.attempts[models.UserAttemptKey(username)]
+	if !found {
+		return nil, nil
+	}
+	return attempt, nil
+}
+
+func (f *fakeLoginAttemptRepository) DeleteByUsername(ctx context.Context, username string) error {
+	return f.Reset(ctx, models.UserAttemptKey(username))
+}
+
+func (f *fakeLoginAttemptRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	attempt, found := f.attempts[models.UserAttemptKey(oldUsername)]
+	if !found {
+		return nil
+	}
+	attempt.Key = models.UserAttemptKey(newUsername)
+	f.attempts[attempt.Key] = attempt
+	return f.Reset(ctx, models.UserAttemptKey(oldUsername))
+}
+
+type fakeLoginAuditRepository struct {
+	entries []models.LoginAuditEntry
+}
+
+func (f *fakeLoginAuditRepository) Create(_ context.Context, entry models.LoginAuditEntry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func (f *fakeLoginAuditRepository) FindByUsername(_ context.Context, username string, limit int) ([]models.LoginAuditEntry, error) {
+	var result []models.LoginAuditEntry
+	for _, entry := range f.entries {
+		if entry.Username == username && (limit <= 0 || len(result) < limit) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeLoginAuditRepository) Name() string {
+	return "login_audit"
+}
+
+func (f *fakeLoginAuditRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	return f.FindByUsername(ctx, username, 0)
+}
+
+func (f *fakeLoginAuditRepository) DeleteByUsername(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *fakeLoginAuditRepository) RenameUsername(_ context.Context, _, _ string) error {
+	return nil
+}
+
+var authTestNow = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestAuthService(userRepo *mockUserRepository, attempts *fakeLoginAttemptRepository, audit *fakeLoginAuditRepository) *authService {
+	service := NewAuthService(userRepo, attempts, audit, models.LockoutPolicy{
+		MaxAttempts: 3,
+		Window:      15 * time.Minute,
+		BaseLockout: time.Minute,
+		MaxLockout:  10 * time.Minute,
+	}).(*authService)
+	service.now = func() time.Time { return authTestNow }
+	return service
+}
+
+func authTestUserRepo() *mockUserRepository {
+	return &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			if username == "alice" {
+				return models.User{ID: primitive.NewObjectID(), Username: "alice", Password: "password123", Role: enums.Free}, true, nil
+			}
+			return models.User{}, false, nil
+		},
+	}
+}
+
+func TestAuthService_Login_Success(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	audit := &fakeLoginAuditRepository{}
+	attempts.attempts["user:alice"] = models.LoginAttempt{Failures: 2}
+
+	service := newTestAuthService(authTestUserRepo(), attempts, audit)
+	user, err := service.Login(context.Background(), models.LoginRequest{
+		Username: "alice", Password: "password123", IP: "10.0.0.1", UserAgent: "test-agent",
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Username != "alice" {
+		t.Errorf("Expected user alice, got %s", user.Username)
+	}
+	if _, found := attempts.attempts["user:alice"]; found {
+		t.Error("Expected username failures to be reset after success")
+	}
+	if len(audit.entries) != 1 || !audit.entries[0].Success || audit.entries[0].UserAgent != "test-agent" || audit.entries[0].IP != "10.0.0.1" {
+		t.Errorf("Expected one successful audit entry with IP and user agent, got %+v", audit.entries)
+	}
+}
+
+func TestAuthService_Login_WrongPassword(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	audit := &fakeLoginAuditRepository{}
+
+	service := newTestAuthService(authTestUserRepo(), attempts, audit)
+	_, err := service.Login(context.Background(), models.LoginRequest{Username: "alice", Password: "wrong", IP: "10.0.0.1"})
+
+	if err == nil || err.Error() != "invalid credentials" {
+		t.Errorf("Expected 'invalid credentials', got %v", err)
+	}
+	if attempts.attempts["user:alice"].Failures != 1 || attempts.attempts["ip:10.0.0.1"].Failures != 1 {
+		t.Errorf("Expected failures recorded per username and IP, got %+v", attempts.attempts)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Success || audit.entries[0].Reason != "invalid password" {
+		t.Errorf("Expected failed audit entry, got %+v", audit.entries)
+	}
+}
+
+func TestAuthService_Login_UnknownUserLooksLikeWrongPassword(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	audit := &fakeLoginAuditRepository{}
+
+	service := newTestAuthService(authTestUserRepo(), attempts, audit)
+	_, err := service.Login(context.Background(), models.LoginRequest{Username: "mallory", Password: "guess", IP: "10.0.0.2"})
+
+	if err == nil || err.Error() != "invalid credentials" {
+		t.Errorf("Expected 'invalid credentials', got %v", err)
+	}
+	if attempts.attempts["user:mallory"].Failures != 1 {
+		t.Error("Expected failure to be recorded for unknown username")
+	}
+}
+
+func TestAuthService_Login_LocksAfterMaxAttempts(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	audit := &fakeLoginAuditRepository{}
+	service := newTestAuthService(authTestUserRepo(), attempts, audit)
+
+	for i := 0; i < 3; i++ {
+		_, _ = service.Login(context.Background(), models.LoginRequest{Username: "alice", Password: "wrong", IP: "10.0.0.1"})
+	}
+
+	_, err := service.Login(context.Background(), models.LoginRequest{Username: "alice", Password: "password123", IP: "10.0.0.9"})
+
+	var locked *models.AccountLockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("Expected AccountLockedError even with the right password, got %v", err)
+	}
+	if !locked.Until.Equal(authTestNow.Add(time.Minute)) {
+		t.Errorf("Expected lock until %v, got %v", authTestNow.Add(time.Minute), locked.Until)
+	}
+	last := audit.entries[len(audit.entries)-1]
+	if last.Success || last.Reason != "locked" {
+		t.Errorf("Expected locked attempt to be audited, got %+v", last)
+	}
+}
+
+func TestAuthService_Login_LocksPerIP(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	service := newTestAuthService(authTestUserRepo(), attempts, &fakeLoginAuditRepository{})
+
+	for _, username := range []string{"u1", "u2", "u3"} {
+		_, _ = service.Login(context.Background(), models.LoginRequest{Username: username, Password: "guess", IP: "10.0.0.66"})
+	}
+
+	_, err := service.Login(context.Background(), models.LoginRequest{Username: "alice", Password: "password123", IP: "10.0.0.66"})
+
+	var locked *models.AccountLockedError
+	if !errors.As(err, &locked) {
+		t.Errorf("Expected credential stuffing IP to be locked, got %v", err)
+	}
+}
+
+func TestAuthService_Login_LockoutExpires(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	attempts.attempts["user:alice"] = models.LoginAttempt{Failures: 3, LockedUntil: authTestNow.Add(-time.Second)}
+
+	service := newTestAuthService(authTestUserRepo(), attempts, &fakeLoginAuditRepository{})
+	_, err := service.Login(context.Background(), models.LoginRequest{Username: "alice", Password: "password123"})
+
+	if err != nil {
+		t.Errorf("Expected login to succeed after lock expired, got %v", err)
+	}
+}
+
+func TestAuthService_Login_EmptyFields(t *testing.T) {
+	service := newTestAuthService(authTestUserRepo(), newFakeLoginAttemptRepository(), &fakeLoginAuditRepository{})
+
+	if _, err := service.Login(context.Background(), models.LoginRequest{Password: "x"}); err == nil || err.Error() != "username cannot be empty" {
+		t.Errorf("Expected 'username cannot be empty', got %v", err)
+	}
+	if _, err := service.Login(context.Background(), models.LoginRequest{Username: "alice"}); err == nil || err.Error() != "password cannot be empty" {
+		t.Errorf("Expected 'password cannot be empty', got %v", err)
+	}
+}
+
+func TestAuthService_Login_AttemptRepositoryError(t *testing.T) {
+	attempts := newFakeLoginAttemptRepository()
+	attempts.err = errors.New("database error")
+
+	service := newTestAuthService(authTestUserRepo(), attempts, &fakeLoginAuditRepository{})
+	_, err := service.Login(context.Background(), models.LoginRequest{Username: "alice", Password: "password123"})
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func TestAuthService_GetLoginHistory(t *testing.T) {
+	audit := &fakeLoginAuditRepository{entries: []models.LoginAuditEntry{
+		{Username: "alice", Success: true},
+		{Username: "bob", Success: false},
+		{Username: "alice", Success: false},
+	}}
+
+	service := newTestAuthService(authTestUserRepo(), newFakeLoginAttemptRepository(), audit)
+	entries, err := service.GetLoginHistory(context.Background(), "alice", 0)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 entries for alice, got %d", len(entries))
+	}
+}
+
+func TestAuthService_GetLoginHistory_EmptyUsername(t *testing.T) {
+	service := newTestAuthService(authTestUserRepo(), newFakeLoginAttemptRepository(), &fakeLoginAuditRepository{})
+
+	_, err := service.GetLoginHistory(context.Background(), "", 10)
+
+	if err == nil || err.Error() != "username cannot be empty" {
+		t.Errorf("Expected 'username cannot be empty', got %v", err)
+	}
+}
+
+func TestUserService_CreateUser_PasswordPolicy(t *testing.T) {
+	policy := models.PasswordPolicy{MinLength: 10, RequireUppercase: true, RequireSymbol: true}
+	service := NewUserService(&mockUserRepository{}, &mockTransactionManager{}, policy)
+
+	err := service.CreateUser(context.Background(), "testuser", "short", enums.Free)
+
+	if err == nil {
+		t.Fatal("Expected password policy error, got nil")
+	}
+	expected := "invalid password: must be at least 10 characters long, contain an uppercase letter, contain a symbol"
+	if err.Error() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func TestUserService_UpdateUser_PasswordPolicy(t *testing.T) {
+	service := NewUserService(&mockUserRepository{}, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+
+	_, err := service.UpdateUser(context.Background(), "testuser", "nodigits", enums.Free)
+
+	if err == nil || err.Error() != "invalid password: must contain a digit" {
+		t.Errorf("Expected digit rule violation, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+type BookmarkService interface {
+	ListBookmarks(ctx context.Context, username string) ([]models.Bookmark, error)
+	AddBookmark(ctx context.Context, username, jobID string) (models.Bookmark, bool, error)
+	RemoveBookmark(ctx context.Context, username, jobID string) error
+}
+
+type bookmarkService struct {
+	bookmarkRepository repositories.BookmarkRepository
+	userRepository     repositories.UserRepository
+	jobRepository      repositories.JobRepository
+	now                func() time.Time
+}
+
+func NewBookmarkService(bookmarkRepository repositories.BookmarkRepository, userRepository repositories.UserRepository, jobRepository repositories.JobRepository) BookmarkService {
+	return &bookmarkService{
+		bookmarkRepository: bookmarkRepository,
+		userRepository:     userRepository,
+		jobRepository:      jobRepository,
+		now:                time.Now,
+	}
+}
+
+// ListBookmarks returns the user's bookmarks, newest first. Jobs still listed are returned as
+// they are now; expired ones fall back to the stored snapshot and are marked closed.
+func (s *bookmarkService) ListBookmarks(ctx context.Context, username string) ([]models.Bookmark, error) {
+	log.Printf("Service ListBookmarks called for username: %s", username)
+
+	bookmarks, err := s.bookmarkRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error in ListBookmarks for username %s: %v", username, err)
+		return nil, err
+	}
+	if len(bookmarks) == 0 {
+		return bookmarks, nil
+	}
+
+	ids := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		ids = append(ids, bookmark.JobID)
+	}
+	jobs, err := s.jobRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("ERROR: Repository error loading bookmarked jobs of %s: %v", username, err)
+		return nil, err
+	}
+	live := make(map[string]models.Job, len(jobs))
+	for _, job := range jobs {
+		live[job.ID] = job
+	}
+
+	// The TTL monitor removes expired jobs only periodically, so a job past its expiry that is
+	// still stored counts as closed too.
+	now := s.now()
+	for i, bookmark := range bookmarks {
+		job, found := live[bookmark.JobID]
+		if found && job.ExpiresAt.After(now) {
+			bookmarks[i].Job = job
+			continue
+		}
+		bookmarks[i].Closed = true
+	}
+	return bookmarks, nil
+}
+
+// AddBookmark bookmarks a listed job, reporting whether the bookmark is new. Bookmarking a job
+// again refreshes its snapshot.
+func (s *bookmarkService) AddBookmark(ctx context.Context, username, jobID string) (models.Bookmark, bool, error) {
+	log.Printf("Service AddBookmark called for job %s by username: %s", jobID, username)
+
+	if strings.TrimSpace(jobID) == "" {
+		return models.Bookmark{}, false, errors.New("job ID is required")
+	}
+
+	user, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding user %s in AddBookmark: %v", username, err)
+		return models.Bookmark{}, false, err
+	}
+	if !found {
+		return models.Bookmark{}, false, errors.New("user not found")
+	}
+
+	job, found, err := s.jobRepository.FindByID(ctx, jobID)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding job %s in AddBookmark: %v", jobID, err)
+		return models.Bookmark{}, false, err
+	}
+	if !found {
+		return models.Bookmark{}, false, errors.New("job not found")
+	}
+
+	bookmark, created, err := s.bookmarkRepository.Save(ctx, models.Bookmark{
+		Username:  user.Username,
+		JobID:     job.ID,
+		Job:       job,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		log.Printf("ERROR: Repository error in AddBookmark for job %s: %v", jobID, err)
+		return models.Bookmark{}, false, err
+	}
+
+	log.Printf("Saved bookmark of job %s for username: %s, new: %t", jobID, username, created)
+	return bookmark, created, nil
+}
+
+func (s *bookmarkService) RemoveBookmark(ctx context.Context, username, jobID string) error {
+	log.Printf("Service RemoveBookmark called for job %s by username: %s", jobID, username)
+
+	found, err := s.bookmarkRepository.Delete(ctx, username, jobID)
+	if err != nil {
+		log.Printf("ERROR: Repository error in RemoveBookmark for job %s: %v", jobID, err)
+		return err
+	}
+	if !found {
+		return errors.New("bookmark not found")
+	}
+	return nil
+}
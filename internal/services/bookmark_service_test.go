@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBookmarkRepository struct {
+	bookmarks []models.Bookmark
+}
+
+func (f *fakeBookmarkRepository) Name() string { return "bookmarks" }
+
+func (f *fakeBookmarkRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	return f.FindByUsername(ctx, username)
+}
+
+func (f *fakeBookmarkRepository) DeleteByUsername(_ context.Context, username string) error {
+	return nil
+}
+
+func (f *fakeBookmarkRepository) RenameUsername(_ context.Context, oldUsername, newUsername string) error {
+	return nil
+}
+
+func (f *fakeBookmarkRepository) Save(_ context.Context, bookmark models.Bookmark) (models.Bookmark, bool, error) {
+	for i, existing := range f.bookmarks {
+		if strings.EqualFold(existing.Username, bookmark.Username) && existing.JobID == bookmark.JobID {
+			f.bookmarks[i].Job = bookmark.Job
+			return f.bookmarks[i], false, nil
+		}
+	}
+	f.bookmarks = append(f.bookmarks, bookmark)
+	return bookmark, true, nil
+}
+
+func (f *fakeBookmarkRepository) FindByUsername(_ context.Context, username string) ([]models.Bookmark, error) {
+	bookmarks := []models.Bookmark{}
+	for _, bookmark := range f.bookmarks {
+		if strings.EqualFold(bookmark.Username, username) {
+			bookmarks = append(bookmarks, bookmark)
+		}
+	}
+	return bookmarks, nil
+}
+
+func (f *fakeBookmarkRepository) Delete(_ context.Context, username, jobID string) (bool, error) {
+	for i, bookmark := range f.bookmarks {
+		if strings.EqualFold(bookmark.Username, username) && bookmark.JobID == jobID {
+			f.bookmarks = append(f.bookmarks[:i], f.bookmarks[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newBookmarkTestService(jobs map[string]models.Job) (*bookmarkService, *fakeBookmarkRepository) {
+	repo := &fakeBookmarkRepository{}
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: "TestUser"}, strings.EqualFold(username, "testuser"), nil
+		},
+	}
+	jobRepo := &mockJobRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.Job, bool, error) {
+			job, found := jobs[id]
+			return job, found, nil
+		},
+		findByIDsFunc: func(ctx context.Context, ids []string) ([]models.Job, error) {
+			found := []models.Job{}
+			for _, id := range ids {
+				if job, ok := jobs[id]; ok {
+					found = append(found, job)
+				}
+			}
+			return found, nil
+		},
+	}
+	return NewBookmarkService(repo, userRepo, jobRepo).(*bookmarkService), repo
+}
+
+func TestBookmarkService_AddBookmark(t *testing.T) {
+	jobs := map[string]models.Job{"job-1": {ID: "job-1", Title: "Go Developer"}}
+	service, repo := newBookmarkTestService(jobs)
+
+	bookmark, created, err := service.AddBookmark(context.Background(), "testuser", "job-1")
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "TestUser", bookmark.Username)
+	assert.Equal(t, "Go Developer", bookmark.Job.Title)
+
+	jobs["job-1"] = models.Job{ID: "job-1", Title: "Senior Go Developer"}
+	bookmark, created, err = service.AddBookmark(context.Background(), "testuser", "job-1")
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "Senior Go Developer", bookmark.Job.Title)
+	assert.Len(t, repo.bookmarks, 1)
+}
+
+func TestBookmarkService_AddBookmark_NotFound(t *testing.T) {
+	service, _ := newBookmarkTestService(map[string]models.Job{})
+
+	_, _, err := service.AddBookmark(context.Background(), "testuser", "ghost")
+	assert.EqualError(t, err, "job not found")
+	_, _, err = service.AddBookmark(context.Background(), "nobody", "ghost")
+	assert.EqualError(t, err, "user not found")
+	_, _, err = service.AddBookmark(context.Background(), "testuser", " ")
+	assert.EqualError(t, err, "job ID is required")
+}
+
+func TestBookmarkService_ListBookmarks_MarksExpiredJobsClosed(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	jobs := map[string]models.Job{
+		"live":    {ID: "live", Title: "Updated title", ExpiresAt: now.Add(time.Hour)},
+		"expired": {ID: "expired", Title: "Expired", ExpiresAt: now.Add(-time.Minute)},
+	}
+	service, repo := newBookmarkTestService(jobs)
+	service.now = func() time.Time { return now }
+	repo.bookmarks = []models.Bookmark{
+		{Username: "TestUser", JobID: "live", Job: models.Job{ID: "live", Title: "Old title"}},
+		{Username: "TestUser", JobID: "expired", Job: models.Job{ID: "expired", Title: "Expired"}},
+		{Username: "TestUser", JobID: "gone", Job: models.Job{ID: "gone", Title: "Snapshot"}},
+	}
+
+	bookmarks, err := service.ListBookmarks(context.Background(), "testuser")
+
+	assert.NoError(t, err)
+	assert.Len(t, bookmarks, 3)
+	assert.False(t, bookmarks[0].Closed)
+	assert.Equal(t, "Updated title", bookmarks[0].Job.Title)
+	assert.True(t, bookmarks[1].Closed)
+	assert.True(t, bookmarks[2].Closed)
+	assert.Equal(t, "Snapshot", bookmarks[2].Job.Title)
+}
+
+func TestBookmarkService_RemoveBookmark(t *testing.T) {
+	service, repo := newBookmarkTestService(map[string]models.Job{})
+	repo.bookmarks = []models.Bookmark{{Username: "TestUser", JobID: "job-1"}}
+
+	assert.EqualError(t, service.RemoveBookmark(context.Background(), "testuser", "job-2"), "bookmark not found")
+	assert.NoError(t, service.RemoveBookmark(context.Background(), "testuser", "job-1"))
+	assert.Empty(t, repo.bookmarks)
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTrendDays = 30
+	maxTrendDays     = 366
+	// trendSeriesLimit caps the series of a breakdown metric when no keys are requested.
+	trendSeriesLimit = 10
+)
+
+type InsightsService interface {
+	RecordSnapshot(ctx context.Context) (models.MarketSnapshot, error)
+	EnsureDailySnapshot(ctx context.Context) (bool, error)
+	Trends(ctx context.Context, query models.TrendQuery) (models.TrendReport, error)
+	TrendsForUser(ctx context.Context, username string, query models.TrendQuery) (models.TrendReport, error)
+	SalaryInsights(ctx context.Context, username string, filter models.JobFilter, currency string) (models.SalaryInsights, error)
+}
+
+type insightsService struct {
+	snapshotRepository repositories.MarketSnapshotRepository
+	jobRepository      repositories.JobRepository
+	userRepository     repositories.UserRepository
+	salaryPolicy       models.SalaryPolicy
+	now                func() time.Time
+}
+
+func NewInsightsService(snapshotRepository repositories.MarketSnapshotRepository, jobRepository repositories.JobRepository, userRepository repositories.UserRepository, salaryPolicy models.SalaryPolicy) InsightsService {
+	return &insightsService{
+		snapshotRepository: snapshotRepository,
+		jobRepository:      jobRepository,
+		userRepository:     userRepository,
+		salaryPolicy:       salaryPolicy,
+		now:                time.Now,
+	}
+}
+
+// RecordSnapshot aggregates the jobs listed right now into today's snapshot, replacing one
+// already taken today.
+func (s *insightsService) RecordSnapshot(ctx context.Context) (models.MarketSnapshot, error) {
+	log.Printf("Service RecordSnapshot called")
+
+	jobs, err := s.jobRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error loading jobs for market snapshot: %v", err)
+		return models.MarketSnapshot{}, err
+	}
+
+	now := s.now().UTC()
+	snapshot := buildMarketSnapshot(jobs, now)
+	if err := s.snapshotRepository.Upsert(ctx, snapshot); err != nil {
+		log.Printf("ERROR: Repository error storing market snapshot of %s: %v", snapshot.Date, err)
+		return models.MarketSnapshot{}, err
+	}
+
+	log.Printf("Recorded market snapshot of %s over %d jobs", snapshot.Date, snapshot.TotalJobs)
+	return snapshot, nil
+}
+
+// EnsureDailySnapshot records today's snapshot unless one exists, so it can run more often than
+// daily and still catch up after restarts. It reports whether a snapshot was recorded.
+func (s *insightsService) EnsureDailySnapshot(ctx context.Context) (bool, error) {
+	today := s.now().UTC().Format(models.MarketSnapshotDateLayout)
+	exists, err := s.snapshotRepository.Exists(ctx, today)
+	if err != nil {
+		log.Printf("ERROR: Repository error looking up market snapshot of %s: %v", today, err)
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	if _, err := s.RecordSnapshot(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Trends returns the time series of one snapshot metric, one point per recorded day.
+func (s *insightsService) Trends(ctx context.Context, query models.TrendQuery) (models.TrendReport, error) {
+	log.Printf("Service Trends called for metric: %s", query.Metric)
+
+	query, err := s.normalizeTrendQuery(query)
+	if err != nil {
+		return models.TrendReport{}, err
+	}
+
+	from := query.From.Format(models.MarketSnapshotDateLayout)
+	to := query.To.Format(models.MarketSnapshotDateLayout)
+	snapshots, err := s.snapshotRepository.FindRange(ctx, from, to)
+	if err != nil {
+		log.Printf("ERROR: Repository error loading market snapshots from %s to %s: %v", from, to, err)
+		return models.TrendReport{}, err
+	}
+
+	return models.TrendReport{
+		Metric: query.Metric,
+		From:   from,
+		To:     to,
+		Series: trendSeries(snapshots, query),
+	}, nil
+}
+
+// TrendsForUser serves Trends to end users, for whom market trends are a PREMIUM feature.
+func (s *insightsService) TrendsForUser(ctx context.Context, username string, query models.TrendQuery) (models.TrendReport, error) {
+	log.Printf("Service TrendsForUser called for username: %s", username)
+
+	user, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error in TrendsForUser for username %s: %v", username, err)
+		return models.TrendReport{}, err
+	}
+	if !found {
+		return models.TrendReport{}, errors.New("user not found")
+	}
+	if user.Role != enums.Premium {
+		log.Printf("User %s with role %s denied market trends", username, user.Role)
+		return models.TrendReport{}, &models.ForbiddenError{Reason: "market trends are available to PREMIUM users"}
+	}
+	return s.Trends(ctx, query)
+}
+
+func (s *insightsService) normalizeTrendQuery(query models.TrendQuery) (models.TrendQuery, error) {
+	query.Metric = strings.TrimSpace(query.Metric)
+	if query.Metric == "" {
+		return query, errors.New("metric is required")
+	}
+	known := false
+	for _, metric := range models.TrendMetrics() {
+		if strings.EqualFold(metric, query.Metric) {
+			query.Metric, known = metric, true
+		}
+	}
+	if !known {
+		return query, fmt.Errorf("invalid metric %q, expected one of: %s", query.Metric, strings.Join(models.TrendMetrics(), ", "))
+	}
+
+	query.From, query.To = query.From.UTC(), query.To.UTC()
+	if query.To.IsZero() {
+		query.To = s.now().UTC()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddDate(0, 0, -(defaultTrendDays - 1))
+	}
+	if query.From.After(query.To) {
+		return query, errors.New("invalid date range: from is after to")
+	}
+	if query.To.Sub(query.From) >= maxTrendDays*24*time.Hour {
+		return query, fmt.Errorf("invalid date range: at most %d days", maxTrendDays)
+	}
+	return query, nil
+}
+
+func buildMarketSnapshot(jobs []models.Job, now time.Time) models.MarketSnapshot {
+	fields, seniorities, skills := newFacetCounter(), newFacetCounter(), newFacetCounter()
+	compensations := map[string][]float64{}
+	total, brazilianFriendly := 0, 0
+
+	for _, job := range jobs {
+		if !isListed(job, now) {
+			continue
+		}
+		total++
+		if job.IsBrazilianFriendly.IsFriendly {
+			brazilianFriendly++
+		}
+		fields.add(job.Field)
+		seniorities.add(job.SeniorityLevel)
+		for _, skill := range uniqueSkills(job.Skills) {
+			skills.add(skill)
+		}
+		if compensation, ok := models.ParseCompensation(job.CompensationTierSummary); ok {
+			compensations[compensation.Currency] = append(compensations[compensation.Currency], compensation.Midpoint())
+		}
+	}
+
+	snapshot := models.MarketSnapshot{
+		Date:               now.Format(models.MarketSnapshotDateLayout),
+		TakenAt:            now,
+		TotalJobs:          total,
+		ByField:            fields.counts(),
+		BySeniority:        seniorities.counts(),
+		BySkill:            skills.counts(),
+		MedianCompensation: make(map[string]float64, len(compensations)),
+	}
+	if total > 0 {
+		snapshot.BrazilianFriendlyShare = math.Round(float64(brazilianFriendly)/float64(total)*1000) / 1000
+	}
+	for currency, midpoints := range compensations {
+		snapshot.MedianCompensation[currency] = percentile(sortedCopy(midpoints), 0.5)
+	}
+	return snapshot
+}
+
+// facetCounter counts values case-insensitively, reporting each under its first spelling.
+type facetCounter struct {
+	order  []string
+	names  map[string]string
+	totals map[string]int
+}
+
+func newFacetCounter() *facetCounter {
+	return &facetCounter{names: map[string]string{}, totals: map[string]int{}}
+}
+
+func (c *facetCounter) add(value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	key := strings.ToLower(value)
+	if _, seen := c.names[key]; !seen {
+		c.names[key] = value
+		c.order = append(c.order, key)
+	}
+	c.totals[key]++
+}
+
+// counts lists the values, most common first.
+func (c *facetCounter) counts() []models.FacetCount {
+	counts := make([]models.FacetCount, 0, len(c.order))
+	for _, key := range c.order {
+		counts = append(counts, models.FacetCount{Value: c.names[key], Count: c.totals[key]})
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	return counts
+}
+
+func uniqueSkills(skills []string) []string {
+	seen := make(map[string]bool, len(skills))
+	unique := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		key := models.SkillKey(skill)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, skill)
+	}
+	return unique
+}
+
+// isListed reports whether the job is still open; expired jobs linger until the TTL monitor
+// removes them.
+func isListed(job models.Job, now time.Time) bool {
+	return job.ExpiresAt.IsZero() || job.ExpiresAt.After(now)
+}
+
+func sortedCopy(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// percentile interpolates linearly between the closest ranks of sorted, which must not be empty.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+func trendSeries(snapshots []models.MarketSnapshot, query models.TrendQuery) []models.TrendSeries {
+	switch query.Metric {
+	case models.TrendMetricJobs:
+		return []models.TrendSeries{singleSeries(snapshots, func(snapshot models.MarketSnapshot) float64 {
+			return float64(snapshot.TotalJobs)
+		})}
+	case models.TrendMetricBrazilianFriendlyShare:
+		return []models.TrendSeries{singleSeries(snapshots, func(snapshot models.MarketSnapshot) float64 {
+			return snapshot.BrazilianFriendlyShare
+		})}
+	case models.TrendMetricMedianCompensation:
+		return breakdownSeries(snapshots, query.Keys, false, func(snapshot models.MarketSnapshot) []trendValue {
+			values := make([]trendValue, 0, len(snapshot.MedianCompensation))
+			for currency, median := range snapshot.MedianCompensation {
+				values = append(values, trendValue{key: currency, value: median})
+			}
+			return values
+		})
+	case models.TrendMetricField:
+		return breakdownSeries(snapshots, query.Keys, true, func(snapshot models.MarketSnapshot) []trendValue {
+			return facetTrendValues(snapshot.ByField)
+		})
+	case models.TrendMetricSeniority:
+		return breakdownSeries(snapshots, query.Keys, true, func(snapshot models.MarketSnapshot) []trendValue {
+			return facetTrendValues(snapshot.BySeniority)
+		})
+	default:
+		return breakdownSeries(snapshots, query.Keys, true, func(snapshot models.MarketSnapshot) []trendValue {
+			return facetTrendValues(snapshot.BySkill)
+		})
+	}
+}
+
+// trendValue is the value of one breakdown key in a snapshot.
+type trendValue struct {
+	key   string
+	value float64
+}
+
+func facetTrendValues(counts []models.FacetCount) []trendValue {
+	values := make([]trendValue, 0, len(counts))
+	for _, count := range counts {
+		values = append(values, trendValue{key: count.Value, value: float64(count.Count)})
+	}
+	return values
+}
+
+func singleSeries(snapshots []models.MarketSnapshot, value func(models.MarketSnapshot) float64) models.TrendSeries {
+	series := models.TrendSeries{Points: make([]models.TrendPoint, 0, len(snapshots))}
+	for _, snapshot := range snapshots {
+		series.Points = append(series.Points, models.TrendPoint{Date: snapshot.Date, Value: value(snapshot)})
+	}
+	return series
+}
+
+// breakdownSeries returns one series per requested key, or per the most common values of the
+// latest snapshot when none are requested. Keys match case-insensitively. With zeroMissing, days
+// a value was absent count as zero; otherwise they are left out of its series.
+func breakdownSeries(snapshots []models.MarketSnapshot, keys []string, zeroMissing bool, values func(models.MarketSnapshot) []trendValue) []models.TrendSeries {
+	if len(keys) == 0 && len(snapshots) > 0 {
+		latest := values(snapshots[len(snapshots)-1])
+		sort.SliceStable(latest, func(i, j int) bool {
+			if latest[i].value != latest[j].value {
+				return latest[i].value > latest[j].value
+			}
+			return latest[i].key < latest[j].key
+		})
+		for _, value := range latest {
+			if len(keys) == trendSeriesLimit {
+				break
+			}
+			keys = append(keys, value.key)
+		}
+	}
+
+	series := make([]models.TrendSeries, 0, len(keys))
+	for _, key := range keys {
+		series = append(series, models.TrendSeries{Key: key, Points: []models.TrendPoint{}})
+	}
+	for _, snapshot := range snapshots {
+		byKey := map[string]float64{}
+		for _, value := range values(snapshot) {
+			byKey[strings.ToLower(value.key)] = value.value
+		}
+		for i := range series {
+			value, found := byKey[strings.ToLower(series[i].Key)]
+			if !found && !zeroMissing {
+				continue
+			}
+			series[i].Points = append(series[i].Points, models.TrendPoint{Date: snapshot.Date, Value: value})
+		}
+	}
+	return series
+}
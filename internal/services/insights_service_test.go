@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMarketSnapshotRepository struct {
+	snapshots map[string]models.MarketSnapshot
+}
+
+func newFakeMarketSnapshotRepository(snapshots ...models.MarketSnapshot) *fakeMarketSnapshotRepository {
+	repo := &fakeMarketSnapshotRepository{snapshots: map[string]models.MarketSnapshot{}}
+	for _, snapshot := range snapshots {
+		repo.snapshots[snapshot.Date] = snapshot
+	}
+	return repo
+}
+
+func (f *fakeMarketSnapshotRepository) Upsert(_ context.Context, snapshot models.MarketSnapshot) error {
+	f.snapshots[snapshot.Date] = snapshot
+	return nil
+}
+
+func (f *fakeMarketSnapshotRepository) Exists(_ context.Context, date string) (bool, error) {
+	_, found := f.snapshots[date]
+	return found, nil
+}
+
+func (f *fakeMarketSnapshotRepository) FindRange(_ context.Context, from, to string) ([]models.MarketSnapshot, error) {
+	snapshots := []models.MarketSnapshot{}
+	for date, snapshot := range f.snapshots {
+		if date >= from && date <= to {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Date < snapshots[j].Date })
+	return snapshots, nil
+}
+
+func newInsightsTestService(role enums.RoleEnum, snapshotRepo *fakeMarketSnapshotRepository, jobs []models.Job) *insightsService {
+	jobRepo := &mockJobRepository{
+		findAllFunc:      func(ctx context.Context) ([]models.Job, error) { return jobs, nil },
+		findByFilterFunc: func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) { return jobs, nil },
+	}
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Role: role}, true, nil
+		},
+	}
+	service := NewInsightsService(snapshotRepo, jobRepo, userRepo, models.DefaultSalaryPolicy()).(*insightsService)
+	service.now = func() time.Time { return time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC) }
+	return service
+}
+
+func TestInsightsService_RecordSnapshot_AggregatesListedJobs(t *testing.T) {
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)
+	live := now.Add(24 * time.Hour)
+	jobs := []models.Job{
+		{ID: "1", Field: "Engineering", SeniorityLevel: "Senior", Skills: []string{"Go", "Kubernetes"}, IsBrazilianFriendly: models.BrazilianFriendly{IsFriendly: true}, CompensationTierSummary: "$120K – $160K", ExpiresAt: live},
+		{ID: "2", Field: "engineering", SeniorityLevel: "Mid", Skills: []string{"Go", "go"}, CompensationTierSummary: "$90,000 - $110,000 per year", ExpiresAt: live},
+		{ID: "3", Field: "Design", SeniorityLevel: "Senior", CompensationTierSummary: "$50/hour", ExpiresAt: live},
+		{ID: "4", Field: "Engineering", CompensationTierSummary: "R$ 10.000 a R$ 14.000 por mês", IsBrazilianFriendly: models.BrazilianFriendly{IsFriendly: true}, ExpiresAt: live},
+		{ID: "5", Field: "Engineering", CompensationTierSummary: "Competitive + 401k", ExpiresAt: live},
+		{ID: "expired", Field: "Engineering", Skills: []string{"Go"}, ExpiresAt: now.Add(-time.Hour)},
+	}
+	repo := newFakeMarketSnapshotRepository()
+	service := newInsightsTestService(enums.Free, repo, jobs)
+
+	snapshot, err := service.RecordSnapshot(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-06-10", snapshot.Date)
+	assert.Equal(t, 5, snapshot.TotalJobs)
+	assert.Equal(t, []models.FacetCount{{Value: "Engineering", Count: 4}, {Value: "Design", Count: 1}}, snapshot.ByField)
+	assert.Equal(t, []models.FacetCount{{Value: "Senior", Count: 2}, {Value: "Mid", Count: 1}}, snapshot.BySeniority)
+	assert.Equal(t, []models.FacetCount{{Value: "Go", Count: 2}, {Value: "Kubernetes", Count: 1}}, snapshot.BySkill)
+	assert.Equal(t, 0.4, snapshot.BrazilianFriendlyShare)
+	// USD midpoints: 140000, 100000 and 50*2080 = 104000.
+	assert.Equal(t, map[string]float64{"USD": 104000, "BRL": 144000}, snapshot.MedianCompensation)
+	assert.Contains(t, repo.snapshots, "2026-06-10")
+}
+
+func TestInsightsService_EnsureDailySnapshot_OncePerDay(t *testing.T) {
+	repo := newFakeMarketSnapshotRepository()
+	service := newInsightsTestService(enums.Free, repo, []models.Job{{ID: "1", Field: "Engineering"}})
+
+	recorded, err := service.EnsureDailySnapshot(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, recorded)
+
+	recorded, err = service.EnsureDailySnapshot(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, recorded)
+}
+
+func TestInsightsService_Trends_BuildsSeries(t *testing.T) {
+	repo := newFakeMarketSnapshotRepository(
+		models.MarketSnapshot{Date: "2026-06-01", TotalJobs: 10, BySkill: []models.FacetCount{{Value: "Go", Count: 4}}, MedianCompensation: map[string]float64{"USD": 120000}},
+		models.MarketSnapshot{Date: "2026-06-02", TotalJobs: 12, BySkill: []models.FacetCount{{Value: "Rust", Count: 5}, {Value: "Go", Count: 3}}, MedianCompensation: map[string]float64{"USD": 125000, "BRL": 144000}},
+		models.MarketSnapshot{Date: "2026-05-01", TotalJobs: 99},
+	)
+	service := newInsightsTestService(enums.Free, repo, nil)
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	report, err := service.Trends(context.Background(), models.TrendQuery{Metric: "jobs", From: from})
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-06-10", report.To)
+	assert.Equal(t, []models.TrendPoint{{Date: "2026-06-01", Value: 10}, {Date: "2026-06-02", Value: 12}}, report.Series[0].Points)
+
+	report, err = service.Trends(context.Background(), models.TrendQuery{Metric: "SKILL", From: from})
+	assert.NoError(t, err)
+	assert.Equal(t, "skill", report.Metric)
+	assert.Len(t, report.Series, 2)
+	assert.Equal(t, "Rust", report.Series[0].Key)
+	assert.Equal(t, []models.TrendPoint{{Date: "2026-06-01", Value: 0}, {Date: "2026-06-02", Value: 5}}, report.Series[0].Points)
+
+	report, err = service.Trends(context.Background(), models.TrendQuery{Metric: "medianCompensation", Keys: []string{"brl"}, From: from})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TrendSeries{{Key: "brl", Points: []models.TrendPoint{{Date: "2026-06-02", Value: 144000}}}}, report.Series)
+}
+
+func TestInsightsService_Trends_ValidatesQuery(t *testing.T) {
+	service := newInsightsTestService(enums.Free, newFakeMarketSnapshotRepository(), nil)
+	ctx := context.Background()
+
+	_, err := service.Trends(ctx, models.TrendQuery{})
+	assert.EqualError(t, err, "metric is required")
+	_, err = service.Trends(ctx, models.TrendQuery{Metric: "salary"})
+	assert.ErrorContains(t, err, "invalid metric")
+	_, err = service.Trends(ctx, models.TrendQuery{Metric: "jobs", From: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)})
+	assert.EqualError(t, err, "invalid date range: from is after to")
+	_, err = service.Trends(ctx, models.TrendQuery{Metric: "jobs", From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.ErrorContains(t, err, "at most 366 days")
+}
+
+func TestInsightsService_TrendsForUser_RequiresPremium(t *testing.T) {
+	free := newInsightsTestService(enums.Free, newFakeMarketSnapshotRepository(), nil)
+	_, err := free.TrendsForUser(context.Background(), "testuser", models.TrendQuery{Metric: "jobs"})
+	var forbidden *models.ForbiddenError
+	assert.ErrorAs(t, err, &forbidden)
+
+	premium := newInsightsTestService(enums.Premium, newFakeMarketSnapshotRepository(), nil)
+	report, err := premium.TrendsForUser(context.Background(), "testuser", models.TrendQuery{Metric: "jobs"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-05-12", report.From)
+}
+
+func salaryTestJobs() []models.Job {
+	jobs := []models.Job{}
+	for i, pay := range []string{"$100K", "$110K", "$120K", "$130K", "$140K", "$150K"} {
+		jobs = append(jobs, models.Job{ID: pay, Field: "Engineering", SeniorityLevel: "Senior", WorkplaceType: "Remote", CompensationTierSummary: pay})
+		if i < 2 {
+			// Two mid-level postings are too few to be reported on their own.
+			jobs = append(jobs, models.Job{ID: "mid" + pay, Field: "engineering", SeniorityLevel: "Mid", WorkplaceType: "Remote", CompensationTierSummary: "R$ 10.000 a R$ 10.000 por mês"})
+		}
+	}
+	jobs = append(jobs, models.Job{ID: "unparsed", Field: "Engineering", CompensationTierSummary: "Competitive"})
+	jobs = append(jobs, models.Job{ID: "yen", Field: "Engineering", CompensationTierSummary: "¥9,000,000"})
+	return jobs
+}
+
+func TestInsightsService_SalaryInsights_PremiumBreakdowns(t *testing.T) {
+	service := newInsightsTestService(enums.Premium, newFakeMarketSnapshotRepository(), salaryTestJobs())
+
+	insights, err := service.SalaryInsights(context.Background(), "testuser", models.JobFilter{}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", insights.Currency)
+	assert.True(t, insights.Detailed)
+	// BRL 120000/year converts to USD 21600.
+	// Too few jobs lie beyond the quartiles and the 90th percentile for them to be reported.
+	assert.Equal(t, &models.SalaryPercentiles{SampleSize: 8, P50: 115000}, insights.Overall)
+	assert.Equal(t, []models.SalaryGroup{{Value: "Engineering", SalaryPercentiles: *insights.Overall}}, insights.ByField)
+	assert.Equal(t, []models.SalaryGroup{{Value: "Senior", SalaryPercentiles: models.SalaryPercentiles{SampleSize: 6, P50: 125000}}}, insights.BySeniority)
+	assert.Len(t, insights.ByWorkplaceType, 1)
+	assert.Equal(t, "false", insights.ByBrazilianFriendly[0].Value)
+}
+
+func TestInsightsService_SalaryInsights_FreeAndAnonymousGetOverallOnly(t *testing.T) {
+	free := newInsightsTestService(enums.Free, newFakeMarketSnapshotRepository(), salaryTestJobs())
+
+	for _, username := range []string{"testuser", ""} {
+		insights, err := free.SalaryInsights(context.Background(), username, models.JobFilter{}, "brl")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BRL", insights.Currency)
+		assert.False(t, insights.Detailed)
+		assert.Equal(t, 8, insights.Overall.SampleSize)
+		assert.Nil(t, insights.ByField)
+	}
+}
+
+func TestInsightsService_SalaryInsights_TailsNeedLargerSamples(t *testing.T) {
+	jobs := []models.Job{}
+	for i := range 50 {
+		jobs = append(jobs, models.Job{ID: strconv.Itoa(i), Field: "Engineering", CompensationTierSummary: fmt.Sprintf("$%d", 100000+i*1234)})
+	}
+	service := newInsightsTestService(enums.Free, newFakeMarketSnapshotRepository(), jobs)
+
+	insights, err := service.SalaryInsights(context.Background(), "", models.JobFilter{}, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 50, insights.Overall.SampleSize)
+	assert.Equal(t, 130000.0, insights.Overall.P50)
+	assert.Equal(t, 115000.0, *insights.Overall.P25)
+	assert.Equal(t, 145000.0, *insights.Overall.P75)
+	assert.Equal(t, 154000.0, *insights.Overall.P90)
+
+	// One posting fewer leaves too few jobs above the 90th percentile, and skills never reach
+	// the repository.
+	service.jobRepository.(*mockJobRepository).findByFilterFunc = func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+		assert.Empty(t, filter.Skills)
+		return jobs[:49], nil
+	}
+	insights, err = service.SalaryInsights(context.Background(), "", models.JobFilter{Skills: []string{"Go"}}, "USD")
+	assert.NoError(t, err)
+	assert.NotNil(t, insights.Overall.P75)
+	assert.Nil(t, insights.Overall.P90)
+}
+
+func TestInsightsService_SalaryInsights_WithholdsSmallSamples(t *testing.T) {
+	service := newInsightsTestService(enums.Premium, newFakeMarketSnapshotRepository(), salaryTestJobs()[:3])
+
+	insights, err := service.SalaryInsights(context.Background(), "testuser", models.JobFilter{}, "USD")
+
+	assert.NoError(t, err)
+	assert.Nil(t, insights.Overall)
+	assert.Empty(t, insights.ByField)
+
+	_, err = service.SalaryInsights(context.Background(), "testuser", models.JobFilter{}, "XYZ")
+	assert.EqualError(t, err, `invalid currency "XYZ"`)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"log"
+	"slices"
+)
+
+// tagSkills extracts the canonical skills mentioned in the job title and description. A catalog
+// failure must not block ingestion, so it is logged and the job is stored untagged until the
+// next retag run.
+func (s *jobService) tagSkills(ctx context.Context, job models.Job) []string {
+	catalog, err := s.taxonomyRepo.FindAll(ctx, "")
+	if err != nil {
+		log.Printf("ERROR: Failed to load skill catalog while tagging job '%s': %v", job.ID, err)
+		return nil
+	}
+	return jobSkills(job, catalog)
+}
+
+// RetagJobs re-extracts the skills of every stored job, so that catalog edits and merges reach
+// jobs ingested before them. Only jobs whose tags change are written.
+func (s *jobService) RetagJobs(ctx context.Context) (models.JobRetagResult, error) {
+	log.Printf("Service RetagJobs called")
+
+	catalog, err := s.taxonomyRepo.FindAll(ctx, "")
+	if err != nil {
+		log.Printf("ERROR: Repository error loading skill catalog in RetagJobs: %v", err)
+		return models.JobRetagResult{}, err
+	}
+	jobs, err := s.repo.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing jobs in RetagJobs: %v", err)
+		return models.JobRetagResult{}, err
+	}
+
+	result := models.JobRetagResult{Scanned: len(jobs)}
+	for _, job := range jobs {
+		skills := jobSkills(job, catalog)
+		if slices.Equal(skills, job.Skills) {
+			continue
+		}
+		if err := s.repo.UpdateSkills(ctx, job.ID, skills); err != nil {
+			log.Printf("ERROR: Failed to retag job '%s': %v", job.ID, err)
+			return result, err
+		}
+		result.Updated++
+	}
+
+	log.Printf("Retagged %d of %d jobs", result.Updated, result.Scanned)
+	return result, nil
+}
+
+// canonicalSkillFilter maps the requested skills to their catalog names so that ?skills=golang
+// matches jobs tagged "Go". Unknown skills are kept as given and simply match nothing.
+func (s *jobService) canonicalSkillFilter(ctx context.Context, skills []string) ([]string, error) {
+	canonical := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		entry, found, err := s.taxonomyRepo.FindByAlias(ctx, skill)
+		if err != nil {
+			log.Printf("ERROR: Repository error resolving skill filter '%s': %v", skill, err)
+			return nil, err
+		}
+		if found {
+			skill = entry.Name
+		}
+		if !slices.Contains(canonical, skill) {
+			canonical = append(canonical, skill)
+		}
+	}
+	return canonical, nil
+}
+
+// jobSkills lists the catalog names mentioned in the job title and description, sorted so
+// that retagging can compare them with the stored tags, or nil when there are none.
+func jobSkills(job models.Job, catalog []models.CanonicalSkill) []string {
+	var skills []string
+	for _, skill := range canonicalSkillsInText(job.Title, job.Description, catalog) {
+		skills = append(skills, skill.Name)
+	}
+	slices.Sort(skills)
+	return skills
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"log"
+	"math"
+	"time"
 
 	"jboard-go-crud/internal/models"
 	"jboard-go-crud/internal/repositories"
@@ -18,24 +20,43 @@ const (
 type JobService interface {
 	CreateOrUpdate(ctx context.Context, job models.Job) (UpsertOutcome, error)
 	FindAll(ctx context.Context) ([]models.Job, error)
+	FindJobs(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error)
+	JobStats(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobStats, error)
+	RecentJobs(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error)
+	StreamJobs(ctx context.Context, username string, filter models.JobFilter, useProfile bool, fn func(models.Job) error) error
+	RetagJobs(ctx context.Context) (models.JobRetagResult, error)
+}
+
+// JobListener is notified after a job is created. A failing listener is logged and never fails
+// the ingest.
+type JobListener interface {
+	JobCreated(ctx context.Context, job models.Job) error
 }
 
 type jobService struct {
-	repo repositories.JobRepository
+	repo         repositories.JobRepository
+	userRepo     repositories.UserRepository
+	taxonomyRepo repositories.SkillTaxonomyRepository
+	listeners    []JobListener
+	now          func() time.Time
 }
 
-func NewJobService(r repositories.JobRepository) JobService {
-	return &jobService{repo: r}
+func NewJobService(r repositories.JobRepository, userRepo repositories.UserRepository, taxonomyRepo repositories.SkillTaxonomyRepository, listeners ...JobListener) JobService {
+	return &jobService{repo: r, userRepo: userRepo, taxonomyRepo: taxonomyRepo, listeners: listeners, now: time.Now}
 }
 
 func (s *jobService) CreateOrUpdate(ctx context.Context, job models.Job) (UpsertOutcome, error) {
-	_, found, err := s.repo.FindByID(ctx, job.ID)
+	job.Skills = s.tagSkills(ctx, job)
+
+	existing, found, err := s.repo.FindByID(ctx, job.ID)
 	if err != nil {
 		log.Printf("FindByID error for '%s': %v", job.ID, err)
 		return 0, err
 	}
 
 	if found {
+		// Re-posting a job must not make it look new to saved searches.
+		job.FirstSeenAt = existing.FirstSeenAt
 		if err := s.repo.UpdateByID(ctx, job.ID, job); err != nil {
 			log.Printf("Update failed for '%s': %v", job.ID, err)
 			return 0, err
@@ -43,10 +64,17 @@ func (s *jobService) CreateOrUpdate(ctx context.Context, job models.Job) (Upsert
 		return OutcomeUpdated, nil
 	}
 
+	job.FirstSeenAt = s.now()
 	if err := s.repo.Create(ctx, job); err != nil {
 		log.Printf("Create failed for '%s': %v", job.ID, err)
 		return 0, err
 	}
+
+	for _, listener := range s.listeners {
+		if err := listener.JobCreated(ctx, job); err != nil {
+			log.Printf("Job listener failed for '%s': %v", job.ID, err)
+		}
+	}
 	return OutcomeCreated, nil
 }
 
@@ -58,3 +86,178 @@ func (s *jobService) FindAll(ctx context.Context) ([]models.Job, error) {
 	}
 	return jobs, nil
 }
+
+// FindJobs lists jobs matching filter. For a logged-in user, every dimension the caller left
+// empty falls back to the profile preferences unless useProfile is false.
+func (s *jobService) FindJobs(ctx context.Context, username string, filter models.JobFilter, useProfile bool) ([]models.Job, error) {
+	filter, err := s.resolveFilter(ctx, username, filter, useProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.IsEmpty() {
+		return s.FindAll(ctx)
+	}
+
+	jobs, err := s.repo.FindByFilter(ctx, filter)
+	if err != nil {
+		log.Printf("Repository FindByFilter error: %v", err)
+		return nil, err
+	}
+	if filter.HasTextCriteria() {
+		jobs = matchingText(jobs, filter)
+	}
+	return jobs, nil
+}
+
+func matchingText(jobs []models.Job, filter models.JobFilter) []models.Job {
+	kept := jobs[:0]
+	for _, job := range jobs {
+		if filter.MatchesText(job) {
+			kept = append(kept, job)
+		}
+	}
+	return kept
+}
+
+// StreamJobs calls fn for every job FindJobs would list for the same arguments, as the jobs are
+// read from the database.
+func (s *jobService) StreamJobs(ctx context.Context, username string, filter models.JobFilter, useProfile bool, fn func(models.Job) error) error {
+	filter, err := s.resolveFilter(ctx, username, filter, useProfile)
+	if err != nil {
+		return err
+	}
+
+	if filter.HasTextCriteria() {
+		next := fn
+		fn = func(job models.Job) error {
+			if !filter.MatchesText(job) {
+				return nil
+			}
+			return next(job)
+		}
+	}
+
+	if err := s.repo.StreamByFilter(ctx, filter, fn); err != nil {
+		log.Printf("Repository StreamByFilter error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// RecentJobs returns the newest open jobs matching filter, newest first. Profile defaults never
+// apply, so the result depends on the filter alone.
+func (s *jobService) RecentJobs(ctx context.Context, filter models.JobFilter, limit int) ([]models.Job, error) {
+	filter, err := s.resolveFilter(ctx, "", filter, false)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.repo.FindRecent(ctx, filter, s.now(), limit)
+	if err != nil {
+		log.Printf("Repository FindRecent error: %v", err)
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// JobStats returns facet counts over the jobs FindJobs would list for the same arguments.
+func (s *jobService) JobStats(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobStats, error) {
+	filter, err := s.resolveFilter(ctx, username, filter, useProfile)
+	if err != nil {
+		return models.JobStats{}, err
+	}
+
+	var stats models.JobStats
+	if filter.HasTextCriteria() {
+		stats, err = s.countFacets(ctx, filter)
+	} else {
+		stats, err = s.repo.FacetCounts(ctx, filter)
+	}
+	if err != nil {
+		log.Printf("Repository FacetCounts error: %v", err)
+		return models.JobStats{}, err
+	}
+	if stats.Total > 0 {
+		stats.BrazilianFriendlyRatio = math.Round(float64(stats.BrazilianFriendly)/float64(stats.Total)*1000) / 1000
+	}
+	return stats, nil
+}
+
+// countFacets computes JobStats in memory for filters the aggregation cannot apply, streaming
+// the matching jobs instead of loading them at once.
+func (s *jobService) countFacets(ctx context.Context, filter models.JobFilter) (models.JobStats, error) {
+	var stats models.JobStats
+	fields, seniorities, workplaces := newFacetCounter(), newFacetCounter(), newFacetCounter()
+	employmentTypes, companies := newFacetCounter(), newFacetCounter()
+	err := s.repo.StreamByFilter(ctx, filter, func(job models.Job) error {
+		if !filter.MatchesText(job) {
+			return nil
+		}
+		stats.Total++
+		if job.IsBrazilianFriendly.IsFriendly {
+			stats.BrazilianFriendly++
+		}
+		fields.add(job.Field)
+		seniorities.add(job.SeniorityLevel)
+		workplaces.add(job.WorkplaceType)
+		employmentTypes.add(job.EmploymentType)
+		companies.add(job.Company)
+		return nil
+	})
+	if err != nil {
+		return models.JobStats{}, err
+	}
+	stats.Fields = fields.counts()
+	stats.SeniorityLevels = seniorities.counts()
+	stats.WorkplaceTypes = workplaces.counts()
+	stats.EmploymentTypes = employmentTypes.counts()
+	stats.Companies = companies.counts()
+	if len(stats.Companies) > repositories.JobStatsCompanyLimit {
+		stats.Companies = stats.Companies[:repositories.JobStatsCompanyLimit]
+	}
+	return stats, nil
+}
+
+// resolveFilter applies the profile defaults and canonicalizes the skills of a listing filter.
+func (s *jobService) resolveFilter(ctx context.Context, username string, filter models.JobFilter, useProfile bool) (models.JobFilter, error) {
+	if useProfile && username != "" {
+		user, found, err := s.userRepo.FindByUsername(ctx, username)
+		if err != nil {
+			log.Printf("FindByUsername error for '%s': %v", username, err)
+			return models.JobFilter{}, err
+		}
+		if found && user.Profile != nil {
+			filter = withProfileDefaults(filter, user.Profile.JobFilter())
+			log.Printf("Applied profile defaults for '%s': %+v", username, filter)
+		}
+	}
+
+	if len(filter.Skills) > 0 {
+		skills, err := s.canonicalSkillFilter(ctx, filter.Skills)
+		if err != nil {
+			return models.JobFilter{}, err
+		}
+		filter.Skills = skills
+	}
+	return filter, nil
+}
+
+func withProfileDefaults(filter, defaults models.JobFilter) models.JobFilter {
+	if len(filter.Fields) == 0 {
+		filter.Fields = defaults.Fields
+	}
+	if len(filter.SeniorityLevels) == 0 {
+		filter.SeniorityLevels = defaults.SeniorityLevels
+	}
+	if len(filter.WorkplaceTypes) == 0 {
+		filter.WorkplaceTypes = defaults.WorkplaceTypes
+	}
+	if filter.MinSalary == 0 {
+		filter.MinSalary, filter.SalaryCurrency = defaults.MinSalary, defaults.SalaryCurrency
+	}
+	if filter.EnglishLevel == "" {
+		filter.EnglishLevel = defaults.EnglishLevel
+	}
+	return filter
+}
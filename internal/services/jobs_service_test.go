@@ -4,14 +4,31 @@ import (
 	"context"
 	"errors"
 	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"slices"
 	"testing"
+	"time"
 )
 
 type mockJobRepository struct {
-	createFunc     func(ctx context.Context, job models.Job) error
-	findByIDFunc   func(ctx context.Context, id string) (models.Job, bool, error)
-	updateByIDFunc func(ctx context.Context, id string, job models.Job) error
-	findAllFunc    func(ctx context.Context) ([]models.Job, error)
+	createFunc       func(ctx context.Context, job models.Job) error
+	findByIDFunc     func(ctx context.Context, id string) (models.Job, bool, error)
+	findByIDsFunc    func(ctx context.Context, ids []string) ([]models.Job, error)
+	updateByIDFunc   func(ctx context.Context, id string, job models.Job) error
+	findAllFunc      func(ctx context.Context) ([]models.Job, error)
+	findByFilterFunc func(ctx context.Context, filter models.JobFilter) ([]models.Job, error)
+	findRecentFunc   func(ctx context.Context, filter models.JobFilter, openAt time.Time, limit int) ([]models.Job, error)
+	updateSkillsFunc func(ctx context.Context, id string, skills []string) error
+	facetCountsFunc  func(ctx context.Context, filter models.JobFilter) (models.JobStats, error)
+	streamFunc       func(ctx context.Context, filter models.JobFilter, fn func(models.Job) error) error
+}
+
+func (m *mockJobRepository) StreamByFilter(ctx context.Context, filter models.JobFilter, fn func(models.Job) error) error {
+	return m.streamFunc(ctx, filter, fn)
+}
+
+func (m *mockJobRepository) FacetCounts(ctx context.Context, filter models.JobFilter) (models.JobStats, error) {
+	return m.facetCountsFunc(ctx, filter)
 }
 
 func (m *mockJobRepository) Create(ctx context.Context, job models.Job) error {
@@ -22,6 +39,14 @@ func (m *mockJobRepository) FindByID(ctx context.Context, id string) (models.Job
 	return m.findByIDFunc(ctx, id)
 }
 
+func (m *mockJobRepository) FindRecent(ctx context.Context, filter models.JobFilter, openAt time.Time, limit int) ([]models.Job, error) {
+	return m.findRecentFunc(ctx, filter, openAt, limit)
+}
+
+func (m *mockJobRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Job, error) {
+	return m.findByIDsFunc(ctx, ids)
+}
+
 func (m *mockJobRepository) UpdateByID(ctx context.Context, id string, job models.Job) error {
 	return m.updateByIDFunc(ctx, id, job)
 }
@@ -30,9 +55,17 @@ func (m *mockJobRepository) FindAll(ctx context.Context) ([]models.Job, error) {
 	return m.findAllFunc(ctx)
 }
 
+func (m *mockJobRepository) FindByFilter(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+	return m.findByFilterFunc(ctx, filter)
+}
+
+func (m *mockJobRepository) UpdateSkills(ctx context.Context, id string, skills []string) error {
+	return m.updateSkillsFunc(ctx, id, skills)
+}
+
 func TestNewJobService(t *testing.T) {
 	mockRepo := &mockJobRepository{}
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 
 	if service == nil {
 		t.Error("Expected service to be created, got nil")
@@ -58,7 +91,7 @@ func TestJobService_CreateOrUpdate_Create(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	outcome, err := service.CreateOrUpdate(ctx, job)
@@ -100,7 +133,7 @@ func TestJobService_CreateOrUpdate_Update(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	outcome, err := service.CreateOrUpdate(ctx, job)
@@ -130,7 +163,7 @@ func TestJobService_CreateOrUpdate_FindError(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	_, err := service.CreateOrUpdate(ctx, job)
@@ -163,7 +196,7 @@ func TestJobService_CreateOrUpdate_CreateError(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	_, err := service.CreateOrUpdate(ctx, job)
@@ -205,7 +238,7 @@ func TestJobService_CreateOrUpdate_UpdateError(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	_, err := service.CreateOrUpdate(ctx, job)
@@ -245,7 +278,7 @@ func TestJobService_FindAll_Success(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	jobs, err := service.FindAll(ctx)
@@ -274,7 +307,7 @@ func TestJobService_FindAll_Error(t *testing.T) {
 		},
 	}
 
-	service := NewJobService(mockRepo)
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
 	ctx := context.Background()
 
 	_, err := service.FindAll(ctx)
@@ -287,3 +320,460 @@ func TestJobService_FindAll_Error(t *testing.T) {
 		t.Errorf("Expected 'database error', got %s", err.Error())
 	}
 }
+
+func TestJobService_FindJobs_AppliesProfileDefaults(t *testing.T) {
+	var gotFilter models.JobFilter
+	mockRepo := &mockJobRepository{
+		findByFilterFunc: func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+			gotFilter = filter
+			return []models.Job{{ID: "test-id"}}, nil
+		},
+	}
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Profile: &models.UserProfile{
+				PreferredFields: []string{"Engineering"},
+				Seniority:       "Senior",
+				WorkplaceTypes:  []string{"Remote"},
+			}}, true, nil
+		},
+	}
+
+	service := NewJobService(mockRepo, userRepo, newFakeSkillTaxonomyRepository())
+	jobs, err := service.FindJobs(context.Background(), "testuser", models.JobFilter{WorkplaceTypes: []string{"Hybrid"}}, true)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Errorf("Expected 1 job, got %d", len(jobs))
+	}
+	if len(gotFilter.Fields) != 1 || gotFilter.Fields[0] != "Engineering" {
+		t.Errorf("Expected field default from profile, got %v", gotFilter.Fields)
+	}
+	if len(gotFilter.SeniorityLevels) != 1 || gotFilter.SeniorityLevels[0] != "Senior" {
+		t.Errorf("Expected seniority default from profile, got %v", gotFilter.SeniorityLevels)
+	}
+	if len(gotFilter.WorkplaceTypes) != 1 || gotFilter.WorkplaceTypes[0] != "Hybrid" {
+		t.Errorf("Expected explicit workplace type to override profile, got %v", gotFilter.WorkplaceTypes)
+	}
+}
+
+func TestJobService_FindJobs_AnonymousWithoutFilterListsAll(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+			return []models.Job{{ID: "a"}, {ID: "b"}}, nil
+		},
+	}
+
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
+	jobs, err := service.FindJobs(context.Background(), "", models.JobFilter{}, true)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Errorf("Expected 2 jobs, got %d", len(jobs))
+	}
+}
+
+func TestJobService_FindJobs_ProfileDisabled(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+			return []models.Job{{ID: "a"}}, nil
+		},
+	}
+
+	// The user repository has no functions set, so looking up the profile would panic.
+	service := NewJobService(mockRepo, &mockUserRepository{}, newFakeSkillTaxonomyRepository())
+	if _, err := service.FindJobs(context.Background(), "testuser", models.JobFilter{}, false); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestJobService_FindJobs_AppliesProfileSalaryAndEnglish(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		findByFilterFunc: func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+			return []models.Job{
+				{ID: "underpaid", CompensationTierSummary: "$60K – $80K"},
+				{ID: "paid", CompensationTierSummary: "$120K – $160K"},
+				{ID: "other-currency", CompensationTierSummary: "€50K – €60K"},
+				{ID: "unknown-pay", CompensationTierSummary: "Competitive"},
+				{ID: "fluent", Description: "Fluent English is required."},
+				{ID: "intermediate", Description: "Inglês intermediário para reuniões."},
+			}, nil
+		},
+	}
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Profile: &models.UserProfile{
+				SalaryFloor:    100000,
+				SalaryCurrency: "USD",
+				EnglishLevel:   enums.EnglishAdvanced,
+			}}, true, nil
+		},
+	}
+
+	service := NewJobService(mockRepo, userRepo, newFakeSkillTaxonomyRepository())
+	jobs, err := service.FindJobs(context.Background(), "testuser", models.JobFilter{}, true)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	if !slices.Equal(ids, []string{"paid", "other-currency", "unknown-pay", "intermediate"}) {
+		t.Errorf("Expected jobs below the floor or above the English level to be dropped, got %v", ids)
+	}
+}
+
+func TestJobService_FindJobs_UserLookupError(t *testing.T) {
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{}, false, errors.New("database error")
+		},
+	}
+
+	service := NewJobService(&mockJobRepository{}, userRepo, newFakeSkillTaxonomyRepository())
+	_, err := service.FindJobs(context.Background(), "testuser", models.JobFilter{}, true)
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func newJobSkillTaxonomy() *fakeSkillTaxonomyRepository {
+	return newFakeSkillTaxonomyRepository(
+		models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage, Aliases: []string{"golang"}},
+		models.CanonicalSkill{Name: "Kubernetes", Category: enums.SkillCategoryCloud, Aliases: []string{"k8s"}},
+		models.CanonicalSkill{Name: "PostgreSQL", Category: enums.SkillCategoryDatabase, Aliases: []string{"postgres"}},
+	)
+}
+
+func TestJobService_CreateOrUpdate_TagsSkills(t *testing.T) {
+	var stored models.Job
+	mockRepo := &mockJobRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.Job, bool, error) {
+			return models.Job{}, false, nil
+		},
+		createFunc: func(ctx context.Context, job models.Job) error {
+			stored = job
+			return nil
+		},
+	}
+
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+	_, err := service.CreateOrUpdate(context.Background(), models.Job{
+		ID:          "test-id",
+		Title:       "Senior Golang Engineer",
+		Description: "Run services on K8s backed by Postgres.",
+		Skills:      []string{"Cobol"},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !slices.Equal(stored.Skills, []string{"Go", "Kubernetes", "PostgreSQL"}) {
+		t.Errorf("Expected skills extracted from title and description, got %v", stored.Skills)
+	}
+}
+
+func TestJobService_FindJobs_CanonicalizesSkillFilter(t *testing.T) {
+	var gotFilter models.JobFilter
+	mockRepo := &mockJobRepository{
+		findByFilterFunc: func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+			gotFilter = filter
+			return nil, nil
+		},
+	}
+
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+	_, err := service.FindJobs(context.Background(), "", models.JobFilter{Skills: []string{"golang", "k8s", "Go", "Elixir"}}, false)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !slices.Equal(gotFilter.Skills, []string{"Go", "Kubernetes", "Elixir"}) {
+		t.Errorf("Expected canonical skill filter, got %v", gotFilter.Skills)
+	}
+}
+
+func TestJobService_RetagJobs(t *testing.T) {
+	updated := map[string][]string{}
+	mockRepo := &mockJobRepository{
+		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+			return []models.Job{
+				{ID: "unchanged", Title: "Go Developer", Skills: []string{"Go"}},
+				{ID: "stale", Title: "Go Developer on Kubernetes", Skills: []string{"Go"}},
+				{ID: "cleared", Title: "Office Manager", Skills: []string{"Go"}},
+			}, nil
+		},
+		updateSkillsFunc: func(ctx context.Context, id string, skills []string) error {
+			updated[id] = skills
+			return nil
+		},
+	}
+
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+	result, err := service.RetagJobs(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Scanned != 3 || result.Updated != 2 {
+		t.Errorf("Expected 3 scanned and 2 updated, got %+v", result)
+	}
+	if !slices.Equal(updated["stale"], []string{"Go", "Kubernetes"}) {
+		t.Errorf("Expected stale job to be retagged, got %v", updated["stale"])
+	}
+	if skills, found := updated["cleared"]; !found || skills != nil {
+		t.Errorf("Expected cleared job to lose its tags, got %v", skills)
+	}
+	if _, found := updated["unchanged"]; found {
+		t.Error("Expected unchanged job not to be written")
+	}
+}
+
+func TestJobService_RetagJobs_UpdateError(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+			return []models.Job{{ID: "a", Title: "Go Developer"}}, nil
+		},
+		updateSkillsFunc: func(ctx context.Context, id string, skills []string) error {
+			return errors.New("update error")
+		},
+	}
+
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+	if _, err := service.RetagJobs(context.Background()); err == nil || err.Error() != "update error" {
+		t.Errorf("Expected 'update error', got %v", err)
+	}
+}
+
+func TestJobService_CreateOrUpdate_FirstSeenAt(t *testing.T) {
+	firstSeen := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	now := firstSeen.Add(48 * time.Hour)
+	var created, updated models.Job
+	mockRepo := &mockJobRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.Job, bool, error) {
+			if id == "existing" {
+				return models.Job{ID: id, FirstSeenAt: firstSeen}, true, nil
+			}
+			return models.Job{}, false, nil
+		},
+		createFunc: func(ctx context.Context, job models.Job) error {
+			created = job
+			return nil
+		},
+		updateByIDFunc: func(ctx context.Context, id string, job models.Job) error {
+			updated = job
+			return nil
+		},
+	}
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+	service.(*jobService).now = func() time.Time { return now }
+
+	if _, err := service.CreateOrUpdate(context.Background(), models.Job{ID: "fresh"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.CreateOrUpdate(context.Background(), models.Job{ID: "existing", FirstSeenAt: now}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !created.FirstSeenAt.Equal(now) {
+		t.Errorf("Expected new job first seen now, got %v", created.FirstSeenAt)
+	}
+	if !updated.FirstSeenAt.Equal(firstSeen) {
+		t.Errorf("Expected re-posted job to keep its first-seen time, got %v", updated.FirstSeenAt)
+	}
+}
+
+type recordingJobListener struct {
+	jobs []string
+	err  error
+}
+
+func (l *recordingJobListener) JobCreated(ctx context.Context, job models.Job) error {
+	l.jobs = append(l.jobs, job.ID)
+	return l.err
+}
+
+func TestJobService_CreateOrUpdate_NotifiesListenersOnCreate(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.Job, bool, error) {
+			return models.Job{ID: id}, id == "existing", nil
+		},
+		createFunc:     func(ctx context.Context, job models.Job) error { return nil },
+		updateByIDFunc: func(ctx context.Context, id string, job models.Job) error { return nil },
+	}
+	failing := &recordingJobListener{err: errors.New("alert queue unavailable")}
+	listener := &recordingJobListener{}
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy(), failing, listener)
+
+	outcome, err := service.CreateOrUpdate(context.Background(), models.Job{ID: "fresh"})
+	if err != nil || outcome != OutcomeCreated {
+		t.Fatalf("Expected created without error, got %v, %v", outcome, err)
+	}
+	if _, err := service.CreateOrUpdate(context.Background(), models.Job{ID: "existing"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(failing.jobs) != 1 || len(listener.jobs) != 1 || listener.jobs[0] != "fresh" {
+		t.Errorf("Expected both listeners notified once of the new job, got %v and %v", failing.jobs, listener.jobs)
+	}
+}
+
+func TestJobService_JobStats(t *testing.T) {
+	var gotFilter models.JobFilter
+	mockRepo := &mockJobRepository{
+		facetCountsFunc: func(ctx context.Context, filter models.JobFilter) (models.JobStats, error) {
+			gotFilter = filter
+			return models.JobStats{Total: 3, BrazilianFriendly: 2}, nil
+		},
+	}
+	mockUserRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Profile: &models.UserProfile{WorkplaceTypes: []string{"Remote"}}}, true, nil
+		},
+	}
+	service := NewJobService(mockRepo, mockUserRepo, newJobSkillTaxonomy())
+
+	stats, err := service.JobStats(context.Background(), "testuser", models.JobFilter{Skills: []string{"golang"}}, true)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.BrazilianFriendlyRatio != 0.667 {
+		t.Errorf("Expected ratio 0.667, got %v", stats.BrazilianFriendlyRatio)
+	}
+	if !slices.Equal(gotFilter.Skills, []string{"Go"}) || !slices.Equal(gotFilter.WorkplaceTypes, []string{"Remote"}) {
+		t.Errorf("Expected the listing filter with profile defaults and canonical skills, got %+v", gotFilter)
+	}
+}
+
+func TestJobService_JobStats_Empty(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		facetCountsFunc: func(ctx context.Context, filter models.JobFilter) (models.JobStats, error) {
+			return models.JobStats{}, nil
+		},
+	}
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+
+	stats, err := service.JobStats(context.Background(), "", models.JobFilter{}, true)
+
+	if err != nil || stats.BrazilianFriendlyRatio != 0 {
+		t.Errorf("Expected zero ratio without error, got %v, %v", stats.BrazilianFriendlyRatio, err)
+	}
+}
+
+func TestJobService_JobStats_CountsInMemoryForProfileSalary(t *testing.T) {
+	mockRepo := &mockJobRepository{
+		streamFunc: func(ctx context.Context, filter models.JobFilter, fn func(models.Job) error) error {
+			for _, job := range []models.Job{
+				{ID: "a", Field: "Engineering", Company: "Acme", CompensationTierSummary: "$150K", IsBrazilianFriendly: models.BrazilianFriendly{IsFriendly: true}},
+				{ID: "b", Field: "engineering", Company: "Globex", CompensationTierSummary: "$130K"},
+				{ID: "c", Field: "Design", Company: "Acme", CompensationTierSummary: "$70K"},
+			} {
+				if err := fn(job); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	mockUserRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Profile: &models.UserProfile{SalaryFloor: 100000, SalaryCurrency: "USD"}}, true, nil
+		},
+	}
+	service := NewJobService(mockRepo, mockUserRepo, newJobSkillTaxonomy())
+
+	stats, err := service.JobStats(context.Background(), "testuser", models.JobFilter{}, true)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.Total != 2 || stats.BrazilianFriendly != 1 || stats.BrazilianFriendlyRatio != 0.5 {
+		t.Errorf("Expected 2 jobs above the floor, 1 Brazilian-friendly, got %+v", stats)
+	}
+	if len(stats.Fields) != 1 || stats.Fields[0] != (models.FacetCount{Value: "Engineering", Count: 2}) {
+		t.Errorf("Expected fields counted ignoring case, got %+v", stats.Fields)
+	}
+	if len(stats.Companies) != 2 {
+		t.Errorf("Expected 2 companies, got %+v", stats.Companies)
+	}
+}
+
+func TestJobService_RecentJobs(t *testing.T) {
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.UTC)
+	var gotFilter models.JobFilter
+	var gotOpenAt time.Time
+	var gotLimit int
+	mockRepo := &mockJobRepository{
+		findRecentFunc: func(ctx context.Context, filter models.JobFilter, openAt time.Time, limit int) ([]models.Job, error) {
+			gotFilter, gotOpenAt, gotLimit = filter, openAt, limit
+			return []models.Job{{ID: "new"}, {ID: "old"}}, nil
+		},
+	}
+	mockUserRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			t.Error("Expected profile defaults to be skipped")
+			return models.User{}, false, nil
+		},
+	}
+	service := NewJobService(mockRepo, mockUserRepo, newJobSkillTaxonomy())
+	service.(*jobService).now = func() time.Time { return now }
+
+	jobs, err := service.RecentJobs(context.Background(), models.JobFilter{Skills: []string{"golang"}, BrazilianFriendly: true}, 2)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != "new" || jobs[1].ID != "old" {
+		t.Errorf("Expected the jobs in repository order, got %+v", jobs)
+	}
+	if !gotOpenAt.Equal(now) || gotLimit != 2 {
+		t.Errorf("Expected jobs open now and the limit pushed to the repository, got %v, %d", gotOpenAt, gotLimit)
+	}
+	if !slices.Equal(gotFilter.Skills, []string{"Go"}) || !gotFilter.BrazilianFriendly {
+		t.Errorf("Expected canonical skills and the Brazilian-friendly flag, got %+v", gotFilter)
+	}
+}
+
+func TestJobService_StreamJobs(t *testing.T) {
+	var gotFilter models.JobFilter
+	mockRepo := &mockJobRepository{
+		streamFunc: func(ctx context.Context, filter models.JobFilter, fn func(models.Job) error) error {
+			gotFilter = filter
+			for _, id := range []string{"a", "b", "c"} {
+				if err := fn(models.Job{ID: id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	service := NewJobService(mockRepo, &mockUserRepository{}, newJobSkillTaxonomy())
+
+	var ids []string
+	stop := errors.New("client went away")
+	err := service.StreamJobs(context.Background(), "", models.JobFilter{Skills: []string{"k8s"}}, true, func(job models.Job) error {
+		ids = append(ids, job.ID)
+		if len(ids) == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected the callback error, got %v", err)
+	}
+	if !slices.Equal(ids, []string{"a", "b"}) {
+		t.Errorf("Expected streaming to stop after the failing job, got %v", ids)
+	}
+	if !slices.Equal(gotFilter.Skills, []string{"Kubernetes"}) {
+		t.Errorf("Expected canonical skills, got %v", gotFilter.Skills)
+	}
+}
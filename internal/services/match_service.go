@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultJobMatchLimit = 20
+	maxJobMatchLimit     = 100
+)
+
+// Score weights of a job match; they add up to 100.
+const (
+	matchSkillsWeight            = 70
+	matchSeniorityWeight         = 15
+	matchFieldWeight             = 5
+	matchWorkplaceTypeWeight     = 5
+	matchBrazilianFriendlyWeight = 5
+)
+
+type MatchService interface {
+	MatchJobs(ctx context.Context, username string, limit int) ([]models.JobMatch, error)
+	SkillGaps(ctx context.Context, username string, limit int) (models.SkillGapReport, error)
+}
+
+type matchService struct {
+	userRepository     repositories.UserRepository
+	skillRepository    repositories.SkillRepository
+	taxonomyRepository repositories.SkillTaxonomyRepository
+	jobRepository      repositories.JobRepository
+}
+
+func NewMatchService(userRepository repositories.UserRepository, skillRepository repositories.SkillRepository, taxonomyRepository repositories.SkillTaxonomyRepository, jobRepository repositories.JobRepository) MatchService {
+	return &matchService{
+		userRepository:     userRepository,
+		skillRepository:    skillRepository,
+		taxonomyRepository: taxonomyRepository,
+		jobRepository:      jobRepository,
+	}
+}
+
+// MatchJobs ranks the current jobs sharing at least one skill with the user, best match first.
+func (s *matchService) MatchJobs(ctx context.Context, username string, limit int) ([]models.JobMatch, error) {
+	log.Printf("Service MatchJobs called for username: %s", username)
+
+	if limit <= 0 {
+		limit = defaultJobMatchLimit
+	}
+	if limit > maxJobMatchLimit {
+		limit = maxJobMatchLimit
+	}
+
+	user, userSkills, err := s.loadCandidate(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	profile := userProfile(user)
+	if len(userSkills) == 0 {
+		log.Printf("User %s has no skills, no job matches", username)
+		return []models.JobMatch{}, nil
+	}
+
+	jobs, err := s.jobRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing jobs in MatchJobs: %v", err)
+		return nil, err
+	}
+
+	matches := []models.JobMatch{}
+	for _, job := range jobs {
+		if match, ok := scoreJobMatch(job, userSkills, profile); ok {
+			matches = append(matches, match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return len(matches[i].MissingSkills) < len(matches[j].MissingSkills)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	log.Printf("Returning %d job matches for username: %s", len(matches), username)
+	return matches, nil
+}
+
+// loadCandidate returns the user and their skills keyed by canonical catalog name, so that
+// "golang" on the user matches jobs tagged "Go".
+func (s *matchService) loadCandidate(ctx context.Context, username string) (models.User, map[string]enums.ProficiencyEnum, error) {
+	if strings.TrimSpace(username) == "" {
+		log.Printf("Validation error in loadCandidate: username is required")
+		return models.User{}, nil, errors.New("username is required")
+	}
+
+	user, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding user %s: %v", username, err)
+		return models.User{}, nil, err
+	}
+	if !found {
+		log.Printf("User not found: %s", username)
+		return models.User{}, nil, errors.New("user not found")
+	}
+
+	skill, found, err := s.skillRepository.FindByUsername(ctx, user.Username)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding skills of %s: %v", username, err)
+		return models.User{}, nil, err
+	}
+	if !found || len(skill.Skills) == 0 {
+		return user, nil, nil
+	}
+
+	catalog, err := s.taxonomyRepository.FindAll(ctx, "")
+	if err != nil {
+		log.Printf("ERROR: Repository error loading skill catalog: %v", err)
+		return models.User{}, nil, err
+	}
+	return user, canonicalSkillLevels(skill.Skills, skillNamesByAlias(catalog)), nil
+}
+
+// skillNamesByAlias maps every alias key of the catalog to its canonical skill name.
+func skillNamesByAlias(catalog []models.CanonicalSkill) map[string]string {
+	names := map[string]string{}
+	for _, entry := range catalog {
+		for _, alias := range entry.Aliases {
+			names[alias] = entry.Name
+		}
+	}
+	return names
+}
+
+// canonicalSkillLevels keys skill entries by canonical name, keeping the highest proficiency
+// when several aliases of one skill are listed. Unknown skills keep their own name.
+func canonicalSkillLevels(entries []models.SkillEntry, names map[string]string) map[string]enums.ProficiencyEnum {
+	skills := make(map[string]enums.ProficiencyEnum, len(entries))
+	for _, entry := range entries {
+		name, known := names[models.SkillKey(entry.Name)]
+		if !known {
+			name = entry.Name
+		}
+		if current, seen := skills[name]; !seen || entry.Proficiency.Rank() > current.Rank() {
+			skills[name] = entry.Proficiency
+		}
+	}
+	return skills
+}
+
+func userProfile(user models.User) models.UserProfile {
+	if user.Profile == nil {
+		return models.UserProfile{}
+	}
+	return *user.Profile
+}
+
+// scoreJobMatch scores job for a user with the given canonical skills. Jobs without any skill
+// in common are not matches.
+func scoreJobMatch(job models.Job, userSkills map[string]enums.ProficiencyEnum, profile models.UserProfile) (models.JobMatch, bool) {
+	match := models.JobMatch{Job: job, MatchedSkills: []string{}, MissingSkills: []string{}}
+	coverage := 0.0
+	for _, name := range job.Skills {
+		proficiency, has := userSkills[name]
+		if !has {
+			match.MissingSkills = append(match.MissingSkills, name)
+			continue
+		}
+		match.MatchedSkills = append(match.MatchedSkills, name)
+		coverage += proficiencyWeight(proficiency)
+	}
+	if len(match.MatchedSkills) == 0 {
+		return models.JobMatch{}, false
+	}
+
+	score := matchSkillsWeight * coverage / float64(len(job.Skills))
+	if profile.Seniority != "" && strings.EqualFold(profile.Seniority, job.SeniorityLevel) {
+		score += matchSeniorityWeight
+	}
+	if containsFold(profile.PreferredFields, job.Field) {
+		score += matchFieldWeight
+	}
+	if containsFold(profile.WorkplaceTypes, job.WorkplaceType) {
+		score += matchWorkplaceTypeWeight
+	}
+	if profile.BrazilianFriendly && job.IsBrazilianFriendly.IsFriendly {
+		score += matchBrazilianFriendlyWeight
+	}
+	match.Score = int(math.Round(score))
+	return match, true
+}
+
+// proficiencyWeight discounts lower proficiency levels; a skill listed without a level counts as
+// intermediate.
+func proficiencyWeight(proficiency enums.ProficiencyEnum) float64 {
+	rank := proficiency.Rank()
+	if rank == 0 {
+		rank = enums.ProficiencyIntermediate.Rank()
+	}
+	return float64(rank+4) / 8
+}
+
+func containsFold(values []string, value string) bool {
+	return value != "" && slices.ContainsFunc(values, func(candidate string) bool {
+		return strings.EqualFold(candidate, value)
+	})
+}
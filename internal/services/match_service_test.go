@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newMatchTestService(profile *models.UserProfile, skills []models.SkillEntry, jobs []models.Job) MatchService {
+	return newMatchTestServiceWithRole(enums.Free, profile, skills, jobs)
+}
+
+func newMatchTestServiceWithRole(role enums.RoleEnum, profile *models.UserProfile, skills []models.SkillEntry, jobs []models.Job) MatchService {
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			if username != "testuser" {
+				return models.User{}, false, nil
+			}
+			return models.User{Username: username, Role: role, Profile: profile}, true, nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser", Skills: skills}, skills != nil, nil)
+	jobRepo := &mockJobRepository{
+		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+			return jobs, nil
+		},
+	}
+	return NewMatchService(userRepo, skillRepo, newJobSkillTaxonomy(), jobRepo)
+}
+
+func TestMatchService_MatchJobs_RanksBySkillsAndProfile(t *testing.T) {
+	profile := &models.UserProfile{Seniority: "Senior", PreferredFields: []string{"Engineering"}, BrazilianFriendly: true}
+	skills := []models.SkillEntry{
+		{Name: "golang", Proficiency: enums.ProficiencyExpert},
+		{Name: "K8s", Proficiency: enums.ProficiencyBeginner},
+	}
+	service := newMatchTestService(profile, skills, []models.Job{
+		{ID: "partial", Skills: []string{"Go", "PostgreSQL"}, SeniorityLevel: "Junior"},
+		{ID: "full", Skills: []string{"Go", "Kubernetes"}, SeniorityLevel: "senior", Field: "Engineering",
+			IsBrazilianFriendly: models.BrazilianFriendly{IsFriendly: true}},
+		{ID: "unrelated", Skills: []string{"PostgreSQL"}, SeniorityLevel: "Senior"},
+		{ID: "untagged", SeniorityLevel: "Senior"},
+	})
+
+	matches, err := service.MatchJobs(context.Background(), "testuser", 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "full", matches[0].Job.ID)
+	// Expert Go counts fully and beginner Kubernetes 0.625 of its share: 70*1.625/2 ≈ 57, plus 25.
+	assert.Equal(t, 82, matches[0].Score)
+	assert.Equal(t, []string{"Go", "Kubernetes"}, matches[0].MatchedSkills)
+	assert.Empty(t, matches[0].MissingSkills)
+	assert.Equal(t, "partial", matches[1].Job.ID)
+	assert.Equal(t, 35, matches[1].Score)
+	assert.Equal(t, []string{"PostgreSQL"}, matches[1].MissingSkills)
+}
+
+func TestMatchService_MatchJobs_Limit(t *testing.T) {
+	skills := []models.SkillEntry{{Name: "Go"}}
+	service := newMatchTestService(nil, skills, []models.Job{
+		{ID: "a", Skills: []string{"Go"}}, {ID: "b", Skills: []string{"Go", "Kubernetes"}},
+	})
+
+	matches, err := service.MatchJobs(context.Background(), "testuser", 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "a", matches[0].Job.ID)
+}
+
+func TestMatchService_MatchJobs_NoSkills(t *testing.T) {
+	service := newMatchTestService(nil, nil, []models.Job{{ID: "a", Skills: []string{"Go"}}})
+
+	matches, err := service.MatchJobs(context.Background(), "testuser", 0)
+
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestMatchService_MatchJobs_UserNotFound(t *testing.T) {
+	service := newMatchTestService(nil, nil, nil)
+
+	_, err := service.MatchJobs(context.Background(), "ghost", 0)
+
+	assert.EqualError(t, err, "user not found")
+}
+
+func TestMatchService_SkillGaps(t *testing.T) {
+	profile := &models.UserProfile{PreferredFields: []string{"Engineering"}, Seniority: "Senior"}
+	skills := []models.SkillEntry{{Name: "Go"}}
+	service := newMatchTestServiceWithRole(enums.Premium, profile, skills, []models.Job{
+		{ID: "covered", Field: "Engineering", SeniorityLevel: "Senior", Skills: []string{"Go"}},
+		{ID: "one-missing", Field: "engineering", SeniorityLevel: "Senior", Skills: []string{"Go", "Kubernetes"}},
+		{ID: "two-missing", Field: "Engineering", SeniorityLevel: "Senior", Skills: []string{"Kubernetes", "PostgreSQL"}},
+		{ID: "other-field", Field: "Design", SeniorityLevel: "Senior", Skills: []string{"PostgreSQL"}},
+		{ID: "other-level", Field: "Engineering", SeniorityLevel: "Junior", Skills: []string{"PostgreSQL"}},
+		{ID: "untagged", Field: "Engineering", SeniorityLevel: "Senior"},
+	})
+
+	report, err := service.SkillGaps(context.Background(), "testuser", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.JobsInScope)
+	assert.Equal(t, 1, report.CoveredJobs)
+	assert.Equal(t, []models.SkillGap{
+		{Name: "Kubernetes", Demand: 2, Unlocks: 1},
+		{Name: "PostgreSQL", Demand: 1},
+	}, report.Gaps)
+}
+
+func TestMatchService_SkillGaps_RequiresPremium(t *testing.T) {
+	service := newMatchTestService(nil, []models.SkillEntry{{Name: "Go"}}, nil)
+
+	_, err := service.SkillGaps(context.Background(), "testuser", 0)
+
+	var forbidden *models.ForbiddenError
+	assert.ErrorAs(t, err, &forbidden)
+}
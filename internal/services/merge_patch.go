@@ -0,0 +1,25 @@
+package services
+
+// mergePatch applies a JSON Merge Patch (RFC 7396) to a decoded JSON document: objects merge
+// recursively, null removes a member and any other value replaces the target.
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	result := map[string]any{}
+	if targetObject, ok := target.(map[string]any); ok {
+		for key, value := range targetObject {
+			result[key] = value
+		}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergePatch(result[key], value)
+	}
+	return result
+}
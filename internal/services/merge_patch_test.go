@@ -0,0 +1,47 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Examples from RFC 7396, appendix A.
+func TestMergePatch_RFCExamples(t *testing.T) {
+	tests := []struct {
+		target   any
+		patch    any
+		expected any
+	}{
+		{map[string]any{"a": "b"}, map[string]any{"a": "c"}, map[string]any{"a": "c"}},
+		{map[string]any{"a": "b"}, map[string]any{"b": "c"}, map[string]any{"a": "b", "b": "c"}},
+		{map[string]any{"a": "b"}, map[string]any{"a": nil}, map[string]any{}},
+		{map[string]any{"a": "b", "b": "c"}, map[string]any{"a": nil}, map[string]any{"b": "c"}},
+		{map[string]any{"a": []any{"b"}}, map[string]any{"a": "c"}, map[string]any{"a": "c"}},
+		{map[string]any{"a": "c"}, map[string]any{"a": []any{"b"}}, map[string]any{"a": []any{"b"}}},
+		{map[string]any{"a": map[string]any{"b": "c"}}, map[string]any{"a": map[string]any{"b": "d", "c": nil}}, map[string]any{"a": map[string]any{"b": "d"}}},
+		{map[string]any{"a": []any{map[string]any{"b": "c"}}}, map[string]any{"a": []any{float64(1)}}, map[string]any{"a": []any{float64(1)}}},
+		{[]any{"a", "b"}, []any{"c", "d"}, []any{"c", "d"}},
+		{map[string]any{"a": "b"}, []any{"c"}, []any{"c"}},
+		{map[string]any{"a": "foo"}, nil, nil},
+		{map[string]any{"a": "foo"}, "bar", "bar"},
+		{map[string]any{"e": nil}, map[string]any{"a": float64(1)}, map[string]any{"e": nil, "a": float64(1)}},
+		{[]any{float64(1), float64(2)}, map[string]any{"a": "b", "c": nil}, map[string]any{"a": "b"}},
+		{map[string]any{}, map[string]any{"a": map[string]any{"bb": map[string]any{"ccc": nil}}}, map[string]any{"a": map[string]any{"bb": map[string]any{}}}},
+	}
+
+	for i, tt := range tests {
+		if got := mergePatch(tt.target, tt.patch); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("case %d: expected %v, got %v", i, tt.expected, got)
+		}
+	}
+}
+
+func TestMergePatch_DoesNotMutateTarget(t *testing.T) {
+	target := map[string]any{"a": "b"}
+
+	mergePatch(target, map[string]any{"a": nil, "c": "d"})
+
+	if !reflect.DeepEqual(target, map[string]any{"a": "b"}) {
+		t.Errorf("Expected target to be left untouched, got %v", target)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultSalaryCurrency = "USD"
+
+// salaryRoundingStep is what reported pay is rounded to, so a figure never repeats the exact
+// amount of one posting.
+const salaryRoundingStep = 1000
+
+// SalaryInsights reports percentiles of the yearly pay of listed jobs matching the filter,
+// converted to currency. Everyone gets the overall figures; the breakdowns by field, seniority,
+// workplace type and Brazilian-friendliness are a PREMIUM feature. Only the categorical parts of
+// the filter apply: a free-text one such as skills could narrow the set down to a single company.
+func (s *insightsService) SalaryInsights(ctx context.Context, username string, filter models.JobFilter, currency string) (models.SalaryInsights, error) {
+	log.Printf("Service SalaryInsights called for username: %s, filter: %+v", username, filter)
+
+	filter = models.JobFilter{
+		Fields:            filter.Fields,
+		SeniorityLevels:   filter.SeniorityLevels,
+		WorkplaceTypes:    filter.WorkplaceTypes,
+		BrazilianFriendly: filter.BrazilianFriendly,
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = defaultSalaryCurrency
+	}
+	if _, known := s.salaryPolicy.ExchangeRates[currency]; !known {
+		return models.SalaryInsights{}, fmt.Errorf("invalid currency %q", currency)
+	}
+
+	detailed := false
+	if username != "" {
+		user, found, err := s.userRepository.FindByUsername(ctx, username)
+		if err != nil {
+			log.Printf("ERROR: Repository error in SalaryInsights for username %s: %v", username, err)
+			return models.SalaryInsights{}, err
+		}
+		if !found {
+			return models.SalaryInsights{}, errors.New("user not found")
+		}
+		detailed = user.Role == enums.Premium
+	}
+
+	jobs, err := s.jobRepository.FindByFilter(ctx, filter)
+	if err != nil {
+		log.Printf("ERROR: Repository error loading jobs for salary insights: %v", err)
+		return models.SalaryInsights{}, err
+	}
+
+	now := s.now()
+	overall := []float64{}
+	byField, bySeniority, byWorkplaceType, byBrazilianFriendly := newSalaryGrouper(), newSalaryGrouper(), newSalaryGrouper(), newSalaryGrouper()
+	for _, job := range jobs {
+		if !isListed(job, now) {
+			continue
+		}
+		compensation, ok := models.ParseCompensation(job.CompensationTierSummary)
+		if !ok {
+			continue
+		}
+		pay, ok := s.salaryPolicy.Convert(compensation.Midpoint(), compensation.Currency, currency)
+		if !ok {
+			continue
+		}
+		overall = append(overall, pay)
+		byField.add(job.Field, pay)
+		bySeniority.add(job.SeniorityLevel, pay)
+		byWorkplaceType.add(job.WorkplaceType, pay)
+		byBrazilianFriendly.add(strconv.FormatBool(job.IsBrazilianFriendly.IsFriendly), pay)
+	}
+
+	minSample := s.salaryPolicy.MinSampleSize
+	insights := models.SalaryInsights{
+		Currency:      currency,
+		MinSampleSize: minSample,
+		Detailed:      detailed,
+	}
+	if len(overall) >= minSample && len(overall) > 0 {
+		percentiles := salaryPercentiles(overall, minSample)
+		insights.Overall = &percentiles
+	}
+	if detailed {
+		insights.ByField = byField.groups(minSample)
+		insights.BySeniority = bySeniority.groups(minSample)
+		insights.ByWorkplaceType = byWorkplaceType.groups(minSample)
+		insights.ByBrazilianFriendly = byBrazilianFriendly.groups(minSample)
+	}
+	return insights, nil
+}
+
+// salaryGrouper collects pay per value case-insensitively, reporting each under its first spelling.
+type salaryGrouper struct {
+	names map[string]string
+	pay   map[string][]float64
+}
+
+func newSalaryGrouper() *salaryGrouper {
+	return &salaryGrouper{names: map[string]string{}, pay: map[string][]float64{}}
+}
+
+func (g *salaryGrouper) add(value string, pay float64) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	key := strings.ToLower(value)
+	if _, seen := g.names[key]; !seen {
+		g.names[key] = value
+	}
+	g.pay[key] = append(g.pay[key], pay)
+}
+
+// groups returns the groups with at least minSample jobs, largest first.
+func (g *salaryGrouper) groups(minSample int) []models.SalaryGroup {
+	groups := []models.SalaryGroup{}
+	for key, pay := range g.pay {
+		if len(pay) < minSample {
+			continue
+		}
+		groups = append(groups, models.SalaryGroup{Value: g.names[key], SalaryPercentiles: salaryPercentiles(pay, minSample)})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].SampleSize != groups[j].SampleSize {
+			return groups[i].SampleSize > groups[j].SampleSize
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
+
+// salaryPercentiles reports the median of pay, plus each other percentile that has at least
+// minSample jobs beyond it. The tails of a small group would otherwise give away its extremes.
+func salaryPercentiles(pay []float64, minSample int) models.SalaryPercentiles {
+	sorted := sortedCopy(pay)
+	return models.SalaryPercentiles{
+		SampleSize: len(sorted),
+		P25:        tailPercentile(sorted, 25, minSample),
+		P50:        roundSalary(percentile(sorted, 0.50)),
+		P75:        tailPercentile(sorted, 75, minSample),
+		P90:        tailPercentile(sorted, 90, minSample),
+	}
+}
+
+// tailPercentile returns the given whole percentile of sorted, or nil when fewer than minSample
+// jobs lie beyond it.
+func tailPercentile(sorted []float64, percent, minSample int) *float64 {
+	if min(percent, 100-percent)*len(sorted) < minSample*100 {
+		return nil
+	}
+	value := roundSalary(percentile(sorted, float64(percent)/100))
+	return &value
+}
+
+func roundSalary(pay float64) float64 {
+	return math.Round(pay/salaryRoundingStep) * salaryRoundingStep
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"sort"
+	"time"
+)
+
+type SavedSearchService interface {
+	ListSearches(ctx context.Context, username string) ([]models.SavedSearch, error)
+	GetSearch(ctx context.Context, username, id string) (models.SavedSearch, error)
+	CreateSearch(ctx context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error)
+	UpdateSearch(ctx context.Context, username, id string, request models.SavedSearchRequest) (models.SavedSearch, error)
+	DeleteSearch(ctx context.Context, username, id string) error
+	RunSearch(ctx context.Context, username, id string) (models.SavedSearchResults, error)
+}
+
+type savedSearchService struct {
+	searchRepository repositories.SavedSearchRepository
+	userRepository   repositories.UserRepository
+	jobService       JobService
+	policy           models.SavedSearchPolicy
+	now              func() time.Time
+}
+
+func NewSavedSearchService(searchRepository repositories.SavedSearchRepository, userRepository repositories.UserRepository, jobService JobService, policy models.SavedSearchPolicy) SavedSearchService {
+	return &savedSearchService{
+		searchRepository: searchRepository,
+		userRepository:   userRepository,
+		jobService:       jobService,
+		policy:           policy,
+		now:              time.Now,
+	}
+}
+
+func (s *savedSearchService) ListSearches(ctx context.Context, username string) ([]models.SavedSearch, error) {
+	log.Printf("Service ListSearches called for username: %s", username)
+
+	searches, err := s.searchRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error in ListSearches for username %s: %v", username, err)
+		return nil, err
+	}
+	return searches, nil
+}
+
+func (s *savedSearchService) GetSearch(ctx context.Context, username, id string) (models.SavedSearch, error) {
+	log.Printf("Service GetSearch called for saved search %s of username: %s", id, username)
+
+	search, found, err := s.searchRepository.FindByID(ctx, username, id)
+	if err != nil {
+		log.Printf("ERROR: Repository error in GetSearch for saved search %s: %v", id, err)
+		return models.SavedSearch{}, err
+	}
+	if !found {
+		return models.SavedSearch{}, errors.New("saved search not found")
+	}
+	return search, nil
+}
+
+// CreateSearch stores a new search unless the user already holds as many as their role allows.
+func (s *savedSearchService) CreateSearch(ctx context.Context, username string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+	log.Printf("Service CreateSearch called for username: %s", username)
+
+	request = request.Normalized()
+	if fieldErrors := request.FieldErrors(); len(fieldErrors) > 0 {
+		log.Printf("Validation error in CreateSearch for username %s: %v", username, fieldErrors)
+		return models.SavedSearch{}, &models.ValidationError{Fields: fieldErrors}
+	}
+
+	user, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding user %s in CreateSearch: %v", username, err)
+		return models.SavedSearch{}, err
+	}
+	if !found {
+		return models.SavedSearch{}, errors.New("user not found")
+	}
+
+	limit := s.policy.Limit(user.Role)
+	reserved, err := s.searchRepository.ReserveSlot(ctx, user.Username, limit)
+	if err != nil {
+		log.Printf("ERROR: Repository error reserving a saved search slot for %s: %v", username, err)
+		return models.SavedSearch{}, err
+	}
+	if !reserved {
+		log.Printf("User %s with role %s reached the limit of %d saved searches", username, user.Role, limit)
+		return models.SavedSearch{}, &models.ForbiddenError{Reason: fmt.Sprintf("%s users may keep at most %d saved searches", user.Role, limit)}
+	}
+
+	search, err := s.searchRepository.Create(ctx, models.SavedSearch{
+		Username:  user.Username,
+		Name:      request.Name,
+		Filter:    request.Filter,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		log.Printf("ERROR: Repository error in CreateSearch for username %s: %v", username, err)
+		if releaseErr := s.searchRepository.ReleaseSlot(ctx, user.Username); releaseErr != nil {
+			log.Printf("ERROR: Failed to release saved search slot of %s: %v", username, releaseErr)
+		}
+		return models.SavedSearch{}, err
+	}
+
+	log.Printf("Created saved search %s for username: %s", search.ID.Hex(), username)
+	return search, nil
+}
+
+func (s *savedSearchService) UpdateSearch(ctx context.Context, username, id string, request models.SavedSearchRequest) (models.SavedSearch, error) {
+	log.Printf("Service UpdateSearch called for saved search %s of username: %s", id, username)
+
+	request = request.Normalized()
+	if fieldErrors := request.FieldErrors(); len(fieldErrors) > 0 {
+		log.Printf("Validation error in UpdateSearch for saved search %s: %v", id, fieldErrors)
+		return models.SavedSearch{}, &models.ValidationError{Fields: fieldErrors}
+	}
+
+	found, err := s.searchRepository.Update(ctx, username, id, request)
+	if err != nil {
+		log.Printf("ERROR: Repository error in UpdateSearch for saved search %s: %v", id, err)
+		return models.SavedSearch{}, err
+	}
+	if !found {
+		return models.SavedSearch{}, errors.New("saved search not found")
+	}
+	return s.GetSearch(ctx, username, id)
+}
+
+func (s *savedSearchService) DeleteSearch(ctx context.Context, username, id string) error {
+	log.Printf("Service DeleteSearch called for saved search %s of username: %s", id, username)
+
+	found, err := s.searchRepository.Delete(ctx, username, id)
+	if err != nil {
+		log.Printf("ERROR: Repository error in DeleteSearch for saved search %s: %v", id, err)
+		return err
+	}
+	if !found {
+		return errors.New("saved search not found")
+	}
+	return nil
+}
+
+// RunSearch executes the search and records the run. Jobs first seen after the previous run
+// are flagged as new and listed first; on the first run every job is new.
+func (s *savedSearchService) RunSearch(ctx context.Context, username, id string) (models.SavedSearchResults, error) {
+	log.Printf("Service RunSearch called for saved search %s of username: %s", id, username)
+
+	search, err := s.GetSearch(ctx, username, id)
+	if err != nil {
+		return models.SavedSearchResults{}, err
+	}
+
+	// Profile defaults are off: the saved filter is exactly what the user asked for.
+	jobs, err := s.jobService.FindJobs(ctx, username, search.Filter, false)
+	if err != nil {
+		log.Printf("ERROR: Job search failed for saved search %s: %v", id, err)
+		return models.SavedSearchResults{}, err
+	}
+
+	results := models.SavedSearchResults{Search: search, Jobs: make([]models.SavedSearchJob, 0, len(jobs))}
+	for _, job := range jobs {
+		isNew := search.LastRunAt == nil || job.FirstSeenAt.After(*search.LastRunAt)
+		if isNew {
+			results.NewCount++
+		}
+		results.Jobs = append(results.Jobs, models.SavedSearchJob{Job: job, New: isNew})
+	}
+	sort.SliceStable(results.Jobs, func(i, j int) bool {
+		a, b := results.Jobs[i], results.Jobs[j]
+		if a.New != b.New {
+			return a.New
+		}
+		return a.FirstSeenAt.After(b.FirstSeenAt)
+	})
+
+	ranAt := s.now()
+	if err := s.searchRepository.MarkRun(ctx, username, id, ranAt); err != nil {
+		log.Printf("ERROR: Failed to record run of saved search %s: %v", id, err)
+		return models.SavedSearchResults{}, err
+	}
+	results.Search.LastRunAt = &ranAt
+
+	log.Printf("Saved search %s returned %d jobs, %d new", id, len(results.Jobs), results.NewCount)
+	return results, nil
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeSavedSearchRepository struct {
+	searches map[string]models.SavedSearch
+	slots    map[string]int
+}
+
+func newFakeSavedSearchRepository() *fakeSavedSearchRepository {
+	return &fakeSavedSearchRepository{searches: map[string]models.SavedSearch{}, slots: map[string]int{}}
+}
+
+func (f *fakeSavedSearchRepository) Name() string { return "saved_searches" }
+
+func (f *fakeSavedSearchRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	return f.FindByUsername(ctx, username)
+}
+
+func (f *fakeSavedSearchRepository) DeleteByUsername(_ context.Context, username string) error {
+	for id, search := range f.searches {
+		if strings.EqualFold(search.Username, username) {
+			delete(f.searches, id)
+		}
+	}
+	return nil
+}
+
+func (f *fakeSavedSearchRepository) RenameUsername(_ context.Context, oldUsername, newUsername string) error {
+	for id, search := range f.searches {
+		if strings.EqualFold(search.Username, oldUsername) {
+			search.Username = newUsername
+			f.searches[id] = search
+		}
+	}
+	return nil
+}
+
+func (f *fakeSavedSearchRepository) Create(_ context.Context, search models.SavedSearch) (models.SavedSearch, error) {
+	for _, other := range f.searches {
+		if strings.EqualFold(other.Username, search.Username) && strings.EqualFold(other.Name, search.Name) {
+			return models.SavedSearch{}, &models.ConflictError{Field: "name", Value: search.Name}
+		}
+	}
+	search.ID = primitive.NewObjectID()
+	f.searches[search.ID.Hex()] = search
+	return search, nil
+}
+
+func (f *fakeSavedSearchRepository) FindAll(_ context.Context) ([]models.SavedSearch, error) {
+	searches := []models.SavedSearch{}
+	for _, search := range f.searches {
+		searches = append(searches, search)
+	}
+	return searches, nil
+}
+
+func (f *fakeSavedSearchRepository) FindByUsername(_ context.Context, username string) ([]models.SavedSearch, error) {
+	searches := []models.SavedSearch{}
+	for _, search := range f.searches {
+		if strings.EqualFold(search.Username, username) {
+			searches = append(searches, search)
+		}
+	}
+	return searches, nil
+}
+
+func (f *fakeSavedSearchRepository) FindByID(_ context.Context, username, id string) (models.SavedSearch, bool, error) {
+	search, found := f.searches[id]
+	if !found || !strings.EqualFold(search.Username, username) {
+		return models.SavedSearch{}, false, nil
+	}
+	return search, true, nil
+}
+
+func (f *fakeSavedSearchRepository) CountByUsername(ctx context.Context, username string) (int64, error) {
+	searches, _ := f.FindByUsername(ctx, username)
+	return int64(len(searches)), nil
+}
+
+func (f *fakeSavedSearchRepository) ReserveSlot(_ context.Context, username string, limit int) (bool, error) {
+	key := strings.ToLower(username)
+	if f.slots[key] >= limit {
+		return false, nil
+	}
+	f.slots[key]++
+	return true, nil
+}
+
+func (f *fakeSavedSearchRepository) ReleaseSlot(_ context.Context, username string) error {
+	f.slots[strings.ToLower(username)]--
+	return nil
+}
+
+func (f *fakeSavedSearchRepository) Update(ctx context.Context, username, id string, request models.SavedSearchRequest) (bool, error) {
+	search, found, _ := f.FindByID(ctx, username, id)
+	if !found {
+		return false, nil
+	}
+	search.Name, search.Filter = request.Name, request.Filter
+	f.searches[id] = search
+	return true, nil
+}
+
+func (f *fakeSavedSearchRepository) MarkRun(ctx context.Context, username, id string, at time.Time) error {
+	search, found, _ := f.FindByID(ctx, username, id)
+	if !found {
+		return errors.New("saved search not found")
+	}
+	search.LastRunAt = &at
+	f.searches[id] = search
+	return nil
+}
+
+func (f *fakeSavedSearchRepository) Delete(ctx context.Context, username, id string) (bool, error) {
+	if _, found, _ := f.FindByID(ctx, username, id); !found {
+		return false, nil
+	}
+	delete(f.searches, id)
+	return true, f.ReleaseSlot(ctx, username)
+}
+
+func newSavedSearchTestService(role enums.RoleEnum, jobRepo *mockJobRepository) (*savedSearchService, *fakeSavedSearchRepository) {
+	repo := newFakeSavedSearchRepository()
+	userRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Role: role}, true, nil
+		},
+	}
+	jobService := NewJobService(jobRepo, userRepo, newJobSkillTaxonomy())
+	service := NewSavedSearchService(repo, userRepo, jobService, models.SavedSearchPolicy{FreeLimit: 1, PremiumLimit: 2}).(*savedSearchService)
+	return service, repo
+}
+
+func TestSavedSearchService_CreateSearch_NormalizesAndValidates(t *testing.T) {
+	service, _ := newSavedSearchTestService(enums.Free, &mockJobRepository{})
+
+	search, err := service.CreateSearch(context.Background(), "testuser", models.SavedSearchRequest{
+		Name:   "  Go remote ",
+		Filter: models.JobFilter{Skills: []string{" Go ", "go"}, WorkplaceTypes: []string{"Remote"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Go remote", search.Name)
+	assert.Equal(t, []string{"Go"}, search.Filter.Skills)
+	assert.False(t, search.CreatedAt.IsZero())
+
+	_, err = service.CreateSearch(context.Background(), "testuser", models.SavedSearchRequest{Name: " "})
+	var validation *models.ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Len(t, validation.Fields, 2)
+}
+
+func TestSavedSearchService_CreateSearch_RoleLimits(t *testing.T) {
+	request := func(name string) models.SavedSearchRequest {
+		return models.SavedSearchRequest{Name: name, Filter: models.JobFilter{Fields: []string{"Engineering"}}}
+	}
+
+	free, _ := newSavedSearchTestService(enums.Free, &mockJobRepository{})
+	_, err := free.CreateSearch(context.Background(), "testuser", request("first"))
+	assert.NoError(t, err)
+	_, err = free.CreateSearch(context.Background(), "testuser", request("second"))
+	var forbidden *models.ForbiddenError
+	assert.ErrorAs(t, err, &forbidden)
+	assert.Contains(t, forbidden.Reason, "FREE users may keep at most 1")
+
+	// A create that fails gives its slot back.
+	free, repo := newSavedSearchTestService(enums.Free, &mockJobRepository{})
+	repo.searches["taken"] = models.SavedSearch{Username: "testuser", Name: "first"}
+	_, err = free.CreateSearch(context.Background(), "testuser", request("first"))
+	var conflict *models.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 0, repo.slots["testuser"])
+
+	premium, _ := newSavedSearchTestService(enums.Premium, &mockJobRepository{})
+	_, err = premium.CreateSearch(context.Background(), "testuser", request("first"))
+	assert.NoError(t, err)
+	_, err = premium.CreateSearch(context.Background(), "testuser", request("second"))
+	assert.NoError(t, err)
+}
+
+func TestSavedSearchService_UpdateAndDelete_OwnerScoped(t *testing.T) {
+	service, _ := newSavedSearchTestService(enums.Free, &mockJobRepository{})
+	search, err := service.CreateSearch(context.Background(), "testuser", models.SavedSearchRequest{
+		Name: "Go", Filter: models.JobFilter{Skills: []string{"Go"}},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.UpdateSearch(context.Background(), "otheruser", search.ID.Hex(), models.SavedSearchRequest{
+		Name: "Mine now", Filter: models.JobFilter{Skills: []string{"Go"}},
+	})
+	assert.EqualError(t, err, "saved search not found")
+
+	updated, err := service.UpdateSearch(context.Background(), "testuser", search.ID.Hex(), models.SavedSearchRequest{
+		Name: "Go and K8s", Filter: models.JobFilter{Skills: []string{"Go", "k8s"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Go and K8s", updated.Name)
+
+	assert.EqualError(t, service.DeleteSearch(context.Background(), "otheruser", search.ID.Hex()), "saved search not found")
+	assert.NoError(t, service.DeleteSearch(context.Background(), "testuser", search.ID.Hex()))
+}
+
+func TestSavedSearchService_RunSearch_FlagsNewJobs(t *testing.T) {
+	lastRun := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	var gotFilter models.JobFilter
+	jobRepo := &mockJobRepository{
+		findByFilterFunc: func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+			gotFilter = filter
+			return []models.Job{
+				{ID: "old", FirstSeenAt: lastRun.Add(-time.Hour)},
+				{ID: "new", FirstSeenAt: lastRun.Add(time.Hour)},
+				{ID: "legacy"},
+			}, nil
+		},
+	}
+	service, repo := newSavedSearchTestService(enums.Free, jobRepo)
+	now := lastRun.Add(24 * time.Hour)
+	service.now = func() time.Time { return now }
+
+	search, err := service.CreateSearch(context.Background(), "testuser", models.SavedSearchRequest{
+		Name: "Go", Filter: models.JobFilter{Skills: []string{"golang"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.MarkRun(context.Background(), "testuser", search.ID.Hex(), lastRun))
+
+	results, err := service.RunSearch(context.Background(), "testuser", search.ID.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Go"}, gotFilter.Skills)
+	assert.Equal(t, 1, results.NewCount)
+	assert.Len(t, results.Jobs, 3)
+	assert.Equal(t, "new", results.Jobs[0].ID)
+	assert.True(t, results.Jobs[0].New)
+	assert.False(t, results.Jobs[1].New)
+	assert.Equal(t, now, *results.Search.LastRunAt)
+
+	stored, _, _ := repo.FindByID(context.Background(), "testuser", search.ID.Hex())
+	assert.Equal(t, now, *stored.LastRunAt)
+}
+
+func TestSavedSearchService_RunSearch_FirstRunMarksAllNew(t *testing.T) {
+	jobRepo := &mockJobRepository{
+		findByFilterFunc: func(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+			return []models.Job{{ID: "a"}, {ID: "b"}}, nil
+		},
+	}
+	service, _ := newSavedSearchTestService(enums.Free, jobRepo)
+	search, err := service.CreateSearch(context.Background(), "testuser", models.SavedSearchRequest{
+		Name: "Remote", Filter: models.JobFilter{WorkplaceTypes: []string{"Remote"}},
+	})
+	assert.NoError(t, err)
+
+	results, err := service.RunSearch(context.Background(), "testuser", search.ID.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, results.NewCount)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"log"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultSkillGapLimit = 10
+	maxSkillGapLimit     = 50
+)
+
+// SkillGaps lists the skills most demanded by tagged jobs in the user's preferred fields and
+// seniority that the user lacks. Without those preferences every tagged job is in scope.
+// Skill-gap analysis is a PREMIUM feature.
+func (s *matchService) SkillGaps(ctx context.Context, username string, limit int) (models.SkillGapReport, error) {
+	log.Printf("Service SkillGaps called for username: %s", username)
+
+	if limit <= 0 {
+		limit = defaultSkillGapLimit
+	}
+	if limit > maxSkillGapLimit {
+		limit = maxSkillGapLimit
+	}
+
+	user, userSkills, err := s.loadCandidate(ctx, username)
+	if err != nil {
+		return models.SkillGapReport{}, err
+	}
+	if user.Role != enums.Premium {
+		log.Printf("User %s with role %s denied skill-gap analysis", username, user.Role)
+		return models.SkillGapReport{}, &models.ForbiddenError{Reason: "skill-gap analysis is available to PREMIUM users"}
+	}
+	profile := userProfile(user)
+
+	jobs, err := s.jobRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing jobs in SkillGaps: %v", err)
+		return models.SkillGapReport{}, err
+	}
+
+	report := models.SkillGapReport{Fields: profile.PreferredFields, Seniority: profile.Seniority, Gaps: []models.SkillGap{}}
+	gaps := map[string]*models.SkillGap{}
+	for _, job := range jobs {
+		if len(job.Skills) == 0 || !inSkillGapScope(job, profile) {
+			continue
+		}
+		report.JobsInScope++
+
+		var missing []string
+		for _, name := range job.Skills {
+			if _, has := userSkills[name]; !has {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) == 0 {
+			report.CoveredJobs++
+			continue
+		}
+		for _, name := range missing {
+			gap, found := gaps[name]
+			if !found {
+				gap = &models.SkillGap{Name: name}
+				gaps[name] = gap
+			}
+			gap.Demand++
+			if len(missing) == 1 {
+				gap.Unlocks++
+			}
+		}
+	}
+
+	for _, gap := range gaps {
+		report.Gaps = append(report.Gaps, *gap)
+	}
+	sort.Slice(report.Gaps, func(i, j int) bool {
+		a, b := report.Gaps[i], report.Gaps[j]
+		if a.Demand != b.Demand {
+			return a.Demand > b.Demand
+		}
+		if a.Unlocks != b.Unlocks {
+			return a.Unlocks > b.Unlocks
+		}
+		return a.Name < b.Name
+	})
+	if len(report.Gaps) > limit {
+		report.Gaps = report.Gaps[:limit]
+	}
+
+	log.Printf("Returning %d skill gaps over %d jobs for username: %s", len(report.Gaps), report.JobsInScope, username)
+	return report, nil
+}
+
+func inSkillGapScope(job models.Job, profile models.UserProfile) bool {
+	if len(profile.PreferredFields) > 0 && !containsFold(profile.PreferredFields, job.Field) {
+		return false
+	}
+	return profile.Seniority == "" || strings.EqualFold(profile.Seniority, job.SeniorityLevel)
+}
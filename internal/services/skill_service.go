@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
 	"jboard-go-crud/internal/repositories"
 	"log"
+	"strings"
+	"sync"
+	"time"
 )
 
 type SkillService interface {
@@ -13,15 +17,34 @@ type SkillService interface {
 	AddSkill(ctx context.Context, skillRequest models.SkillRequest) error
 	RemoveSkill(ctx context.Context, skillRequest models.SkillRequest) error
 	DeleteUserSkills(ctx context.Context, username string) error
+	GetCatalog(ctx context.Context, category string) ([]models.CanonicalSkill, error)
+	UpsertCatalogSkill(ctx context.Context, skill models.CanonicalSkill) (models.CanonicalSkill, error)
+	MergeSkills(ctx context.Context, request models.SkillMergeRequest) (models.SkillMergeResult, error)
+	SuggestSkills(ctx context.Context, prefix string, limit int) ([]models.SkillSuggestion, error)
+	ReplaceUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error)
+	AddUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error)
 }
 
 type skillService struct {
-	skillRepository repositories.SkillRepository
+	skillRepository    repositories.SkillRepository
+	taxonomyRepository repositories.SkillTaxonomyRepository
+	jobRepository      repositories.JobRepository
+	userRepository     repositories.UserRepository
+	txManager          repositories.TransactionManager
+	now                func() time.Time
+
+	popularityMu sync.Mutex
+	popularity   *skillPopularity
 }
 
-func NewSkillService(skillRepository repositories.SkillRepository) SkillService {
+func NewSkillService(skillRepository repositories.SkillRepository, taxonomyRepository repositories.SkillTaxonomyRepository, jobRepository repositories.JobRepository, userRepository repositories.UserRepository, txManager repositories.TransactionManager) SkillService {
 	return &skillService{
-		skillRepository: skillRepository,
+		skillRepository:    skillRepository,
+		taxonomyRepository: taxonomyRepository,
+		jobRepository:      jobRepository,
+		userRepository:     userRepository,
+		txManager:          txManager,
+		now:                time.Now,
 	}
 }
 
@@ -52,10 +75,20 @@ func (s *skillService) GetAllSkills(ctx context.Context, username string) (model
 func (s *skillService) AddSkill(ctx context.Context, skillRequest models.SkillRequest) error {
 	log.Printf("Service AddSkill called for username: %s, skill: %s", skillRequest.Username, skillRequest.Skill)
 
+	skillRequest = skillRequest.Normalized()
 	if skillRequest.Username == "" || skillRequest.Skill == "" {
 		log.Printf("Validation error in AddSkill: username and skill are required")
 		return errors.New("username and skill are required")
 	}
+	if err := skillRequest.Validate(s.now().Year()); err != nil {
+		log.Printf("Validation error in AddSkill: %v", err)
+		return err
+	}
+	canonical, err := s.canonicalName(ctx, skillRequest.Skill)
+	if err != nil {
+		return err
+	}
+	skillRequest.Skill = canonical
 	log.Printf("Validation passed for AddSkill username: %s, skill: %s", skillRequest.Username, skillRequest.Skill)
 
 	// Verifica se o usuário já tem skills cadastradas
@@ -68,6 +101,12 @@ func (s *skillService) AddSkill(ctx context.Context, skillRequest models.SkillRe
 	if !exists {
 		log.Printf("User does not exist, creating new skills document for username: %s", skillRequest.Username)
 		err := s.skillRepository.Create(ctx, skillRequest)
+		var conflict *models.ConflictError
+		if errors.As(err, &conflict) {
+			// A concurrent request created the document first; add to it instead.
+			log.Printf("Skills document created concurrently for username: %s, adding skill instead", skillRequest.Username)
+			err = s.skillRepository.AddSkill(ctx, skillRequest)
+		}
 		if err != nil {
 			log.Printf("ERROR: Failed to create skills for username %s: %v", skillRequest.Username, err)
 		} else {
@@ -95,6 +134,12 @@ func (s *skillService) RemoveSkill(ctx context.Context, skillRequest models.Skil
 	}
 	log.Printf("Validation passed for RemoveSkill username: %s, skill: %s", skillRequest.Username, skillRequest.Skill)
 
+	canonical, err := s.canonicalName(ctx, skillRequest.Skill)
+	if err != nil {
+		return err
+	}
+	skillRequest.Skill = canonical
+
 	// Verifica se o usuário existe
 	_, exists, err := s.skillRepository.FindByUsername(ctx, skillRequest.Username)
 	if err != nil {
@@ -147,3 +192,222 @@ func (s *skillService) DeleteUserSkills(ctx context.Context, username string) er
 	}
 	return err
 }
+
+func (s *skillService) GetCatalog(ctx context.Context, category string) ([]models.CanonicalSkill, error) {
+	log.Printf("Service GetCatalog called for category: '%s'", category)
+
+	skillCategory := enums.SkillCategoryEnum(strings.ToUpper(strings.TrimSpace(category)))
+	if skillCategory != "" && !skillCategory.IsValid() {
+		log.Printf("Validation error in GetCatalog: invalid category %s", category)
+		return nil, errors.New("invalid category: must be one of LANGUAGE, FRAMEWORK, CLOUD, DATABASE")
+	}
+
+	skills, err := s.taxonomyRepository.FindAll(ctx, skillCategory)
+	if err != nil {
+		log.Printf("ERROR: Repository error in GetCatalog: %v", err)
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (s *skillService) UpsertCatalogSkill(ctx context.Context, skill models.CanonicalSkill) (models.CanonicalSkill, error) {
+	log.Printf("Service UpsertCatalogSkill called for name: %s", skill.Name)
+
+	skill = skill.Normalized()
+	if err := skill.Validate(); err != nil {
+		log.Printf("Validation error in UpsertCatalogSkill: %v", err)
+		return models.CanonicalSkill{}, err
+	}
+
+	if err := s.taxonomyRepository.Upsert(ctx, skill); err != nil {
+		log.Printf("ERROR: Repository error in UpsertCatalogSkill for %s: %v", skill.Key, err)
+		return models.CanonicalSkill{}, err
+	}
+
+	log.Printf("Successfully saved canonical skill: %s", skill.Key)
+	return skill, nil
+}
+
+// MergeSkills folds the source spellings into the target canonical skill: catalog entries of
+// the sources are absorbed as aliases and every user's skill list is rewritten to the target
+// name. Each step is idempotent, so a merge interrupted halfway can simply be repeated.
+func (s *skillService) MergeSkills(ctx context.Context, request models.SkillMergeRequest) (models.SkillMergeResult, error) {
+	log.Printf("Service MergeSkills called for target: %s, sources: %v", request.Target, request.Sources)
+
+	if strings.TrimSpace(request.Target) == "" {
+		return models.SkillMergeResult{}, errors.New("target is required")
+	}
+	var sources []string
+	for _, source := range request.Sources {
+		if models.SkillKey(source) != "" {
+			sources = append(sources, strings.TrimSpace(source))
+		}
+	}
+	if len(sources) == 0 {
+		return models.SkillMergeResult{}, errors.New("sources are required")
+	}
+
+	target, found, err := s.taxonomyRepository.FindByAlias(ctx, request.Target)
+	if err != nil {
+		log.Printf("ERROR: Repository error finding merge target %s: %v", request.Target, err)
+		return models.SkillMergeResult{}, err
+	}
+	if !found {
+		log.Printf("Merge target %s is not in the skill catalog", request.Target)
+		return models.SkillMergeResult{}, errors.New("canonical skill not found")
+	}
+
+	// Absorbed entries are deleted before the target is saved so that their aliases are free.
+	// Both run in one transaction; where transactions are unavailable, the absorbed entries are
+	// restored when saving the target fails so the catalog never loses them.
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		merged, absorbed, err := s.absorbSkills(ctx, target, sources)
+		if err != nil {
+			return err
+		}
+		if err := s.taxonomyRepository.Upsert(ctx, merged); err != nil {
+			log.Printf("ERROR: Repository error saving merge target %s: %v", merged.Key, err)
+			s.restoreSkills(ctx, absorbed)
+			return err
+		}
+		target = merged
+		return nil
+	})
+	if err != nil {
+		return models.SkillMergeResult{}, err
+	}
+
+	updated, err := s.rewriteUserSkills(ctx, target)
+	if err != nil {
+		return models.SkillMergeResult{}, err
+	}
+
+	log.Printf("Successfully merged %v into %s, updated %d users", sources, target.Key, updated)
+	return models.SkillMergeResult{Target: target, UsersUpdated: updated}, nil
+}
+
+// absorbSkills deletes the catalog entries the sources resolve to and returns the target with
+// their aliases added, along with the deleted entries.
+func (s *skillService) absorbSkills(ctx context.Context, target models.CanonicalSkill, sources []string) (models.CanonicalSkill, []models.CanonicalSkill, error) {
+	aliases := append([]string{}, target.Aliases...)
+	ambiguous := append([]string{}, target.AmbiguousAliases...)
+	var absorbed []models.CanonicalSkill
+	for _, source := range sources {
+		entry, found, err := s.taxonomyRepository.FindByAlias(ctx, source)
+		if err != nil {
+			log.Printf("ERROR: Repository error finding merge source %s: %v", source, err)
+			s.restoreSkills(ctx, absorbed)
+			return models.CanonicalSkill{}, nil, err
+		}
+		if found && entry.Key != target.Key {
+			log.Printf("Absorbing canonical skill %s into %s", entry.Key, target.Key)
+			if err := s.taxonomyRepository.Delete(ctx, entry.Key); err != nil {
+				s.restoreSkills(ctx, absorbed)
+				return models.CanonicalSkill{}, nil, err
+			}
+			absorbed = append(absorbed, entry)
+			aliases = append(aliases, entry.Aliases...)
+			ambiguous = append(ambiguous, entry.AmbiguousAliases...)
+		}
+		aliases = append(aliases, source)
+	}
+	target.Aliases, target.AmbiguousAliases = aliases, ambiguous
+	return target.Normalized(), absorbed, nil
+}
+
+// restoreSkills puts back catalog entries deleted by a merge that could not be completed.
+func (s *skillService) restoreSkills(ctx context.Context, skills []models.CanonicalSkill) {
+	for _, skill := range skills {
+		if err := s.taxonomyRepository.Upsert(ctx, skill); err != nil {
+			log.Printf("ERROR: Failed to restore canonical skill %s after a failed merge: %v", skill.Key, err)
+		}
+	}
+}
+
+// rewriteUserSkills renames every entry matching one of the target aliases. Aliases are
+// compared by key, which no database query can do, so all skill documents are scanned.
+func (s *skillService) rewriteUserSkills(ctx context.Context, target models.CanonicalSkill) (int, error) {
+	documents, err := s.skillRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing skills for merge: %v", err)
+		return 0, err
+	}
+
+	updated := 0
+	for _, document := range documents {
+		rewritten, err := s.rewriteSkillsOf(ctx, document, target)
+		if err != nil {
+			log.Printf("ERROR: Failed to rewrite skills of username %s: %v", document.Username, err)
+			return updated, err
+		}
+		if rewritten {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// rewriteSkillsOf stores the merged list only while the document still holds the list it was
+// built from, re-reading it after losing a race so skills changed during the scan are kept.
+func (s *skillService) rewriteSkillsOf(ctx context.Context, document models.Skill, target models.CanonicalSkill) (bool, error) {
+	for attempt := 1; attempt <= maxSkillSetAttempts; attempt++ {
+		entries, changed := mergeSkillEntries(document.Skills, target)
+		if !changed {
+			return false, nil
+		}
+		stored, err := s.skillRepository.SetSkillsIfUnchanged(ctx, document.Username, document.Skills, entries)
+		if err != nil || stored {
+			return stored, err
+		}
+		log.Printf("Skills of username %s changed during the merge, retrying (attempt %d)", document.Username, attempt)
+
+		current, found, err := s.skillRepository.FindByUsername(ctx, document.Username)
+		if err != nil || !found {
+			return false, err
+		}
+		document = current
+	}
+	return false, &models.ConflictError{Field: "skills", Value: document.Username, Reason: "Skills were changed by another request, try again"}
+}
+
+// mergeSkillEntries collapses the entries matching target into one, kept at the position of
+// the first match and carrying the highest proficiency, experience and last-used year.
+func mergeSkillEntries(entries []models.SkillEntry, target models.CanonicalSkill) ([]models.SkillEntry, bool) {
+	result := make([]models.SkillEntry, 0, len(entries))
+	merged := -1
+	changed := false
+	for _, entry := range entries {
+		if !target.HasAlias(entry.Name) {
+			result = append(result, entry)
+			continue
+		}
+		if entry.Name != target.Name {
+			changed = true
+		}
+		if merged < 0 {
+			entry.Name = target.Name
+			merged = len(result)
+			result = append(result, entry)
+			continue
+		}
+		changed = true
+		result[merged] = result[merged].Combined(entry)
+	}
+	return result, changed
+}
+
+// canonicalName returns the catalog name for a skill, or the name itself when it is unknown.
+func (s *skillService) canonicalName(ctx context.Context, name string) (string, error) {
+	canonical, found, err := s.taxonomyRepository.FindByAlias(ctx, name)
+	if err != nil {
+		log.Printf("ERROR: Repository error resolving skill %s: %v", name, err)
+		return "", err
+	}
+	if !found {
+		return name, nil
+	}
+	if canonical.Name != name {
+		log.Printf("Normalized skill %s to %s", name, canonical.Name)
+	}
+	return canonical.Name, nil
+}
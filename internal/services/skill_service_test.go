@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -43,9 +47,113 @@ func (m *MockSkillRepository) DeleteByUsername(ctx context.Context, username str
 	return args.Error(0)
 }
 
+func (m *MockSkillRepository) FindDuplicateUsernames(ctx context.Context) ([]models.DuplicateUsername, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.DuplicateUsername), args.Error(1)
+}
+
+func (m *MockSkillRepository) EnsureUsernameIndex(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	args := m.Called(ctx, oldUsername, newUsername)
+	return args.Error(0)
+}
+
+func (m *MockSkillRepository) SetSkills(ctx context.Context, username string, skills []models.SkillEntry) ([]models.SkillEntry, error) {
+	args := m.Called(ctx, username, skills)
+	return args.Get(0).([]models.SkillEntry), args.Error(1)
+}
+
+func (m *MockSkillRepository) SetSkillsIfUnchanged(ctx context.Context, username string, expected, skills []models.SkillEntry) (bool, error) {
+	args := m.Called(ctx, username, expected, skills)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSkillRepository) CountUsersBySkill(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockSkillRepository) MigrateLegacySkills(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSkillRepository) Name() string {
+	return "skills"
+}
+
+func (m *MockSkillRepository) ExportByUsername(ctx context.Context, username string) (any, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0), args.Error(1)
+}
+
+type fakeSkillTaxonomyRepository struct {
+	skills     map[string]models.CanonicalSkill
+	failUpsert string
+}
+
+func newFakeSkillTaxonomyRepository(skills ...models.CanonicalSkill) *fakeSkillTaxonomyRepository {
+	f := &fakeSkillTaxonomyRepository{skills: map[string]models.CanonicalSkill{}}
+	for _, skill := range skills {
+		skill = skill.Normalized()
+		f.skills[skill.Key] = skill
+	}
+	return f
+}
+
+func (f *fakeSkillTaxonomyRepository) FindAll(_ context.Context, category enums.SkillCategoryEnum) ([]models.CanonicalSkill, error) {
+	skills := []models.CanonicalSkill{}
+	for _, skill := range f.skills {
+		if category == "" || skill.Category == category {
+			skills = append(skills, skill)
+		}
+	}
+	return skills, nil
+}
+
+func (f *fakeSkillTaxonomyRepository) FindByAlias(_ context.Context, name string) (models.CanonicalSkill, bool, error) {
+	for _, skill := range f.skills {
+		if skill.HasAlias(name) {
+			return skill, true, nil
+		}
+	}
+	return models.CanonicalSkill{}, false, nil
+}
+
+func (f *fakeSkillTaxonomyRepository) Upsert(_ context.Context, skill models.CanonicalSkill) error {
+	if skill.Key == f.failUpsert {
+		return errors.New("database error")
+	}
+	for key, other := range f.skills {
+		if key == skill.Key {
+			continue
+		}
+		for _, alias := range skill.Aliases {
+			if other.HasAlias(alias) {
+				return &models.ConflictError{Field: "alias", Value: alias}
+			}
+		}
+	}
+	f.skills[skill.Key] = skill
+	return nil
+}
+
+func (f *fakeSkillTaxonomyRepository) Delete(_ context.Context, key string) error {
+	delete(f.skills, key)
+	return nil
+}
+
+func (f *fakeSkillTaxonomyRepository) SeedDefaults(_ context.Context, skills []models.CanonicalSkill) (int64, error) {
+	return 0, nil
+}
+
 func TestSkillService_AddSkill_NewUser(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	skillRequest := models.SkillRequest{
 		Username: "testuser",
@@ -62,7 +170,7 @@ func TestSkillService_AddSkill_NewUser(t *testing.T) {
 
 func TestSkillService_AddSkill_ExistingUser(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	skillRequest := models.SkillRequest{
 		Username: "testuser",
@@ -71,7 +179,7 @@ func TestSkillService_AddSkill_ExistingUser(t *testing.T) {
 
 	existingSkill := models.Skill{
 		Username: "testuser",
-		Skills:   []string{"java"},
+		Skills:   []models.SkillEntry{{Name: "java"}},
 	}
 
 	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(existingSkill, true, nil)
@@ -84,7 +192,7 @@ func TestSkillService_AddSkill_ExistingUser(t *testing.T) {
 
 func TestSkillService_AddSkill_InvalidRequest(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	skillRequest := models.SkillRequest{
 		Username: "",
@@ -98,7 +206,7 @@ func TestSkillService_AddSkill_InvalidRequest(t *testing.T) {
 
 func TestSkillService_RemoveSkill_UserNotFound(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	skillRequest := models.SkillRequest{
 		Username: "nonexistent",
@@ -115,11 +223,11 @@ func TestSkillService_RemoveSkill_UserNotFound(t *testing.T) {
 
 func TestSkillService_GetAllSkills_Success(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	expectedSkill := models.Skill{
 		Username: "testuser",
-		Skills:   []string{"java", "python"},
+		Skills:   []models.SkillEntry{{Name: "java"}, {Name: "python", Proficiency: enums.ProficiencyAdvanced}},
 	}
 
 	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(expectedSkill, true, nil)
@@ -132,7 +240,7 @@ func TestSkillService_GetAllSkills_Success(t *testing.T) {
 
 func TestSkillService_GetAllSkills_UserNotFound(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	mockRepo.On("FindByUsername", mock.Anything, "nonexistent").Return(models.Skill{}, false, nil)
 
@@ -144,9 +252,499 @@ func TestSkillService_GetAllSkills_UserNotFound(t *testing.T) {
 
 func TestSkillService_GetAllSkills_EmptyUsername(t *testing.T) {
 	mockRepo := new(MockSkillRepository)
-	service := NewSkillService(mockRepo)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
 
 	_, err := service.GetAllSkills(context.Background(), "")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "username is required")
 }
+
+func TestSkillService_AddSkill_ConcurrentCreateFallsBackToAdd(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	skillRequest := models.SkillRequest{
+		Username: "testuser",
+		Skill:    "go",
+	}
+
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{}, false, nil)
+	mockRepo.On("Create", mock.Anything, skillRequest).Return(&models.ConflictError{Field: "username", Value: "testuser"})
+	mockRepo.On("AddSkill", mock.Anything, skillRequest).Return(nil)
+
+	err := service.AddSkill(context.Background(), skillRequest)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_AddSkill_WithDepthNormalized(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	skillRequest := models.SkillRequest{
+		Username:          "testuser",
+		Skill:             "  kubernetes ",
+		Proficiency:       "advanced",
+		YearsOfExperience: 4,
+		LastUsedYear:      time.Now().Year(),
+	}
+	expected := models.SkillRequest{
+		Username:          "testuser",
+		Skill:             "kubernetes",
+		Proficiency:       enums.ProficiencyAdvanced,
+		YearsOfExperience: 4,
+		LastUsedYear:      time.Now().Year(),
+	}
+
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser"}, true, nil)
+	mockRepo.On("AddSkill", mock.Anything, expected).Return(nil)
+
+	err := service.AddSkill(context.Background(), skillRequest)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_AddSkill_InvalidDepth(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+	service.(*skillService).now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	err := service.AddSkill(context.Background(), models.SkillRequest{
+		Username:          "testuser",
+		Skill:             "go",
+		Proficiency:       "GURU",
+		YearsOfExperience: -1,
+		LastUsedYear:      2030,
+	})
+
+	assert.Error(t, err)
+	for _, expected := range []string{"invalid skill", "proficiency", "yearsOfExperience", "lastUsedYear must be between 1970 and 2025"} {
+		assert.Contains(t, err.Error(), expected)
+	}
+	mockRepo.AssertNotCalled(t, "FindByUsername", mock.Anything, mock.Anything)
+}
+
+func TestSkillService_AddSkill_NormalizesToCanonicalName(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	taxonomy := newFakeSkillTaxonomyRepository(models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage, Aliases: []string{"golang"}})
+	service := NewSkillService(mockRepo, taxonomy, &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	expected := models.SkillRequest{Username: "testuser", Skill: "Go"}
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser"}, true, nil)
+	mockRepo.On("AddSkill", mock.Anything, expected).Return(nil)
+
+	for _, spelling := range []string{"GoLang", "go-lang", "go lang"} {
+		err := service.AddSkill(context.Background(), models.SkillRequest{Username: "testuser", Skill: spelling})
+		assert.NoError(t, err)
+	}
+	mockRepo.AssertNumberOfCalls(t, "AddSkill", 3)
+}
+
+func TestSkillService_RemoveSkill_NormalizesToCanonicalName(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	taxonomy := newFakeSkillTaxonomyRepository(models.CanonicalSkill{Name: "PostgreSQL", Category: enums.SkillCategoryDatabase, Aliases: []string{"postgres"}})
+	service := NewSkillService(mockRepo, taxonomy, &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser"}, true, nil)
+	mockRepo.On("RemoveSkill", mock.Anything, models.SkillRequest{Username: "testuser", Skill: "PostgreSQL"}).Return(nil)
+
+	err := service.RemoveSkill(context.Background(), models.SkillRequest{Username: "testuser", Skill: "Postgres"})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_GetCatalog_FiltersByCategory(t *testing.T) {
+	taxonomy := newFakeSkillTaxonomyRepository(
+		models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage},
+		models.CanonicalSkill{Name: "AWS", Category: enums.SkillCategoryCloud},
+	)
+	service := NewSkillService(new(MockSkillRepository), taxonomy, &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	skills, err := service.GetCatalog(context.Background(), "cloud")
+	assert.NoError(t, err)
+	assert.Len(t, skills, 1)
+	assert.Equal(t, "AWS", skills[0].Name)
+
+	_, err = service.GetCatalog(context.Background(), "TOOLS")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid category")
+}
+
+func TestSkillService_UpsertCatalogSkill_Validates(t *testing.T) {
+	service := NewSkillService(new(MockSkillRepository), newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	_, err := service.UpsertCatalogSkill(context.Background(), models.CanonicalSkill{Name: " ", Category: "TOOLS"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "name cannot be empty")
+	assert.Contains(t, err.Error(), "category")
+
+	skill, err := service.UpsertCatalogSkill(context.Background(), models.CanonicalSkill{Name: "Terraform", Category: "cloud", Aliases: []string{"TF", "tf"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "terraform", skill.Key)
+	assert.Equal(t, []string{"terraform", "tf"}, skill.Aliases)
+}
+
+func TestSkillService_MergeSkills_RewritesUserSkills(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	taxonomy := newFakeSkillTaxonomyRepository(
+		models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage},
+		models.CanonicalSkill{Name: "Golang", Category: enums.SkillCategoryLanguage, Aliases: []string{"go-lang"}},
+	)
+	service := NewSkillService(mockRepo, taxonomy, &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	mockRepo.On("FindAll", mock.Anything).Return([]models.Skill{
+		{Username: "alice", Skills: []models.SkillEntry{
+			{Name: "java"},
+			{Name: "golang", Proficiency: enums.ProficiencyIntermediate, YearsOfExperience: 5},
+			{Name: "Go", Proficiency: enums.ProficiencyExpert, YearsOfExperience: 2, LastUsedYear: 2024},
+		}},
+		{Username: "bob", Skills: []models.SkillEntry{{Name: "Go"}}},
+		{Username: "carol", Skills: []models.SkillEntry{{Name: "GO LANG"}}},
+	}, nil)
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "alice", mock.Anything, []models.SkillEntry{
+		{Name: "java"},
+		{Name: "Go", Proficiency: enums.ProficiencyExpert, YearsOfExperience: 5, LastUsedYear: 2024},
+	}).Return(true, nil)
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "carol", []models.SkillEntry{{Name: "GO LANG"}}, []models.SkillEntry{{Name: "Go"}}).Return(true, nil)
+
+	result, err := service.MergeSkills(context.Background(), models.SkillMergeRequest{Sources: []string{"Golang", "go lang"}, Target: "go"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.UsersUpdated)
+	assert.Equal(t, []string{"go", "golang"}, result.Target.Aliases)
+	_, absorbedStillThere := taxonomy.skills["golang"]
+	assert.False(t, absorbedStillThere)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_MergeSkills_KeepsSkillsAddedDuringTheScan(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	taxonomy := newFakeSkillTaxonomyRepository(
+		models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage},
+		models.CanonicalSkill{Name: "Golang", Category: enums.SkillCategoryLanguage},
+	)
+	service := NewSkillService(mockRepo, taxonomy, &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	scanned := []models.SkillEntry{{Name: "golang"}}
+	current := []models.SkillEntry{{Name: "golang"}, {Name: "docker"}}
+	mockRepo.On("FindAll", mock.Anything).Return([]models.Skill{{Username: "alice", Skills: scanned}}, nil)
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "alice", scanned, []models.SkillEntry{{Name: "Go"}}).Return(false, nil)
+	mockRepo.On("FindByUsername", mock.Anything, "alice").Return(models.Skill{Username: "alice", Skills: current}, true, nil)
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "alice", current, []models.SkillEntry{{Name: "Go"}, {Name: "docker"}}).Return(true, nil)
+
+	result, err := service.MergeSkills(context.Background(), models.SkillMergeRequest{Sources: []string{"Golang"}, Target: "go"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.UsersUpdated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_MergeSkills_RestoresAbsorbedSkillsWhenTargetFails(t *testing.T) {
+	taxonomy := newFakeSkillTaxonomyRepository(
+		models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage},
+		models.CanonicalSkill{Name: "Golang", Category: enums.SkillCategoryLanguage, Aliases: []string{"go-lang"}},
+	)
+	taxonomy.failUpsert = "go"
+	original := taxonomy.skills["golang"]
+	txManager := &mockTransactionManager{}
+	service := NewSkillService(new(MockSkillRepository), taxonomy, &mockJobRepository{}, existingUserRepository(), txManager)
+
+	_, err := service.MergeSkills(context.Background(), models.SkillMergeRequest{Sources: []string{"Golang"}, Target: "go"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, txManager.calls)
+	restored, found := taxonomy.skills["golang"]
+	assert.True(t, found)
+	assert.Equal(t, original, restored)
+}
+
+func TestSkillService_MergeSkills_TargetNotInCatalog(t *testing.T) {
+	service := NewSkillService(new(MockSkillRepository), newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	_, err := service.MergeSkills(context.Background(), models.SkillMergeRequest{Sources: []string{"golang"}, Target: "Go"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "canonical skill not found", err.Error())
+}
+
+func TestSkillService_MergeSkills_SourcesRequired(t *testing.T) {
+	service := NewSkillService(new(MockSkillRepository), newFakeSkillTaxonomyRepository(), &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+
+	_, err := service.MergeSkills(context.Background(), models.SkillMergeRequest{Sources: []string{" "}, Target: "Go"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sources are required")
+}
+
+func newSuggestTestService(mockRepo *MockSkillRepository, jobs []models.Job) (*skillService, *int) {
+	jobScans := 0
+	jobRepo := &mockJobRepository{
+		findAllFunc: func(ctx context.Context) ([]models.Job, error) {
+			jobScans++
+			return jobs, nil
+		},
+	}
+	taxonomy := newFakeSkillTaxonomyRepository(
+		models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage, Aliases: []string{"golang"}},
+		models.CanonicalSkill{Name: "Google Cloud", Category: enums.SkillCategoryCloud, Aliases: []string{"gcp"}},
+		models.CanonicalSkill{Name: "GraphQL", Category: enums.SkillCategoryFramework},
+		models.CanonicalSkill{Name: "Kubernetes", Category: enums.SkillCategoryCloud, Aliases: []string{"k8s"}},
+	)
+	return NewSkillService(mockRepo, taxonomy, jobRepo, existingUserRepository(), &mockTransactionManager{}).(*skillService), &jobScans
+}
+
+func TestSkillService_SuggestSkills_RanksByMatchThenPopularity(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	mockRepo.On("CountUsersBySkill", mock.Anything).Return(map[string]int{"Go": 3, "Google Cloud": 1, "golang": 1}, nil)
+	service, _ := newSuggestTestService(mockRepo, []models.Job{
+		{Skills: []string{"Google Cloud"}}, {Skills: []string{"Google Cloud"}},
+		{Skills: []string{"Google Cloud", "Kubernetes"}}, {Skills: []string{"Google Cloud"}},
+	})
+
+	suggestions, err := service.SuggestSkills(context.Background(), "go", 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 2)
+	assert.Equal(t, "Google Cloud", suggestions[0].Name)
+	assert.Equal(t, 1, suggestions[0].Users)
+	assert.Equal(t, 4, suggestions[0].Jobs)
+	assert.Equal(t, "Go", suggestions[1].Name)
+	assert.Equal(t, 4, suggestions[1].Users)
+}
+
+func TestSkillService_SuggestSkills_FuzzyAndAliasMatches(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	mockRepo.On("CountUsersBySkill", mock.Anything).Return(map[string]int{}, nil)
+	service, _ := newSuggestTestService(mockRepo, nil)
+
+	suggestions, err := service.SuggestSkills(context.Background(), "kubr", 0)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "Kubernetes", suggestions[0].Name)
+
+	suggestions, err = service.SuggestSkills(context.Background(), "K8", 0)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, "Kubernetes", suggestions[0].Name)
+
+	suggestions, err = service.SuggestSkills(context.Background(), "rust", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func TestSkillService_SuggestSkills_CachesPopularity(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	mockRepo.On("CountUsersBySkill", mock.Anything).Return(map[string]int{}, nil)
+	service, jobScans := newSuggestTestService(mockRepo, nil)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	_, _ = service.SuggestSkills(context.Background(), "go", 0)
+	_, _ = service.SuggestSkills(context.Background(), "gra", 0)
+	assert.Equal(t, 1, *jobScans)
+
+	now = now.Add(11 * time.Minute)
+	_, _ = service.SuggestSkills(context.Background(), "go", 0)
+	assert.Equal(t, 2, *jobScans)
+	mockRepo.AssertNumberOfCalls(t, "CountUsersBySkill", 2)
+}
+
+func TestSkillService_SuggestSkills_LimitAndEmptyPrefix(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	mockRepo.On("CountUsersBySkill", mock.Anything).Return(map[string]int{}, nil)
+	service, _ := newSuggestTestService(mockRepo, nil)
+
+	suggestions, err := service.SuggestSkills(context.Background(), "g", 1)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 1)
+
+	_, err = service.SuggestSkills(context.Background(), " - ", 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "prefix is required")
+}
+
+// existingUserRepository reports every username as an existing account.
+func existingUserRepository() *mockUserRepository {
+	return &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username}, true, nil
+		},
+	}
+}
+
+func newSkillSetTestService(mockRepo *MockSkillRepository) SkillService {
+	taxonomy := newFakeSkillTaxonomyRepository(models.CanonicalSkill{Name: "Go", Category: enums.SkillCategoryLanguage, Aliases: []string{"golang"}})
+	service := NewSkillService(mockRepo, taxonomy, &mockJobRepository{}, existingUserRepository(), &mockTransactionManager{})
+	service.(*skillService).now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	return service
+}
+
+func TestSkillService_ReplaceUserSkills_ReportsChanges(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	stored := []models.SkillEntry{
+		{Name: "Go", Proficiency: enums.ProficiencyAdvanced, YearsOfExperience: 5},
+		{Name: "python", YearsOfExperience: 5},
+		{Name: "java"},
+	}
+	mockRepo.On("SetSkills", mock.Anything, "testuser", []models.SkillEntry{
+		{Name: "Go", Proficiency: enums.ProficiencyExpert, YearsOfExperience: 6},
+		{Name: "java"},
+		{Name: "rust", Proficiency: enums.ProficiencyBeginner},
+	}).Return(stored, nil)
+
+	changes, err := service.ReplaceUserSkills(context.Background(), "testuser", []models.SkillEntry{
+		{Name: " golang ", Proficiency: "expert", YearsOfExperience: 6},
+		{Name: "java"},
+		{Name: "rust", Proficiency: "beginner"},
+		{Name: "GO", YearsOfExperience: 1},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rust"}, changes.Added)
+	assert.Equal(t, []string{"python"}, changes.Removed)
+	assert.Equal(t, []string{"Go"}, changes.Updated)
+	assert.Len(t, changes.Skills, 3)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_ReplaceUserSkills_ListsInvalidEntries(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	_, err := service.ReplaceUserSkills(context.Background(), "testuser", []models.SkillEntry{
+		{Name: "go"},
+		{Name: " "},
+		{Name: "java", Proficiency: "GURU", LastUsedYear: 2030},
+	})
+
+	var validation *models.ValidationError
+	assert.True(t, errors.As(err, &validation))
+	fields := []string{}
+	for _, fieldError := range validation.Fields {
+		fields = append(fields, fieldError.Field)
+	}
+	assert.Equal(t, []string{"skills[1].name", "skills[2].proficiency", "skills[2].lastUsedYear"}, fields)
+	mockRepo.AssertNotCalled(t, "SetSkillsIfUnchanged", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSkillService_ReplaceUserSkills_UnknownUser(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	users := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{}, false, nil
+		},
+	}
+	service := NewSkillService(mockRepo, newFakeSkillTaxonomyRepository(), &mockJobRepository{}, users, &mockTransactionManager{})
+
+	_, err := service.ReplaceUserSkills(context.Background(), "ghost", []models.SkillEntry{{Name: "docker"}})
+
+	assert.EqualError(t, err, "user not found")
+	mockRepo.AssertNotCalled(t, "SetSkills", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSkillService_AddUserSkills_RetriesAfterConcurrentAdd(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	before := []models.SkillEntry{{Name: "java"}}
+	after := []models.SkillEntry{{Name: "java"}, {Name: "rust"}}
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser", Skills: before}, true, nil).Once()
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser", Skills: after}, true, nil).Once()
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "testuser", before, mock.Anything).Return(false, nil).Once()
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "testuser", after, []models.SkillEntry{
+		{Name: "java"},
+		{Name: "rust"},
+		{Name: "docker"},
+	}).Return(true, nil).Once()
+
+	changes, err := service.AddUserSkills(context.Background(), "testuser", []models.SkillEntry{{Name: "docker"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docker"}, changes.Added)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_AddUserSkills_GivesUpAfterRepeatedConflicts(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser"}, true, nil)
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "testuser", mock.Anything, mock.Anything).Return(false, nil)
+
+	_, err := service.AddUserSkills(context.Background(), "testuser", []models.SkillEntry{{Name: "docker"}})
+
+	var conflict *models.ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	mockRepo.AssertNumberOfCalls(t, "SetSkillsIfUnchanged", maxSkillSetAttempts)
+}
+
+func TestSkillService_ReplaceUserSkills_EmptyListClearsSkills(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	mockRepo.On("SetSkills", mock.Anything, "testuser", []models.SkillEntry{}).Return([]models.SkillEntry{{Name: "java"}}, nil)
+
+	changes, err := service.ReplaceUserSkills(context.Background(), "testuser", []models.SkillEntry{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"java"}, changes.Removed)
+	assert.Empty(t, changes.Added)
+}
+
+func TestSkillService_ReplaceUserSkills_RetriesAfterConcurrentCreate(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	entries := []models.SkillEntry{{Name: "java"}}
+	mockRepo.On("SetSkills", mock.Anything, "testuser", entries).Return([]models.SkillEntry(nil), &models.ConflictError{Field: "username", Value: "testuser"}).Once()
+	mockRepo.On("SetSkills", mock.Anything, "testuser", entries).Return([]models.SkillEntry{}, nil).Once()
+
+	changes, err := service.ReplaceUserSkills(context.Background(), "testuser", entries)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"java"}, changes.Added)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_AddUserSkills_MergesWithExisting(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	existing := []models.SkillEntry{{Name: "java", YearsOfExperience: 3}, {Name: "Go"}}
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser", Skills: existing}, true, nil)
+	mockRepo.On("SetSkillsIfUnchanged", mock.Anything, "testuser", existing, []models.SkillEntry{
+		{Name: "java", YearsOfExperience: 3},
+		{Name: "Go", Proficiency: enums.ProficiencyAdvanced},
+		{Name: "docker"},
+	}).Return(true, nil)
+
+	changes, err := service.AddUserSkills(context.Background(), "testuser", []models.SkillEntry{
+		{Name: "golang", Proficiency: "ADVANCED"},
+		{Name: "docker"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docker"}, changes.Added)
+	assert.Equal(t, []string{"Go"}, changes.Updated)
+	assert.Empty(t, changes.Removed)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSkillService_AddUserSkills_TooManySkills(t *testing.T) {
+	mockRepo := new(MockSkillRepository)
+	service := newSkillSetTestService(mockRepo)
+
+	existing := make([]models.SkillEntry, models.MaxSkillsPerUser)
+	for i := range existing {
+		existing[i] = models.SkillEntry{Name: fmt.Sprintf("skill-%d", i)}
+	}
+	mockRepo.On("FindByUsername", mock.Anything, "testuser").Return(models.Skill{Username: "testuser", Skills: existing}, true, nil)
+
+	_, err := service.AddUserSkills(context.Background(), "testuser", []models.SkillEntry{{Name: "docker"}})
+
+	var validation *models.ValidationError
+	assert.True(t, errors.As(err, &validation))
+	mockRepo.AssertNotCalled(t, "SetSkills", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jboard-go-crud/internal/models"
+	"log"
+	"strings"
+)
+
+// ReplaceUserSkills atomically replaces the user's whole skill list with the given one.
+func (s *skillService) ReplaceUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error) {
+	log.Printf("Service ReplaceUserSkills called for username: %s with %d skills", username, len(skills))
+
+	if strings.TrimSpace(username) == "" {
+		return models.SkillSetChanges{}, errors.New("username is required")
+	}
+	entries, err := s.normalizeSkillSet(ctx, skills)
+	if err != nil {
+		return models.SkillSetChanges{}, err
+	}
+	if err := s.requireUser(ctx, username); err != nil {
+		return models.SkillSetChanges{}, err
+	}
+
+	previous, err := s.setSkills(ctx, username, entries)
+	if err != nil {
+		return models.SkillSetChanges{}, err
+	}
+
+	changes := diffSkillSets(previous, entries)
+	log.Printf("Replaced skills of username %s: %d added, %d removed, %d updated", username, len(changes.Added), len(changes.Removed), len(changes.Updated))
+	return changes, nil
+}
+
+// maxSkillSetAttempts bounds how often AddUserSkills re-reads the list after losing a race
+// with another write to the same user's skills.
+const maxSkillSetAttempts = 3
+
+// AddUserSkills adds many skills at once. A skill the user already has is replaced, as with AddSkill.
+// The merged list is only stored while the list it was built from is unchanged, so skills added
+// concurrently are never lost.
+func (s *skillService) AddUserSkills(ctx context.Context, username string, skills []models.SkillEntry) (models.SkillSetChanges, error) {
+	log.Printf("Service AddUserSkills called for username: %s with %d skills", username, len(skills))
+
+	if strings.TrimSpace(username) == "" {
+		return models.SkillSetChanges{}, errors.New("username is required")
+	}
+	entries, err := s.normalizeSkillSet(ctx, skills)
+	if err != nil {
+		return models.SkillSetChanges{}, err
+	}
+	if err := s.requireUser(ctx, username); err != nil {
+		return models.SkillSetChanges{}, err
+	}
+
+	for attempt := 1; attempt <= maxSkillSetAttempts; attempt++ {
+		current, _, err := s.skillRepository.FindByUsername(ctx, username)
+		if err != nil {
+			log.Printf("ERROR: Repository error in AddUserSkills for username %s: %v", username, err)
+			return models.SkillSetChanges{}, err
+		}
+
+		merged := mergeSkillSets(current.Skills, entries)
+		if len(merged) > models.MaxSkillsPerUser {
+			return models.SkillSetChanges{}, &models.ValidationError{Fields: []models.FieldError{
+				{Field: "skills", Message: fmt.Sprintf("would leave the user with %d skills, at most %d are allowed", len(merged), models.MaxSkillsPerUser)},
+			}}
+		}
+
+		stored, err := s.skillRepository.SetSkillsIfUnchanged(ctx, username, current.Skills, merged)
+		if err != nil {
+			log.Printf("ERROR: Failed to store skills for username %s: %v", username, err)
+			return models.SkillSetChanges{}, err
+		}
+		if stored {
+			changes := diffSkillSets(current.Skills, merged)
+			log.Printf("Batch added skills to username %s: %d added, %d updated", username, len(changes.Added), len(changes.Updated))
+			return changes, nil
+		}
+		log.Printf("Skills of username %s changed while adding, retrying (attempt %d)", username, attempt)
+	}
+
+	log.Printf("ERROR: Giving up adding skills to username %s after %d concurrent changes", username, maxSkillSetAttempts)
+	return models.SkillSetChanges{}, &models.ConflictError{Field: "skills", Value: username, Reason: "Skills were changed by another request, try again"}
+}
+
+// mergeSkillSets appends entries to current, replacing the entries they share a key with.
+func mergeSkillSets(current, entries []models.SkillEntry) []models.SkillEntry {
+	added := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		added[models.SkillKey(entry.Name)] = true
+	}
+	merged := make([]models.SkillEntry, 0, len(current)+len(entries))
+	for _, entry := range current {
+		if !added[models.SkillKey(entry.Name)] {
+			merged = append(merged, entry)
+		}
+	}
+	return append(merged, entries...)
+}
+
+// setSkills stores the list, retrying once when a concurrent request created the document first.
+// requireUser keeps skill sets from creating skills documents for accounts that do not exist.
+func (s *skillService) requireUser(ctx context.Context, username string) error {
+	_, found, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("ERROR: Repository error looking up username %s: %v", username, err)
+		return err
+	}
+	if !found {
+		log.Printf("User not found for skill set, username: %s", username)
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (s *skillService) setSkills(ctx context.Context, username string, entries []models.SkillEntry) ([]models.SkillEntry, error) {
+	previous, err := s.skillRepository.SetSkills(ctx, username, entries)
+	var conflict *models.ConflictError
+	if errors.As(err, &conflict) {
+		log.Printf("Skills document created concurrently for username: %s, retrying", username)
+		previous, err = s.skillRepository.SetSkills(ctx, username, entries)
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to store skills for username %s: %v", username, err)
+		return nil, err
+	}
+	return previous, nil
+}
+
+// normalizeSkillSet validates every entry, resolves names through the catalog and folds
+// duplicates together, reporting all invalid entries at once.
+func (s *skillService) normalizeSkillSet(ctx context.Context, skills []models.SkillEntry) ([]models.SkillEntry, error) {
+	if len(skills) > models.MaxSkillsPerUser {
+		return nil, &models.ValidationError{Fields: []models.FieldError{
+			{Field: "skills", Message: fmt.Sprintf("must have at most %d entries", models.MaxSkillsPerUser)},
+		}}
+	}
+
+	currentYear := s.now().Year()
+	var fieldErrors []models.FieldError
+	entries := make([]models.SkillEntry, 0, len(skills))
+	positions := map[string]int{}
+	for i, entry := range skills {
+		prefix := fmt.Sprintf("skills[%d].", i)
+		entry = entry.Normalized()
+		if entry.Name == "" {
+			fieldErrors = append(fieldErrors, models.FieldError{Field: prefix + "name", Message: "cannot be empty"})
+			continue
+		}
+		if problems := entry.FieldErrors(prefix, currentYear); len(problems) > 0 {
+			fieldErrors = append(fieldErrors, problems...)
+			continue
+		}
+
+		name, err := s.canonicalName(ctx, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		entry.Name = name
+
+		key := models.SkillKey(name)
+		if position, duplicate := positions[key]; duplicate {
+			entries[position] = entries[position].Combined(entry)
+			continue
+		}
+		positions[key] = len(entries)
+		entries = append(entries, entry)
+	}
+	if len(fieldErrors) > 0 {
+		return nil, &models.ValidationError{Fields: fieldErrors}
+	}
+	return entries, nil
+}
+
+// diffSkillSets compares two skill lists by skill key.
+func diffSkillSets(previous, current []models.SkillEntry) models.SkillSetChanges {
+	changes := models.SkillSetChanges{
+		Added:   []string{},
+		Removed: []string{},
+		Updated: []string{},
+		Skills:  current,
+	}
+
+	before := make(map[string]models.SkillEntry, len(previous))
+	for _, entry := range previous {
+		before[models.SkillKey(entry.Name)] = entry
+	}
+	after := make(map[string]bool, len(current))
+	for _, entry := range current {
+		key := models.SkillKey(entry.Name)
+		after[key] = true
+		old, existed := before[key]
+		switch {
+		case !existed:
+			changes.Added = append(changes.Added, entry.Name)
+		case old != entry:
+			changes.Updated = append(changes.Updated, entry.Name)
+		}
+	}
+	for _, entry := range previous {
+		if !after[models.SkillKey(entry.Name)] {
+			changes.Removed = append(changes.Removed, entry.Name)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSkillSuggestLimit = 10
+	maxSkillSuggestLimit     = 50
+	skillPopularityTTL       = 10 * time.Minute
+)
+
+// Match quality tiers, best first. Suggestions are ranked by tier, then by popularity.
+const (
+	skillMatchName = iota
+	skillMatchAlias
+	skillMatchFuzzy
+)
+
+// skillPopularity counts, per canonical skill key, the users listing the skill and the current
+// jobs tagged with it.
+type skillPopularity struct {
+	computedAt time.Time
+	users      map[string]int
+	jobs       map[string]int
+}
+
+// SuggestSkills autocompletes a skill name with canonical catalog skills only, so that the
+// picker steers users away from ad-hoc spellings.
+func (s *skillService) SuggestSkills(ctx context.Context, prefix string, limit int) ([]models.SkillSuggestion, error) {
+	log.Printf("Service SuggestSkills called for prefix: %s", prefix)
+
+	key := models.SkillKey(prefix)
+	if key == "" {
+		return nil, errors.New("prefix is required")
+	}
+	if limit <= 0 {
+		limit = defaultSkillSuggestLimit
+	}
+	if limit > maxSkillSuggestLimit {
+		limit = maxSkillSuggestLimit
+	}
+
+	catalog, err := s.taxonomyRepository.FindAll(ctx, "")
+	if err != nil {
+		log.Printf("ERROR: Repository error loading skill catalog in SuggestSkills: %v", err)
+		return nil, err
+	}
+
+	type candidate struct {
+		suggestion models.SkillSuggestion
+		tier       int
+	}
+	var candidates []candidate
+	for _, skill := range catalog {
+		if tier, ok := skillMatchTier(skill, key); ok {
+			candidates = append(candidates, candidate{
+				suggestion: models.SkillSuggestion{Name: skill.Name, Category: skill.Category},
+				tier:       tier,
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return []models.SkillSuggestion{}, nil
+	}
+
+	popularity, err := s.loadPopularity(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		skillKey := models.SkillKey(candidates[i].suggestion.Name)
+		candidates[i].suggestion.Users = popularity.users[skillKey]
+		candidates[i].suggestion.Jobs = popularity.jobs[skillKey]
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.tier != b.tier {
+			return a.tier < b.tier
+		}
+		scoreA := a.suggestion.Users + a.suggestion.Jobs
+		scoreB := b.suggestion.Users + b.suggestion.Jobs
+		if scoreA != scoreB {
+			return scoreA > scoreB
+		}
+		return a.suggestion.Name < b.suggestion.Name
+	})
+
+	suggestions := make([]models.SkillSuggestion, 0, min(limit, len(candidates)))
+	for _, c := range candidates[:min(limit, len(candidates))] {
+		suggestions = append(suggestions, c.suggestion)
+	}
+
+	log.Printf("Returning %d skill suggestions for prefix: %s", len(suggestions), prefix)
+	return suggestions, nil
+}
+
+// loadPopularity returns the cached counts, recomputing them once they are older than
+// skillPopularityTTL. The lock is held while counting so concurrent misses count only once.
+func (s *skillService) loadPopularity(ctx context.Context, catalog []models.CanonicalSkill) (*skillPopularity, error) {
+	s.popularityMu.Lock()
+	defer s.popularityMu.Unlock()
+
+	if s.popularity != nil && s.now().Sub(s.popularity.computedAt) < skillPopularityTTL {
+		return s.popularity, nil
+	}
+
+	aliasOwner := map[string]string{}
+	for _, skill := range catalog {
+		for _, alias := range skill.Aliases {
+			aliasOwner[alias] = skill.Key
+		}
+	}
+
+	userCounts, err := s.skillRepository.CountUsersBySkill(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error counting users by skill: %v", err)
+		return nil, err
+	}
+	users := map[string]int{}
+	for name, count := range userCounts {
+		if owner, found := aliasOwner[models.SkillKey(name)]; found {
+			users[owner] += count
+		}
+	}
+
+	jobs, err := s.jobRepository.FindAll(ctx)
+	if err != nil {
+		log.Printf("ERROR: Repository error listing jobs for skill popularity: %v", err)
+		return nil, err
+	}
+	jobCounts := map[string]int{}
+	for _, job := range jobs {
+		for _, name := range job.Skills {
+			if owner, found := aliasOwner[models.SkillKey(name)]; found {
+				jobCounts[owner]++
+			}
+		}
+	}
+
+	s.popularity = &skillPopularity{computedAt: s.now(), users: users, jobs: jobCounts}
+	log.Printf("Recomputed skill popularity from %d skill names and %d jobs", len(userCounts), len(jobs))
+	return s.popularity, nil
+}
+
+// skillMatchTier reports how well key matches the skill: a prefix of the canonical key, a
+// prefix of an alias, or, for keys of three or more characters, a near miss of an alias prefix.
+func skillMatchTier(skill models.CanonicalSkill, key string) (int, bool) {
+	if strings.HasPrefix(skill.Key, key) {
+		return skillMatchName, true
+	}
+	for _, alias := range skill.Aliases {
+		if strings.HasPrefix(alias, key) {
+			return skillMatchAlias, true
+		}
+	}
+
+	keyRunes := []rune(key)
+	if len(keyRunes) < 3 {
+		return 0, false
+	}
+	maxDistance := 1
+	if len(keyRunes) >= 6 {
+		maxDistance = 2
+	}
+	for _, alias := range skill.Aliases {
+		aliasRunes := []rune(alias)
+		// Compare against alias prefixes around the typed length, allowing for an extra or a
+		// missing character.
+		for length := len(keyRunes) - 1; length <= len(keyRunes)+1; length++ {
+			if length <= 0 || length > len(aliasRunes) {
+				continue
+			}
+			if editDistance(keyRunes, aliasRunes[:length]) <= maxDistance {
+				return skillMatchFuzzy, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
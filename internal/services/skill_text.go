@@ -0,0 +1,109 @@
+package services
+
+import (
+	"jboard-go-crud/internal/models"
+	"strings"
+	"unicode"
+)
+
+// maxSkillPhraseWords is the longest alias, in words, looked up in free text ("google cloud platform").
+const maxSkillPhraseWords = 3
+
+// textWord is a word of free text; sentenceStart marks the first word of a sentence.
+type textWord struct {
+	text          string
+	sentenceStart bool
+}
+
+// textWords splits text into words, keeping the symbols skill names use ("C++", "C#", "Node.js").
+// A word ending in "." or followed by "!" or "?" closes its sentence.
+func textWords(text string) []textWord {
+	var words []textWord
+	var current strings.Builder
+	sentenceStart := true
+	for _, r := range text + " " {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("+#.-_", r) {
+			current.WriteRune(r)
+			continue
+		}
+		if current.Len() > 0 {
+			word := current.String()
+			words = append(words, textWord{text: word, sentenceStart: sentenceStart})
+			sentenceStart = strings.HasSuffix(word, ".")
+			current.Reset()
+		}
+		if r == '!' || r == '?' {
+			sentenceStart = true
+		}
+	}
+	return words
+}
+
+// skillKeysInText adds to keys the skill key of every word and run of up to maxSkillPhraseWords
+// words, so "Senior Spring Boot Developer" yields "springboot" among others.
+func skillKeysInText(words []textWord, keys map[string]bool) {
+	for i := range words {
+		phrase := ""
+		for n := 0; n < maxSkillPhraseWords && i+n < len(words); n++ {
+			phrase += models.SkillKey(words[i+n].text)
+			if phrase != "" {
+				keys[phrase] = true
+			}
+		}
+	}
+}
+
+// canonicalSkillsInText lists the catalog skills mentioned in a job title or description, in
+// catalog order. Ambiguous aliases count only when written exactly as listed. In the description
+// they must also not be the first word of a sentence, where "Go" or "Spring" is usually plain
+// English, and the job must mention some other skill first: "Java" in a travel ad is an island.
+func canonicalSkillsInText(title, description string, catalog []models.CanonicalSkill) []models.CanonicalSkill {
+	titleWords, descriptionWords := textWords(title), textWords(description)
+	keys := map[string]bool{}
+	skillKeysInText(titleWords, keys)
+	skillKeysInText(descriptionWords, keys)
+
+	titleSpellings := map[string]bool{}
+	for _, word := range titleWords {
+		titleSpellings[strings.TrimRight(word.text, ".")] = true
+	}
+	descriptionSpellings := map[string]bool{}
+	for _, word := range descriptionWords {
+		if !word.sentenceStart {
+			descriptionSpellings[strings.TrimRight(word.text, ".")] = true
+		}
+	}
+
+	direct := make([]bool, len(catalog))
+	technical := false
+	for i, skill := range catalog {
+		direct[i] = mentionsSkill(skill, keys, titleSpellings)
+		technical = technical || direct[i]
+	}
+
+	var found []models.CanonicalSkill
+	for i, skill := range catalog {
+		if direct[i] || (technical && usesAmbiguousSpelling(skill, descriptionSpellings)) {
+			found = append(found, skill)
+		}
+	}
+	return found
+}
+
+func mentionsSkill(skill models.CanonicalSkill, keys, spellings map[string]bool) bool {
+	for _, alias := range skill.Aliases {
+		if keys[alias] && !skill.IsAmbiguousKey(alias) {
+			return true
+		}
+	}
+	return usesAmbiguousSpelling(skill, spellings)
+}
+
+func usesAmbiguousSpelling(skill models.CanonicalSkill, spellings map[string]bool) bool {
+	for _, spelling := range skill.AmbiguousAliases {
+		if spellings[spelling] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"jboard-go-crud/internal/models"
+	"testing"
+)
+
+func TestCanonicalSkillsInText(t *testing.T) {
+	catalog := models.DefaultSkillTaxonomy()
+
+	found := canonicalSkillsInText("Senior Spring Boot / Node.js Engineer (AWS, k8s, C#)", "", catalog)
+
+	names := map[string]bool{}
+	for _, skill := range found {
+		names[skill.Name] = true
+	}
+	for _, expected := range []string{"Spring Boot", "Node.js", "AWS", "Kubernetes", "C#"} {
+		if !names[expected] {
+			t.Errorf("Expected %s to be found, got %v", expected, names)
+		}
+	}
+	if names["C++"] || names["Java"] {
+		t.Errorf("Expected no unrelated skills, got %v", names)
+	}
+}
+
+func TestCanonicalSkillsInText_NoMatch(t *testing.T) {
+	if found := canonicalSkillsInText("Office Manager", "", models.DefaultSkillTaxonomy()); len(found) != 0 {
+		t.Errorf("Expected no skills, got %v", found)
+	}
+}
+
+func TestCanonicalSkillsInText_AmbiguousAliases(t *testing.T) {
+	catalog := models.DefaultSkillTaxonomy()
+	skillNames := func(title, description string) map[string]bool {
+		names := map[string]bool{}
+		for _, skill := range canonicalSkillsInText(title, description, catalog) {
+			names[skill.Name] = true
+		}
+		return names
+	}
+
+	plain := skillNames("Sales Lead", "You will go to client sites and own each node of the sales funnel. "+
+		"Spring is our busiest season, and you will work with Oracle as a partner.")
+	if plain["Go"] || plain["Node.js"] || plain["Spring Boot"] || plain["Oracle Database"] {
+		t.Errorf("Expected plain words not to tag skills, got %v", plain)
+	}
+
+	tech := skillNames("Go Engineer", "We use Go, Node and Spring across services, backed by Oracle.")
+	for _, expected := range []string{"Go", "Node.js", "Spring Boot", "Oracle Database"} {
+		if !tech[expected] {
+			t.Errorf("Expected %s to be found, got %v", expected, tech)
+		}
+	}
+
+	if names := skillNames("Backend Engineer", "Experience with golang is a plus."); !names["Go"] {
+		t.Errorf("Expected unambiguous aliases to match in any case, got %v", names)
+	}
+
+	for _, text := range []struct{ title, description string }{
+		{"Delivery Driver", "Drivers must react quickly and keep the van behind the guard rails."},
+		{"Plant Supervisor", "Net salary above the market average for relocating to the rust belt."},
+		{"Tour Guide", "You will travel to Java island with our groups every quarter."},
+		{"Security Analyst", "An active TS/SCI clearance is required."},
+	} {
+		if names := skillNames(text.title, text.description); len(names) != 0 {
+			t.Errorf("Expected no skills in %q, got %v", text.description, names)
+		}
+	}
+
+	stack := skillNames("Backend Engineer", "Our services run on .NET and Rust, with a React front end and Java jobs on AWS.")
+	for _, expected := range []string{".NET", "Rust", "React", "Java", "AWS"} {
+		if !stack[expected] {
+			t.Errorf("Expected %s to be found, got %v", expected, stack)
+		}
+	}
+}
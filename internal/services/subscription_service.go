@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"jboard-go-crud/internal/repositories"
+	"log"
+	"strings"
+	"time"
+)
+
+type SubscriptionService interface {
+	GetSubscription(ctx context.Context, username string) (models.Subscription, error)
+	CancelSubscription(ctx context.Context, username string) (models.Subscription, error)
+	HandlePaymentEvent(ctx context.Context, event models.PaymentEvent) error
+	DowngradeExpired(ctx context.Context) (int, error)
+}
+
+type subscriptionService struct {
+	userRepo repositories.UserRepository
+	now      func() time.Time
+}
+
+func NewSubscriptionService(userRepo repositories.UserRepository) SubscriptionService {
+	log.Printf("Creating new SubscriptionService")
+	return &subscriptionService{
+		userRepo: userRepo,
+		now:      time.Now,
+	}
+}
+
+func (s *subscriptionService) GetSubscription(ctx context.Context, username string) (models.Subscription, error) {
+	log.Printf("Service GetSubscription called for username: %s", username)
+
+	user, err := s.findUser(ctx, username)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	if user.Subscription == nil {
+		log.Printf("No subscription found for username: %s", username)
+		return models.Subscription{}, errors.New("subscription not found")
+	}
+
+	return *user.Subscription, nil
+}
+
+func (s *subscriptionService) CancelSubscription(ctx context.Context, username string) (models.Subscription, error) {
+	log.Printf("Service CancelSubscription called for username: %s", username)
+
+	user, err := s.findUser(ctx, username)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	if user.Subscription == nil {
+		log.Printf("No subscription to cancel for username: %s", username)
+		return models.Subscription{}, errors.New("subscription not found")
+	}
+
+	// The user keeps PREMIUM until the paid period ends; DowngradeExpired takes it from there.
+	subscription := *user.Subscription
+	subscription.Status = enums.Cancelled
+	subscription.UpdatedAt = s.now()
+
+	updated, err := s.userRepo.UpdateSubscription(ctx, user.ID.Hex(), user.Subscription, user.Role, subscription)
+	if err != nil {
+		log.Printf("Repository error in CancelSubscription: %v", err)
+		return models.Subscription{}, err
+	}
+	if !updated {
+		return models.Subscription{}, errSubscriptionChanged(username)
+	}
+
+	log.Printf("Successfully cancelled subscription for username: %s", username)
+	return subscription, nil
+}
+
+func (s *subscriptionService) HandlePaymentEvent(ctx context.Context, event models.PaymentEvent) error {
+	log.Printf("Service HandlePaymentEvent called for event ID: %s, type: %s, username: %s", event.ID, event.Type, event.Username)
+
+	if strings.TrimSpace(event.ID) == "" {
+		return errors.New("event ID cannot be empty")
+	}
+	if event.OccurredAt.IsZero() {
+		return errors.New("invalid event: occurred at is required")
+	}
+
+	// Checked before the lookup so that events we ignore are acknowledged even for unknown users.
+	if !supportedPaymentEvent(event.Type) {
+		log.Printf("Unsupported payment event type: %s", event.Type)
+		return errors.New("unsupported event type")
+	}
+
+	user, err := s.findUser(ctx, event.Username)
+	if err != nil {
+		return err
+	}
+
+	var subscription models.Subscription
+	if user.Subscription != nil {
+		subscription = *user.Subscription
+		if subscription.LastEventID == event.ID {
+			log.Printf("Event %s already processed for username: %s, skipping", event.ID, event.Username)
+			return nil
+		}
+		// Providers redeliver and reorder events; applying an older one would roll the subscription back.
+		if !eventAfter(event, subscription) {
+			log.Printf("Event %s occurred at %v, not after the last applied event %s at %v for username: %s, skipping", event.ID, event.OccurredAt, subscription.LastEventID, subscription.LastEventAt, event.Username)
+			return nil
+		}
+	}
+	occurredAt := event.OccurredAt
+
+	role := user.Role
+	switch event.Type {
+	case models.PaymentEventTrialStarted, models.PaymentEventSubscriptionActivated, models.PaymentEventInvoicePaid:
+		if event.PeriodEnd.IsZero() {
+			return errors.New("invalid event: period end is required")
+		}
+		status := enums.Active
+		if event.Type == models.PaymentEventTrialStarted {
+			status = enums.Trial
+		}
+		if subscription.StartedAt.IsZero() || (subscription.Status == enums.Cancelled && role != enums.Premium) {
+			subscription.StartedAt = occurredAt
+		}
+		subscription.Status = status
+		subscription.ExpiresAt = event.PeriodEnd
+		role = enums.Premium
+	case models.PaymentEventPaymentFailed:
+		if user.Subscription == nil {
+			return errors.New("subscription not found")
+		}
+		subscription.Status = enums.PastDue
+	case models.PaymentEventSubscriptionCancelled:
+		if user.Subscription == nil {
+			return errors.New("subscription not found")
+		}
+		subscription.Status = enums.Cancelled
+	}
+
+	subscription.Plan = enums.Premium
+	subscription.UpdatedAt = s.now()
+	subscription.LastEventID = event.ID
+	subscription.LastEventAt = occurredAt
+
+	// A conflict makes the provider redeliver the event, which is then applied to the new state.
+	updated, err := s.userRepo.UpdateSubscription(ctx, user.ID.Hex(), user.Subscription, role, subscription)
+	if err != nil {
+		log.Printf("Repository error in HandlePaymentEvent: %v", err)
+		return err
+	}
+	if !updated {
+		return errSubscriptionChanged(event.Username)
+	}
+
+	log.Printf("Successfully applied event %s for username: %s, status: %s", event.ID, event.Username, subscription.Status)
+	return nil
+}
+
+func (s *subscriptionService) DowngradeExpired(ctx context.Context) (int, error) {
+	now := s.now()
+	log.Printf("Service DowngradeExpired called at: %v", now)
+
+	users, err := s.userRepo.FindExpiredSubscriptions(ctx, now)
+	if err != nil {
+		log.Printf("Repository error in DowngradeExpired: %v", err)
+		return 0, err
+	}
+
+	downgraded := 0
+	for _, user := range users {
+		if user.Subscription == nil {
+			continue
+		}
+		subscription := *user.Subscription
+		subscription.Status = enums.Cancelled
+		subscription.UpdatedAt = now
+
+		updated, err := s.userRepo.UpdateSubscription(ctx, user.ID.Hex(), user.Subscription, enums.Free, subscription)
+		if err != nil {
+			log.Printf("ERROR: Failed to downgrade username %s: %v", user.Username, err)
+			continue
+		}
+		// A renewal landed after the expired users were read; the next run sees the new expiry.
+		if !updated {
+			log.Printf("Subscription of username %s changed since it expired, skipping", user.Username)
+			continue
+		}
+		downgraded++
+		log.Printf("Downgraded username %s to FREE after subscription expired at %v", user.Username, subscription.ExpiresAt)
+	}
+
+	log.Printf("DowngradeExpired finished, downgraded %d of %d users", downgraded, len(users))
+	return downgraded, nil
+}
+
+func supportedPaymentEvent(eventType string) bool {
+	switch eventType {
+	case models.PaymentEventTrialStarted, models.PaymentEventSubscriptionActivated, models.PaymentEventInvoicePaid,
+		models.PaymentEventPaymentFailed, models.PaymentEventSubscriptionCancelled:
+		return true
+	}
+	return false
+}
+
+// eventAfter orders events by (OccurredAt, ID), so events sharing a timestamp with the last
+// applied one are still applied in a deterministic order instead of being dropped.
+func eventAfter(event models.PaymentEvent, subscription models.Subscription) bool {
+	if !event.OccurredAt.Equal(subscription.LastEventAt) {
+		return event.OccurredAt.After(subscription.LastEventAt)
+	}
+	return event.ID > subscription.LastEventID
+}
+
+func errSubscriptionChanged(username string) error {
+	log.Printf("Subscription of username %s was changed by another request", username)
+	return &models.ConflictError{Field: "subscription", Value: username, Reason: "Subscription was changed by another request, try again"}
+}
+
+func (s *subscriptionService) findUser(ctx context.Context, username string) (models.User, error) {
+	if strings.TrimSpace(username) == "" {
+		return models.User{}, errors.New("username cannot be empty")
+	}
+
+	user, found, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("Repository error looking up username %s: %v", username, err)
+		return models.User{}, err
+	}
+	if !found {
+		log.Printf("User not found with username: %s", username)
+		return models.User{}, errors.New("user not found")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,522 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var subscriptionTestNow = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestSubscriptionService(repo *mockUserRepository) *subscriptionService {
+	service := NewSubscriptionService(repo).(*subscriptionService)
+	service.now = func() time.Time { return subscriptionTestNow }
+	return service
+}
+
+func TestSubscriptionService_GetSubscription_Success(t *testing.T) {
+	expected := models.Subscription{Plan: enums.Premium, Status: enums.Active}
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username, Subscription: &expected}, true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	subscription, err := service.GetSubscription(context.Background(), "testuser")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if subscription.Status != enums.Active {
+		t.Errorf("Expected status ACTIVE, got %s", subscription.Status)
+	}
+}
+
+func TestSubscriptionService_GetSubscription_NoSubscription(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username}, true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	_, err := service.GetSubscription(context.Background(), "testuser")
+
+	if err == nil || err.Error() != "subscription not found" {
+		t.Errorf("Expected 'subscription not found', got %v", err)
+	}
+}
+
+func TestSubscriptionService_GetSubscription_UserNotFound(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{}, false, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	_, err := service.GetSubscription(context.Background(), "missing")
+
+	if err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected 'user not found', got %v", err)
+	}
+}
+
+func TestSubscriptionService_GetSubscription_EmptyUsername(t *testing.T) {
+	service := newTestSubscriptionService(&mockUserRepository{})
+	_, err := service.GetSubscription(context.Background(), " ")
+
+	if err == nil || err.Error() != "username cannot be empty" {
+		t.Errorf("Expected 'username cannot be empty', got %v", err)
+	}
+}
+
+func TestSubscriptionService_CancelSubscription_KeepsRoleUntilExpiry(t *testing.T) {
+	userID := primitive.NewObjectID()
+	expiresAt := subscriptionTestNow.Add(10 * 24 * time.Hour)
+
+	var savedRole enums.RoleEnum
+	var saved models.Subscription
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID:           userID,
+				Username:     username,
+				Role:         enums.Premium,
+				Subscription: &models.Subscription{Plan: enums.Premium, Status: enums.Active, ExpiresAt: expiresAt},
+			}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			savedRole = role
+			saved = subscription
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	_, err := service.CancelSubscription(context.Background(), "testuser")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if savedRole != enums.Premium {
+		t.Errorf("Expected role to stay PREMIUM, got %s", savedRole)
+	}
+	if saved.Status != enums.Cancelled {
+		t.Errorf("Expected status CANCELLED, got %s", saved.Status)
+	}
+	if !saved.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Expected expiry to be kept, got %v", saved.ExpiresAt)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_TrialStarted(t *testing.T) {
+	periodEnd := subscriptionTestNow.Add(14 * 24 * time.Hour)
+
+	var savedRole enums.RoleEnum
+	var saved models.Subscription
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username, Role: enums.Free}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			savedRole = role
+			saved = subscription
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_1",
+		Type:       models.PaymentEventTrialStarted,
+		Username:   "testuser",
+		PeriodEnd:  periodEnd,
+		OccurredAt: subscriptionTestNow,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if savedRole != enums.Premium {
+		t.Errorf("Expected role PREMIUM, got %s", savedRole)
+	}
+	if saved.Status != enums.Trial {
+		t.Errorf("Expected status TRIAL, got %s", saved.Status)
+	}
+	if !saved.StartedAt.Equal(subscriptionTestNow) {
+		t.Errorf("Expected start date %v, got %v", subscriptionTestNow, saved.StartedAt)
+	}
+	if !saved.ExpiresAt.Equal(periodEnd) {
+		t.Errorf("Expected expiry %v, got %v", periodEnd, saved.ExpiresAt)
+	}
+	if saved.LastEventID != "evt_1" {
+		t.Errorf("Expected last event ID 'evt_1', got %s", saved.LastEventID)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_InvoicePaidRenews(t *testing.T) {
+	startedAt := subscriptionTestNow.Add(-30 * 24 * time.Hour)
+	periodEnd := subscriptionTestNow.Add(30 * 24 * time.Hour)
+
+	var saved models.Subscription
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID:           primitive.NewObjectID(),
+				Username:     username,
+				Role:         enums.Premium,
+				Subscription: &models.Subscription{Plan: enums.Premium, Status: enums.PastDue, StartedAt: startedAt, ExpiresAt: subscriptionTestNow},
+			}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			saved = subscription
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_2",
+		Type:       models.PaymentEventInvoicePaid,
+		Username:   "testuser",
+		PeriodEnd:  periodEnd,
+		OccurredAt: subscriptionTestNow,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Status != enums.Active {
+		t.Errorf("Expected status ACTIVE, got %s", saved.Status)
+	}
+	if !saved.StartedAt.Equal(startedAt) {
+		t.Errorf("Expected start date to be kept, got %v", saved.StartedAt)
+	}
+	if !saved.ExpiresAt.Equal(periodEnd) {
+		t.Errorf("Expected expiry %v, got %v", periodEnd, saved.ExpiresAt)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_PaymentFailed(t *testing.T) {
+	var saved models.Subscription
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID:           primitive.NewObjectID(),
+				Username:     username,
+				Role:         enums.Premium,
+				Subscription: &models.Subscription{Plan: enums.Premium, Status: enums.Active},
+			}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			saved = subscription
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_3",
+		Type:       models.PaymentEventPaymentFailed,
+		Username:   "testuser",
+		OccurredAt: subscriptionTestNow,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Status != enums.PastDue {
+		t.Errorf("Expected status PAST_DUE, got %s", saved.Status)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_DuplicateEventIgnored(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID:           primitive.NewObjectID(),
+				Username:     username,
+				Subscription: &models.Subscription{Status: enums.Active, LastEventID: "evt_4"},
+			}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			t.Error("Expected duplicate event not to be applied")
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_4",
+		Type:       models.PaymentEventSubscriptionCancelled,
+		Username:   "testuser",
+		OccurredAt: subscriptionTestNow,
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_StaleEventIgnored(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID:           primitive.NewObjectID(),
+				Username:     username,
+				Role:         enums.Premium,
+				Subscription: &models.Subscription{Status: enums.Active, LastEventID: "evt_9", LastEventAt: subscriptionTestNow},
+			}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			t.Error("Expected an event older than the last applied one not to be applied")
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_8",
+		Type:       models.PaymentEventPaymentFailed,
+		Username:   "testuser",
+		OccurredAt: subscriptionTestNow.Add(-time.Hour),
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_SameTimestampOrderedByID(t *testing.T) {
+	applied := 0
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID:           primitive.NewObjectID(),
+				Username:     username,
+				Role:         enums.Premium,
+				Subscription: &models.Subscription{Status: enums.Active, LastEventID: "evt_5", LastEventAt: subscriptionTestNow},
+			}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			applied++
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	for _, id := range []string{"evt_6", "evt_4"} {
+		err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+			ID:         id,
+			Type:       models.PaymentEventPaymentFailed,
+			Username:   "testuser",
+			OccurredAt: subscriptionTestNow,
+		})
+		if err != nil {
+			t.Errorf("Expected no error for %s, got %v", id, err)
+		}
+	}
+
+	if applied != 1 {
+		t.Errorf("Expected only the event ordered after the last applied one to be applied, got %d", applied)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_MissingOccurredAt(t *testing.T) {
+	service := newTestSubscriptionService(&mockUserRepository{})
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{ID: "evt_7", Type: models.PaymentEventInvoicePaid, Username: "testuser"})
+
+	if err == nil || !strings.Contains(err.Error(), "invalid event") {
+		t.Errorf("Expected an invalid event error, got %v", err)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_UnsupportedType(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			t.Error("Expected unsupported events to be rejected before looking up the user")
+			return models.User{}, false, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_5",
+		Type:       "customer.updated",
+		Username:   "testuser",
+		OccurredAt: subscriptionTestNow,
+	})
+
+	if err == nil || err.Error() != "unsupported event type" {
+		t.Errorf("Expected 'unsupported event type', got %v", err)
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_MissingPeriodEnd(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username}, true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{
+		ID:         "evt_6",
+		Type:       models.PaymentEventSubscriptionActivated,
+		Username:   "testuser",
+		OccurredAt: subscriptionTestNow,
+	})
+
+	if err == nil {
+		t.Error("Expected error for missing period end, got nil")
+	}
+}
+
+func TestSubscriptionService_HandlePaymentEvent_EmptyEventID(t *testing.T) {
+	service := newTestSubscriptionService(&mockUserRepository{})
+	err := service.HandlePaymentEvent(context.Background(), models.PaymentEvent{Username: "testuser"})
+
+	if err == nil || err.Error() != "event ID cannot be empty" {
+		t.Errorf("Expected 'event ID cannot be empty', got %v", err)
+	}
+}
+
+func TestSubscriptionService_DowngradeExpired(t *testing.T) {
+	expired := []models.User{
+		{ID: primitive.NewObjectID(), Username: "one", Role: enums.Premium, Subscription: &models.Subscription{Status: enums.Active}},
+		{ID: primitive.NewObjectID(), Username: "two", Role: enums.Premium, Subscription: &models.Subscription{Status: enums.PastDue}},
+	}
+
+	var downgradedRoles []enums.RoleEnum
+	mockRepo := &mockUserRepository{
+		findExpiredSubscriptionsFunc: func(ctx context.Context, now time.Time) ([]models.User, error) {
+			if !now.Equal(subscriptionTestNow) {
+				t.Errorf("Expected reference time %v, got %v", subscriptionTestNow, now)
+			}
+			return expired, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			if subscription.Status != enums.Cancelled {
+				t.Errorf("Expected status CANCELLED, got %s", subscription.Status)
+			}
+			downgradedRoles = append(downgradedRoles, role)
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	count, err := service.DowngradeExpired(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 downgrades, got %d", count)
+	}
+	for _, role := range downgradedRoles {
+		if role != enums.Free {
+			t.Errorf("Expected role FREE, got %s", role)
+		}
+	}
+}
+
+func TestSubscriptionService_DowngradeExpired_ContinuesAfterFailure(t *testing.T) {
+	expired := []models.User{
+		{ID: primitive.NewObjectID(), Username: "one", Subscription: &models.Subscription{}},
+		{ID: primitive.NewObjectID(), Username: "two", Subscription: &models.Subscription{}},
+	}
+
+	calls := 0
+	mockRepo := &mockUserRepository{
+		findExpiredSubscriptionsFunc: func(ctx context.Context, now time.Time) ([]models.User, error) {
+			return expired, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			calls++
+			if calls == 1 {
+				return false, errors.New("update failed")
+			}
+			return true, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	count, err := service.DowngradeExpired(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 downgrade, got %d", count)
+	}
+}
+
+func TestSubscriptionService_DowngradeExpired_SkipsChangedSubscription(t *testing.T) {
+	renewed := &models.Subscription{Status: enums.Active, LastEventID: "evt-1"}
+	expired := []models.User{
+		{ID: primitive.NewObjectID(), Username: "renewed", Subscription: renewed},
+		{ID: primitive.NewObjectID(), Username: "expired", Subscription: &models.Subscription{}},
+	}
+
+	mockRepo := &mockUserRepository{
+		findExpiredSubscriptionsFunc: func(ctx context.Context, now time.Time) ([]models.User, error) {
+			return expired, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			// The renewal was applied after the expired users were read.
+			return expected != renewed, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	count, err := service.DowngradeExpired(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 downgrade, got %d", count)
+	}
+}
+
+func TestSubscriptionService_CancelSubscription_ChangedConcurrently(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username, Subscription: &models.Subscription{Status: enums.Active}}, true, nil
+		},
+		updateSubscriptionFunc: func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+			return false, nil
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	_, err := service.CancelSubscription(context.Background(), "testuser")
+
+	var conflict *models.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Expected a conflict error, got %v", err)
+	}
+}
+
+func TestSubscriptionService_DowngradeExpired_RepositoryError(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findExpiredSubscriptionsFunc: func(ctx context.Context, now time.Time) ([]models.User, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	service := newTestSubscriptionService(mockRepo)
+	_, err := service.DowngradeExpired(context.Background())
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"jboard-go-crud/internal/models"
+	"jboard-go-crud/internal/models/enums"
+	"log"
+	"sort"
+	"strings"
+)
+
+// readOnlyUserFields cannot be patched; some have a dedicated endpoint instead.
+var readOnlyUserFields = map[string]string{
+	"id":            "is read-only",
+	"username":      "is read-only, use POST /v1/users/rename",
+	"email":         "is read-only, use PUT /v1/auth/email",
+	"emailVerified": "is read-only",
+	"subscription":  "is read-only",
+}
+
+// PatchUser applies a JSON Merge Patch (RFC 7396) to the user. Users may patch their own
+// password and profile; only admins may change the role.
+func (s *userService) PatchUser(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error) {
+	log.Printf("Service PatchUser called for ID: %s by %s (admin: %t)", id, actor.Username, actor.Admin)
+
+	if strings.TrimSpace(id) == "" {
+		return models.User{}, errors.New("user ID cannot be empty")
+	}
+	if patch == nil {
+		return models.User{}, &models.ValidationError{Fields: []models.FieldError{{Field: "body", Message: "must be a JSON object"}}}
+	}
+
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return models.User{}, err
+	}
+	if !actor.Admin && !strings.EqualFold(actor.Username, user.Username) {
+		log.Printf("User %s tried to patch user %s", actor.Username, user.Username)
+		return models.User{}, &models.ForbiddenError{Reason: "users may only patch their own account"}
+	}
+
+	fields := make([]string, 0, len(patch))
+	for field := range patch {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	if !actor.Admin {
+		if _, found := patch["role"]; found {
+			log.Printf("User %s tried to patch their own role", actor.Username)
+			return models.User{}, &models.ForbiddenError{Reason: "users may not change these fields on their own account", Fields: []string{"role"}}
+		}
+	}
+
+	var fieldErrors []models.FieldError
+	for _, field := range fields {
+		value := patch[field]
+		switch field {
+		case "password":
+			fieldErrors = append(fieldErrors, s.patchPassword(&user, value)...)
+		case "role":
+			fieldErrors = append(fieldErrors, patchRole(&user, value)...)
+		case "profile":
+			fieldErrors = append(fieldErrors, patchProfile(&user, value)...)
+		default:
+			message, readOnly := readOnlyUserFields[field]
+			if !readOnly {
+				message = "is not a known field"
+			}
+			fieldErrors = append(fieldErrors, models.FieldError{Field: field, Message: message})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		log.Printf("Patch for user %s rejected: %d invalid fields", user.Username, len(fieldErrors))
+		return models.User{}, &models.ValidationError{Fields: fieldErrors}
+	}
+
+	// Only the patched fields are written, so a concurrent rename or email change is not undone.
+	if err := s.userRepo.UpdateFields(ctx, user.ID.Hex(), user, fields...); err != nil {
+		log.Printf("Repository error in PatchUser: %v", err)
+		return models.User{}, err
+	}
+
+	user.Password = ""
+	log.Printf("Successfully patched fields %v of user: %s", fields, user.Username)
+	return user, nil
+}
+
+func (s *userService) patchPassword(user *models.User, value any) []models.FieldError {
+	password, ok := value.(string)
+	if !ok {
+		return []models.FieldError{{Field: "password", Message: "must be a string"}}
+	}
+	if strings.TrimSpace(password) == "" {
+		return []models.FieldError{{Field: "password", Message: "cannot be empty"}}
+	}
+	if err := s.passwordPolicy.Validate(password); err != nil {
+		return []models.FieldError{{Field: "password", Message: strings.TrimPrefix(err.Error(), "invalid password: ")}}
+	}
+	user.Password = password
+	return nil
+}
+
+func patchRole(user *models.User, value any) []models.FieldError {
+	role, ok := value.(string)
+	if !ok || !enums.RoleEnum(role).IsValid() {
+		return []models.FieldError{{Field: "role", Message: "must be one of FREE, PREMIUM"}}
+	}
+	user.Role = enums.RoleEnum(role)
+	return nil
+}
+
+// patchProfile merges the patch into the stored profile; a null patch removes the profile.
+func patchProfile(user *models.User, value any) []models.FieldError {
+	if value == nil {
+		user.Profile = nil
+		return nil
+	}
+	if _, ok := value.(map[string]any); !ok {
+		return []models.FieldError{{Field: "profile", Message: "must be an object"}}
+	}
+
+	var current any = map[string]any{}
+	if user.Profile != nil {
+		encoded, err := json.Marshal(user.Profile)
+		if err != nil {
+			return []models.FieldError{{Field: "profile", Message: "could not be read"}}
+		}
+		if err := json.Unmarshal(encoded, &current); err != nil {
+			return []models.FieldError{{Field: "profile", Message: "could not be read"}}
+		}
+	}
+
+	merged, err := json.Marshal(mergePatch(current, value))
+	if err != nil {
+		return []models.FieldError{{Field: "profile", Message: "could not be merged"}}
+	}
+
+	var profile models.UserProfile
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profile); err != nil {
+		return []models.FieldError{profileDecodeError(err)}
+	}
+
+	profile = profile.Normalized()
+	if fieldErrors := profile.FieldErrors("profile."); len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+	user.Profile = &profile
+	return nil
+}
+
+func profileDecodeError(err error) models.FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return models.FieldError{Field: "profile." + typeErr.Field, Message: "must be a " + typeErr.Type.String()}
+	}
+	if name, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+		return models.FieldError{Field: "profile." + strings.Trim(name, `"`), Message: "is not a known field"}
+	}
+	return models.FieldError{Field: "profile", Message: "is malformed"}
+}
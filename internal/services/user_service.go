@@ -8,6 +8,7 @@ import (
 	"jboard-go-crud/internal/repositories"
 	"log"
 	"strings"
+	"time"
 )
 
 type UserService interface {
@@ -16,16 +17,35 @@ type UserService interface {
 	GetUserByUsername(ctx context.Context, username string) (models.User, error)
 	UpdateUser(ctx context.Context, username string, password string, role enums.RoleEnum) (models.User, error)
 	DeleteUser(ctx context.Context, username string) error
+	ExportUserData(ctx context.Context, username string) (models.UserDataExport, error)
+	RenameUser(ctx context.Context, username, newUsername string) (models.User, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) (models.UserPage, error)
+	GetProfile(ctx context.Context, username string) (models.UserProfile, error)
+	UpdateProfile(ctx context.Context, username string, patch models.UserProfilePatch) (models.UserProfile, error)
+	PatchUser(ctx context.Context, actor models.UserPatchActor, id string, patch map[string]any) (models.User, error)
 }
 
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	txManager      repositories.TransactionManager
+	passwordPolicy models.PasswordPolicy
+	dataStores     []repositories.UserDataStore
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
-	log.Printf("Creating new UserService")
+// NewUserService builds the user service. dataStores lists every repository holding
+// user-owned documents; they are purged on account deletion and included in data exports.
+func NewUserService(userRepo repositories.UserRepository, txManager repositories.TransactionManager, passwordPolicy models.PasswordPolicy, dataStores ...repositories.UserDataStore) UserService {
+	log.Printf("Creating new UserService with %d user data stores", len(dataStores))
 	return &userService{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		txManager:      txManager,
+		passwordPolicy: passwordPolicy,
+		dataStores:     dataStores,
 	}
 }
 
@@ -38,6 +58,9 @@ func (s *userService) CreateUser(ctx context.Context, username, password string,
 	if strings.TrimSpace(password) == "" {
 		return errors.New("password cannot be empty")
 	}
+	if err := s.passwordPolicy.Validate(password); err != nil {
+		return err
+	}
 	if !role.IsValid() {
 		return errors.New("invalid role")
 	}
@@ -49,7 +72,7 @@ func (s *userService) CreateUser(ctx context.Context, username, password string,
 	}
 	if exists {
 		log.Printf("Username already exists: %s", username)
-		return errors.New("username already exists")
+		return &models.ConflictError{Field: "username", Value: username}
 	}
 
 	user := models.User{
@@ -120,6 +143,11 @@ func (s *userService) UpdateUser(ctx context.Context, username string, password
 	if !role.IsValid() {
 		return models.User{}, errors.New("invalid role")
 	}
+	if strings.TrimSpace(password) != "" {
+		if err := s.passwordPolicy.Validate(password); err != nil {
+			return models.User{}, err
+		}
+	}
 
 	existingUser, found, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
@@ -131,11 +159,10 @@ func (s *userService) UpdateUser(ctx context.Context, username string, password
 		return models.User{}, errors.New("user not found")
 	}
 
-	updatedUser := models.User{
-		ID:       existingUser.ID,
-		Username: username,
-		Role:     role,
-	}
+	// Start from the stored document so that fields this endpoint does not manage survive.
+	updatedUser := existingUser
+	updatedUser.Username = username
+	updatedUser.Role = role
 
 	if strings.TrimSpace(password) != "" {
 		updatedUser.Password = password
@@ -173,11 +200,193 @@ func (s *userService) DeleteUser(ctx context.Context, username string) error {
 	}
 
 	idString := existingUser.ID.Hex()
-	if err := s.userRepo.DeleteByID(ctx, idString); err != nil {
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		for _, store := range s.dataStores {
+			if err := store.DeleteByUsername(txCtx, username); err != nil {
+				log.Printf("Repository error deleting %s for username %s: %v", store.Name(), username, err)
+				return err
+			}
+		}
+		return s.userRepo.DeleteByID(txCtx, idString)
+	})
+	if err != nil {
 		log.Printf("Repository error in DeleteUser: %v", err)
 		return err
 	}
 
-	log.Printf("Successfully deleted user: %s", username)
+	log.Printf("Successfully deleted user and all owned data: %s", username)
 	return nil
 }
+
+func (s *userService) ExportUserData(ctx context.Context, username string) (models.UserDataExport, error) {
+	log.Printf("Service ExportUserData called for Username: %s", username)
+
+	user, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.UserDataExport{}, err
+	}
+	user.Password = ""
+
+	export := models.UserDataExport{
+		GeneratedAt: time.Now().UTC(),
+		User:        user,
+		Data:        make(map[string]any, len(s.dataStores)),
+	}
+	for _, store := range s.dataStores {
+		data, err := store.ExportByUsername(ctx, username)
+		if err != nil {
+			log.Printf("Repository error exporting %s for username %s: %v", store.Name(), username, err)
+			return models.UserDataExport{}, err
+		}
+		export.Data[store.Name()] = data
+	}
+
+	log.Printf("Successfully exported data for user: %s", username)
+	return export, nil
+}
+
+func (s *userService) RenameUser(ctx context.Context, username, newUsername string) (models.User, error) {
+	log.Printf("Service RenameUser called for Username: %s, new Username: %s", username, newUsername)
+
+	newUsername = strings.TrimSpace(newUsername)
+	if strings.TrimSpace(username) == "" || newUsername == "" {
+		return models.User{}, errors.New("username cannot be empty")
+	}
+	if username == newUsername {
+		return models.User{}, errors.New("invalid new username: must differ from the current one")
+	}
+
+	existingUser, found, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		log.Printf("Repository error checking existing user: %v", err)
+		return models.User{}, err
+	}
+	if !found {
+		log.Printf("User not found for rename with Username: %s", username)
+		return models.User{}, errors.New("user not found")
+	}
+
+	// Usernames are case-insensitive, so a case-only change finds the user itself.
+	taken, exists, err := s.userRepo.FindByUsername(ctx, newUsername)
+	if err != nil {
+		log.Printf("Repository error checking new username: %v", err)
+		return models.User{}, err
+	}
+	if exists && taken.ID != existingUser.ID {
+		log.Printf("Username already exists: %s", newUsername)
+		return models.User{}, &models.ConflictError{Field: "username", Value: newUsername}
+	}
+
+	renamedUser := existingUser
+	renamedUser.Username = newUsername
+
+	idString := existingUser.ID.Hex()
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.UpdateByID(txCtx, idString, renamedUser); err != nil {
+			return err
+		}
+		for _, store := range s.dataStores {
+			if err := store.RenameUsername(txCtx, existingUser.Username, newUsername); err != nil {
+				log.Printf("Repository error renaming %s for username %s: %v", store.Name(), username, err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Repository error in RenameUser: %v", err)
+		return models.User{}, err
+	}
+
+	renamedUser.Password = ""
+
+	log.Printf("Successfully renamed user %s to %s", username, newUsername)
+	return renamedUser, nil
+}
+
+func (s *userService) ListUsers(ctx context.Context, filter models.UserFilter) (models.UserPage, error) {
+	log.Printf("Service ListUsers called with filter: %+v", filter)
+
+	if filter.Role != "" && !filter.Role.IsValid() {
+		return models.UserPage{}, errors.New("invalid role")
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return models.UserPage{}, errors.New("invalid date range: createdFrom must be before createdTo")
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultUserPageSize
+	}
+	if filter.PageSize > maxUserPageSize {
+		filter.PageSize = maxUserPageSize
+	}
+	filter.UsernamePrefix = strings.TrimSpace(filter.UsernamePrefix)
+
+	users, total, err := s.userRepo.FindUsers(ctx, filter)
+	if err != nil {
+		log.Printf("Repository error in ListUsers: %v", err)
+		return models.UserPage{}, err
+	}
+
+	roleCounts, err := s.userRepo.CountUsersByRole(ctx, filter)
+	if err != nil {
+		log.Printf("Repository error counting roles in ListUsers: %v", err)
+		return models.UserPage{}, err
+	}
+
+	items := make([]models.UserSummary, 0, len(users))
+	for _, user := range users {
+		items = append(items, models.NewUserSummary(user))
+	}
+
+	log.Printf("Successfully listed %d of %d users", len(items), total)
+	return models.UserPage{
+		Items:      items,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		Total:      total,
+		RoleCounts: roleCounts,
+	}, nil
+}
+
+func (s *userService) GetProfile(ctx context.Context, username string) (models.UserProfile, error) {
+	log.Printf("Service GetProfile called for username: %s", username)
+
+	user, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+	if user.Profile == nil {
+		return models.UserProfile{}, nil
+	}
+	return *user.Profile, nil
+}
+
+func (s *userService) UpdateProfile(ctx context.Context, username string, patch models.UserProfilePatch) (models.UserProfile, error) {
+	log.Printf("Service UpdateProfile called for username: %s", username)
+
+	user, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+
+	var current models.UserProfile
+	if user.Profile != nil {
+		current = *user.Profile
+	}
+	profile := patch.Apply(current)
+	if err := profile.Validate(); err != nil {
+		log.Printf("Profile validation failed for username %s: %v", username, err)
+		return models.UserProfile{}, err
+	}
+
+	if err := s.userRepo.UpdateProfile(ctx, user.ID.Hex(), profile); err != nil {
+		log.Printf("Repository error in UpdateProfile: %v", err)
+		return models.UserProfile{}, err
+	}
+
+	log.Printf("Successfully updated profile for user: %s", username)
+	return profile, nil
+}
@@ -6,7 +6,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"jboard-go-crud/internal/models"
 	"jboard-go-crud/internal/models/enums"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
 )
 
 type mockUserRepository struct {
@@ -14,7 +18,16 @@ type mockUserRepository struct {
 	findByIDFunc       func(ctx context.Context, id string) (models.User, bool, error)
 	findByUsernameFunc func(ctx context.Context, username string) (models.User, bool, error)
 	updateByIDFunc     func(ctx context.Context, id string, user models.User) error
+	updateFieldsFunc   func(ctx context.Context, id string, user models.User, fields ...string) error
 	deleteByIDFunc     func(ctx context.Context, id string) error
+
+	updateSubscriptionFunc       func(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error)
+	findExpiredSubscriptionsFunc func(ctx context.Context, now time.Time) ([]models.User, error)
+	findDuplicateUsernamesFunc   func(ctx context.Context) ([]models.DuplicateUsername, error)
+	findUsersFunc                func(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error)
+	countUsersByRoleFunc         func(ctx context.Context, filter models.UserFilter) (map[enums.RoleEnum]int64, error)
+	findByEmailFunc              func(ctx context.Context, email string) (models.User, bool, error)
+	updateProfileFunc            func(ctx context.Context, id string, profile models.UserProfile) error
 }
 
 func (m *mockUserRepository) Create(ctx context.Context, user models.User) error {
@@ -33,13 +46,76 @@ func (m *mockUserRepository) UpdateByID(ctx context.Context, id string, user mod
 	return m.updateByIDFunc(ctx, id, user)
 }
 
+func (m *mockUserRepository) UpdateFields(ctx context.Context, id string, user models.User, fields ...string) error {
+	return m.updateFieldsFunc(ctx, id, user, fields...)
+}
+
+// applyUserFields copies the named fields of user onto target, as UpdateFields stores them.
+func applyUserFields(target *models.User, user models.User, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case "password":
+			target.Password = user.Password
+		case "role":
+			target.Role = user.Role
+		case "email":
+			target.Email = user.Email
+		case "emailVerified":
+			target.EmailVerified = user.EmailVerified
+		case "profile":
+			target.Profile = user.Profile
+		}
+	}
+}
+
 func (m *mockUserRepository) DeleteByID(ctx context.Context, id string) error {
 	return m.deleteByIDFunc(ctx, id)
 }
 
+func (m *mockUserRepository) UpdateSubscription(ctx context.Context, id string, expected *models.Subscription, role enums.RoleEnum, subscription models.Subscription) (bool, error) {
+	return m.updateSubscriptionFunc(ctx, id, expected, role, subscription)
+}
+
+func (m *mockUserRepository) FindExpiredSubscriptions(ctx context.Context, now time.Time) ([]models.User, error) {
+	return m.findExpiredSubscriptionsFunc(ctx, now)
+}
+
+type mockTransactionManager struct {
+	calls int
+}
+
+func (m *mockTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+func (m *mockUserRepository) FindDuplicateUsernames(ctx context.Context) ([]models.DuplicateUsername, error) {
+	return m.findDuplicateUsernamesFunc(ctx)
+}
+
+func (m *mockUserRepository) EnsureUsernameIndex(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockUserRepository) FindUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error) {
+	return m.findUsersFunc(ctx, filter)
+}
+
+func (m *mockUserRepository) CountUsersByRole(ctx context.Context, filter models.UserFilter) (map[enums.RoleEnum]int64, error) {
+	return m.countUsersByRoleFunc(ctx, filter)
+}
+
+func (m *mockUserRepository) UpdateProfile(ctx context.Context, id string, profile models.UserProfile) error {
+	return m.updateProfileFunc(ctx, id, profile)
+}
+
+func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (models.User, bool, error) {
+	return m.findByEmailFunc(ctx, email)
+}
+
 func TestNewUserService(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 
 	if service == nil {
 		t.Error("Expected service to be created, got nil")
@@ -56,7 +132,7 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "testuser", "password123", enums.Free)
@@ -68,7 +144,7 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 
 func TestUserService_CreateUser_EmptyUsername(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "", "password123", enums.Free)
@@ -84,7 +160,7 @@ func TestUserService_CreateUser_EmptyUsername(t *testing.T) {
 
 func TestUserService_CreateUser_WhitespaceUsername(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "   ", "password123", enums.Free)
@@ -100,7 +176,7 @@ func TestUserService_CreateUser_WhitespaceUsername(t *testing.T) {
 
 func TestUserService_CreateUser_EmptyPassword(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "testuser", "", enums.Free)
@@ -116,7 +192,7 @@ func TestUserService_CreateUser_EmptyPassword(t *testing.T) {
 
 func TestUserService_CreateUser_WhitespacePassword(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "testuser", "   ", enums.Free)
@@ -132,7 +208,7 @@ func TestUserService_CreateUser_WhitespacePassword(t *testing.T) {
 
 func TestUserService_CreateUser_InvalidRole(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "testuser", "password123", "INVALID")
@@ -154,7 +230,7 @@ func TestUserService_CreateUser_UsernameAlreadyExists(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "existinguser", "password123", enums.Free)
@@ -175,7 +251,7 @@ func TestUserService_CreateUser_RepositoryFindError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "testuser", "password123", enums.Free)
@@ -199,7 +275,7 @@ func TestUserService_CreateUser_RepositoryCreateError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "testuser", "password123", enums.Free)
@@ -223,7 +299,7 @@ func TestUserService_CreateUser_PremiumRole(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.CreateUser(ctx, "premiumuser", "password123", enums.Premium)
@@ -251,7 +327,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	user, err := service.GetUserByID(ctx, testID.Hex())
@@ -267,7 +343,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 
 func TestUserService_GetUserByID_EmptyID(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByID(ctx, "")
@@ -283,7 +359,7 @@ func TestUserService_GetUserByID_EmptyID(t *testing.T) {
 
 func TestUserService_GetUserByID_WhitespaceID(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByID(ctx, "   ")
@@ -304,7 +380,7 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByID(ctx, primitive.NewObjectID().Hex())
@@ -325,7 +401,7 @@ func TestUserService_GetUserByID_RepositoryError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByID(ctx, primitive.NewObjectID().Hex())
@@ -354,7 +430,7 @@ func TestUserService_GetUserByUsername_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	user, err := service.GetUserByUsername(ctx, "testuser")
@@ -370,7 +446,7 @@ func TestUserService_GetUserByUsername_Success(t *testing.T) {
 
 func TestUserService_GetUserByUsername_EmptyUsername(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByUsername(ctx, "")
@@ -391,7 +467,7 @@ func TestUserService_GetUserByUsername_NotFound(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByUsername(ctx, "nonexistent")
@@ -412,7 +488,7 @@ func TestUserService_GetUserByUsername_RepositoryError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	_, err := service.GetUserByUsername(ctx, "testuser")
@@ -447,10 +523,10 @@ func TestUserService_UpdateUser_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
-	updatedUser, err := service.UpdateUser(ctx, "testuser", "newpass", enums.Premium)
+	updatedUser, err := service.UpdateUser(ctx, "testuser", "newpass123", enums.Premium)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -491,7 +567,7 @@ func TestUserService_UpdateUser_EmptyPassword(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	updatedUser, err := service.UpdateUser(ctx, "testuser", "", enums.Premium)
@@ -507,10 +583,10 @@ func TestUserService_UpdateUser_EmptyPassword(t *testing.T) {
 
 func TestUserService_UpdateUser_EmptyUsername(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
-	_, err := service.UpdateUser(ctx, "", "newpass", enums.Premium)
+	_, err := service.UpdateUser(ctx, "", "newpass123", enums.Premium)
 
 	if err == nil {
 		t.Error("Expected error for empty username, got nil")
@@ -523,10 +599,10 @@ func TestUserService_UpdateUser_EmptyUsername(t *testing.T) {
 
 func TestUserService_UpdateUser_InvalidRole(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
-	_, err := service.UpdateUser(ctx, "testuser", "newpass", "INVALID")
+	_, err := service.UpdateUser(ctx, "testuser", "newpass123", "INVALID")
 
 	if err == nil {
 		t.Error("Expected error for invalid role, got nil")
@@ -544,10 +620,10 @@ func TestUserService_UpdateUser_UserNotFound(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
-	_, err := service.UpdateUser(ctx, "nonexistent", "newpass", enums.Premium)
+	_, err := service.UpdateUser(ctx, "nonexistent", "newpass123", enums.Premium)
 
 	if err == nil {
 		t.Error("Expected error for user not found, got nil")
@@ -576,10 +652,10 @@ func TestUserService_UpdateUser_RepositoryError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
-	_, err := service.UpdateUser(ctx, "testuser", "newpass", enums.Premium)
+	_, err := service.UpdateUser(ctx, "testuser", "newpass123", enums.Premium)
 
 	if err == nil {
 		t.Error("Expected repository error, got nil")
@@ -611,7 +687,7 @@ func TestUserService_DeleteUser_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.DeleteUser(ctx, "testuser")
@@ -623,7 +699,7 @@ func TestUserService_DeleteUser_Success(t *testing.T) {
 
 func TestUserService_DeleteUser_EmptyUsername(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.DeleteUser(ctx, "")
@@ -644,7 +720,7 @@ func TestUserService_DeleteUser_UserNotFound(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.DeleteUser(ctx, "nonexistent")
@@ -665,7 +741,7 @@ func TestUserService_DeleteUser_RepositoryFindError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.DeleteUser(ctx, "testuser")
@@ -697,7 +773,7 @@ func TestUserService_DeleteUser_RepositoryDeleteError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
 	ctx := context.Background()
 
 	err := service.DeleteUser(ctx, "testuser")
@@ -710,3 +786,704 @@ func TestUserService_DeleteUser_RepositoryDeleteError(t *testing.T) {
 		t.Errorf("Expected 'delete failed', got %v", err)
 	}
 }
+
+func TestUserService_DeleteUser_CascadesToDataStores(t *testing.T) {
+	testID := primitive.NewObjectID()
+	deletedUser := false
+
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: testID, Username: username}, true, nil
+		},
+		deleteByIDFunc: func(ctx context.Context, id string) error {
+			deletedUser = true
+			return nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("DeleteByUsername", mock.Anything, "testuser").Return(nil)
+	txManager := &mockTransactionManager{}
+
+	service := NewUserService(mockRepo, txManager, models.DefaultPasswordPolicy(), skillRepo)
+	err := service.DeleteUser(context.Background(), "testuser")
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if !deletedUser {
+		t.Error("Expected user document to be deleted")
+	}
+	if txManager.calls != 1 {
+		t.Errorf("Expected deletion to run in one transaction, got %d", txManager.calls)
+	}
+	skillRepo.AssertExpectations(t)
+}
+
+func TestUserService_DeleteUser_ClearsLoginLockout(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username}, true, nil
+		},
+		deleteByIDFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+	attemptRepo := newFakeLoginAttemptRepository()
+	attemptRepo.attempts["user:testuser"] = models.LoginAttempt{Key: "user:testuser", Failures: 3}
+	attemptRepo.attempts["ip:10.0.0.1"] = models.LoginAttempt{Key: "ip:10.0.0.1", Failures: 3}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy(), attemptRepo)
+	if err := service.DeleteUser(context.Background(), "TestUser"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, found := attemptRepo.attempts["user:testuser"]; found {
+		t.Error("Expected the username lockout counter to be deleted with the user")
+	}
+	if _, found := attemptRepo.attempts["ip:10.0.0.1"]; !found {
+		t.Error("Expected shared IP counters to be kept")
+	}
+}
+
+func TestUserService_DeleteUser_DataStoreErrorKeepsUser(t *testing.T) {
+	testID := primitive.NewObjectID()
+
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: testID, Username: username}, true, nil
+		},
+		deleteByIDFunc: func(ctx context.Context, id string) error {
+			t.Error("Expected user not to be deleted when owned data deletion fails")
+			return nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("DeleteByUsername", mock.Anything, "testuser").Return(errors.New("skills delete failed"))
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy(), skillRepo)
+	err := service.DeleteUser(context.Background(), "testuser")
+
+	if err == nil || err.Error() != "skills delete failed" {
+		t.Errorf("Expected 'skills delete failed', got %v", err)
+	}
+}
+
+func TestUserService_ExportUserData_Success(t *testing.T) {
+	testID := primitive.NewObjectID()
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: testID, Username: username, Password: "secret", Role: enums.Free}, true, nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("ExportByUsername", mock.Anything, "testuser").Return([]string{"go"}, nil)
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy(), skillRepo)
+	export, err := service.ExportUserData(context.Background(), "testuser")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if export.User.Password != "" {
+		t.Error("Expected password to be omitted from export")
+	}
+	if export.User.Username != "testuser" {
+		t.Errorf("Expected username 'testuser', got %s", export.User.Username)
+	}
+	skills, ok := export.Data["skills"].([]string)
+	if !ok || len(skills) != 1 || skills[0] != "go" {
+		t.Errorf("Expected exported skills [go], got %v", export.Data["skills"])
+	}
+	if export.GeneratedAt.IsZero() {
+		t.Error("Expected generation timestamp to be set")
+	}
+}
+
+func TestUserService_ExportUserData_UserNotFound(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{}, false, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.ExportUserData(context.Background(), "missing")
+
+	if err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected 'user not found', got %v", err)
+	}
+}
+
+func TestUserService_ExportUserData_DataStoreError(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username}, true, nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("ExportByUsername", mock.Anything, "testuser").Return(nil, errors.New("database error"))
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy(), skillRepo)
+	_, err := service.ExportUserData(context.Background(), "testuser")
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func TestUserService_CreateUser_ConflictIsTyped(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{}, false, nil
+		},
+		createFunc: func(ctx context.Context, user models.User) error {
+			// Lost the race against a concurrent request; the unique index rejected the insert.
+			return &models.ConflictError{Field: "username", Value: user.Username}
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	err := service.CreateUser(context.Background(), "testuser", "password123", enums.Free)
+
+	var conflict *models.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected ConflictError, got %v", err)
+	}
+	if conflict.Value != "testuser" {
+		t.Errorf("Expected conflicting value 'testuser', got %s", conflict.Value)
+	}
+}
+
+func TestUserService_RenameUser_Success(t *testing.T) {
+	testID := primitive.NewObjectID()
+	var saved models.User
+
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			if username == "typo" {
+				return models.User{ID: testID, Username: "typo", Password: "secret", Role: enums.Premium}, true, nil
+			}
+			return models.User{}, false, nil
+		},
+		updateByIDFunc: func(ctx context.Context, id string, user models.User) error {
+			saved = user
+			return nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("RenameUsername", mock.Anything, "typo", "fixed").Return(nil)
+	txManager := &mockTransactionManager{}
+
+	service := NewUserService(mockRepo, txManager, models.DefaultPasswordPolicy(), skillRepo)
+	user, err := service.RenameUser(context.Background(), "typo", "fixed")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Username != "fixed" || user.Password != "" {
+		t.Errorf("Expected renamed user without password, got %+v", user)
+	}
+	if saved.Username != "fixed" || saved.Password != "secret" || saved.Role != enums.Premium {
+		t.Errorf("Expected stored user to keep password and role, got %+v", saved)
+	}
+	if txManager.calls != 1 {
+		t.Errorf("Expected rename to run in one transaction, got %d", txManager.calls)
+	}
+	skillRepo.AssertExpectations(t)
+}
+
+func TestUserService_RenameUser_CaseOnlyChange(t *testing.T) {
+	testID := primitive.NewObjectID()
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: testID, Username: "bob"}, true, nil
+		},
+		updateByIDFunc: func(ctx context.Context, id string, user models.User) error {
+			return nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("RenameUsername", mock.Anything, "bob", "Bob").Return(nil)
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy(), skillRepo)
+	user, err := service.RenameUser(context.Background(), "bob", "Bob")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Username != "Bob" {
+		t.Errorf("Expected username 'Bob', got %s", user.Username)
+	}
+}
+
+func TestUserService_RenameUser_UsernameTaken(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username}, true, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.RenameUser(context.Background(), "alice", "bob")
+
+	var conflict *models.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Expected ConflictError, got %v", err)
+	}
+}
+
+func TestUserService_RenameUser_UserNotFound(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{}, false, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.RenameUser(context.Background(), "ghost", "bob")
+
+	if err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected 'user not found', got %v", err)
+	}
+}
+
+func TestUserService_RenameUser_SameUsername(t *testing.T) {
+	service := NewUserService(&mockUserRepository{}, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.RenameUser(context.Background(), "bob", "bob")
+
+	if err == nil {
+		t.Error("Expected error for unchanged username, got nil")
+	}
+}
+
+func TestUserService_RenameUser_EmptyNewUsername(t *testing.T) {
+	service := NewUserService(&mockUserRepository{}, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.RenameUser(context.Background(), "bob", "  ")
+
+	if err == nil || err.Error() != "username cannot be empty" {
+		t.Errorf("Expected 'username cannot be empty', got %v", err)
+	}
+}
+
+func TestUserService_RenameUser_DataStoreError(t *testing.T) {
+	testID := primitive.NewObjectID()
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			if username == "alice" {
+				return models.User{ID: testID, Username: "alice"}, true, nil
+			}
+			return models.User{}, false, nil
+		},
+		updateByIDFunc: func(ctx context.Context, id string, user models.User) error {
+			return nil
+		},
+	}
+	skillRepo := new(MockSkillRepository)
+	skillRepo.On("RenameUsername", mock.Anything, "alice", "alicia").Return(errors.New("skills rename failed"))
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy(), skillRepo)
+	_, err := service.RenameUser(context.Background(), "alice", "alicia")
+
+	if err == nil || err.Error() != "skills rename failed" {
+		t.Errorf("Expected 'skills rename failed', got %v", err)
+	}
+}
+
+func TestUserService_ListUsers_Success(t *testing.T) {
+	var receivedFilter models.UserFilter
+	mockRepo := &mockUserRepository{
+		findUsersFunc: func(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error) {
+			receivedFilter = filter
+			return []models.User{
+				{ID: primitive.NewObjectID(), Username: "alice", Password: "secret", Role: enums.Premium},
+			}, 1, nil
+		},
+		countUsersByRoleFunc: func(ctx context.Context, filter models.UserFilter) (map[enums.RoleEnum]int64, error) {
+			return map[enums.RoleEnum]int64{enums.Free: 3, enums.Premium: 1}, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	page, err := service.ListUsers(context.Background(), models.UserFilter{UsernamePrefix: " al "})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if receivedFilter.Page != 1 || receivedFilter.PageSize != defaultUserPageSize {
+		t.Errorf("Expected default pagination, got page %d size %d", receivedFilter.Page, receivedFilter.PageSize)
+	}
+	if receivedFilter.UsernamePrefix != "al" {
+		t.Errorf("Expected trimmed prefix 'al', got '%s'", receivedFilter.UsernamePrefix)
+	}
+	if len(page.Items) != 1 || page.Items[0].Username != "alice" {
+		t.Errorf("Expected one item for alice, got %+v", page.Items)
+	}
+	if page.Items[0].CreatedAt.IsZero() {
+		t.Error("Expected creation date derived from the ID")
+	}
+	if page.Total != 1 || page.RoleCounts[enums.Free] != 3 {
+		t.Errorf("Expected totals and role counts to be returned, got %+v", page)
+	}
+}
+
+func TestUserService_ListUsers_CapsPageSize(t *testing.T) {
+	var receivedFilter models.UserFilter
+	mockRepo := &mockUserRepository{
+		findUsersFunc: func(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error) {
+			receivedFilter = filter
+			return nil, 0, nil
+		},
+		countUsersByRoleFunc: func(ctx context.Context, filter models.UserFilter) (map[enums.RoleEnum]int64, error) {
+			return map[enums.RoleEnum]int64{}, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	page, err := service.ListUsers(context.Background(), models.UserFilter{Page: 3, PageSize: 1000})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if receivedFilter.PageSize != maxUserPageSize || receivedFilter.Page != 3 {
+		t.Errorf("Expected page 3 capped at %d, got page %d size %d", maxUserPageSize, receivedFilter.Page, receivedFilter.PageSize)
+	}
+	if page.Items == nil {
+		t.Error("Expected empty items slice instead of nil")
+	}
+}
+
+func TestUserService_ListUsers_InvalidRole(t *testing.T) {
+	service := NewUserService(&mockUserRepository{}, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.ListUsers(context.Background(), models.UserFilter{Role: "ADMIN"})
+
+	if err == nil || err.Error() != "invalid role" {
+		t.Errorf("Expected 'invalid role', got %v", err)
+	}
+}
+
+func TestUserService_ListUsers_InvalidDateRange(t *testing.T) {
+	service := NewUserService(&mockUserRepository{}, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	now := time.Now()
+	_, err := service.ListUsers(context.Background(), models.UserFilter{CreatedFrom: now, CreatedTo: now.Add(-time.Hour)})
+
+	if err == nil {
+		t.Error("Expected error for inverted date range, got nil")
+	}
+}
+
+func TestUserService_ListUsers_RepositoryError(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findUsersFunc: func(ctx context.Context, filter models.UserFilter) ([]models.User, int64, error) {
+			return nil, 0, errors.New("database error")
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	_, err := service.ListUsers(context.Background(), models.UserFilter{})
+
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("Expected 'database error', got %v", err)
+	}
+}
+
+func TestUserService_UpdateProfile_MergesPatch(t *testing.T) {
+	userID := primitive.NewObjectID()
+	var saved models.UserProfile
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: userID, Username: username, Profile: &models.UserProfile{
+				DisplayName: "Old Name",
+				Location:    "Recife",
+			}}, true, nil
+		},
+		updateProfileFunc: func(ctx context.Context, id string, profile models.UserProfile) error {
+			if id != userID.Hex() {
+				t.Errorf("Expected ID %s, got %s", userID.Hex(), id)
+			}
+			saved = profile
+			return nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	name := "  New Name "
+	fields := []string{"Engineering", "engineering", " Data "}
+	currency := "brl"
+	floor := int64(10000)
+	profile, err := service.UpdateProfile(context.Background(), "testuser", models.UserProfilePatch{
+		DisplayName:     &name,
+		PreferredFields: &fields,
+		SalaryFloor:     &floor,
+		SalaryCurrency:  &currency,
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.DisplayName != "New Name" || profile.Location != "Recife" {
+		t.Errorf("Expected patched name and untouched location, got %+v", profile)
+	}
+	if len(profile.PreferredFields) != 2 || profile.PreferredFields[1] != "Data" {
+		t.Errorf("Expected trimmed, de-duplicated fields, got %v", profile.PreferredFields)
+	}
+	if profile.SalaryCurrency != "BRL" {
+		t.Errorf("Expected upper-cased currency, got %s", profile.SalaryCurrency)
+	}
+	if saved.DisplayName != "New Name" {
+		t.Error("Expected profile to be persisted")
+	}
+}
+
+func TestUserService_UpdateProfile_ValidationErrors(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{ID: primitive.NewObjectID(), Username: username}, true, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	timezone := "Mars/Olympus"
+	floor := int64(-1)
+	level := enums.EnglishLevelEnum("GOOD")
+	_, err := service.UpdateProfile(context.Background(), "testuser", models.UserProfilePatch{
+		Timezone:     &timezone,
+		SalaryFloor:  &floor,
+		EnglishLevel: &level,
+	})
+
+	if err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+	for _, expected := range []string{"invalid profile", "timezone", "salaryFloor", "englishLevel"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to mention %q, got %q", expected, err.Error())
+		}
+	}
+}
+
+func TestUserService_GetProfile_Empty(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{Username: username}, true, nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	profile, err := service.GetProfile(context.Background(), "testuser")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.DisplayName != "" || len(profile.PreferredFields) != 0 {
+		t.Errorf("Expected empty profile, got %+v", profile)
+	}
+}
+
+func TestUserService_UpdateUser_PreservesProfileAndEmail(t *testing.T) {
+	userID := primitive.NewObjectID()
+	var saved models.User
+	mockRepo := &mockUserRepository{
+		findByUsernameFunc: func(ctx context.Context, username string) (models.User, bool, error) {
+			return models.User{
+				ID: userID, Username: username, Password: "password123", Role: enums.Free,
+				Email: "user@example.com", EmailVerified: true,
+				Profile: &models.UserProfile{DisplayName: "Test"},
+			}, true, nil
+		},
+		updateByIDFunc: func(ctx context.Context, id string, user models.User) error {
+			saved = user
+			return nil
+		},
+	}
+
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+	if _, err := service.UpdateUser(context.Background(), "testuser", "", enums.Premium); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if saved.Email != "user@example.com" || !saved.EmailVerified || saved.Profile == nil {
+		t.Errorf("Expected email and profile to survive the update, got %+v", saved)
+	}
+}
+
+// newPatchTestService backs the repository mock with a single stored user.
+func newPatchTestService(stored models.User, saved *models.User) UserService {
+	mockRepo := &mockUserRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.User, bool, error) {
+			if id != stored.ID.Hex() {
+				return models.User{}, false, nil
+			}
+			return stored, true, nil
+		},
+		updateFieldsFunc: func(ctx context.Context, id string, user models.User, fields ...string) error {
+			*saved = stored
+			applyUserFields(saved, user, fields)
+			return nil
+		},
+	}
+	return NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+}
+
+func patchTestUser() models.User {
+	return models.User{
+		ID: primitive.NewObjectID(), Username: "alice", Password: "password123", Role: enums.Free,
+		Email: "alice@example.com", EmailVerified: true,
+		Profile: &models.UserProfile{DisplayName: "Alice", Location: "Recife", Timezone: "America/Recife"},
+	}
+}
+
+func TestUserService_PatchUser_SelfPasswordAndProfile(t *testing.T) {
+	stored := patchTestUser()
+	var saved models.User
+	service := newPatchTestService(stored, &saved)
+
+	user, err := service.PatchUser(context.Background(), models.UserPatchActor{Username: "ALICE"}, stored.ID.Hex(), map[string]any{
+		"password": "newpassword1",
+		"profile":  map[string]any{"location": nil, "displayName": " Alice B "},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Password != "" {
+		t.Error("Expected password to be cleared from the response")
+	}
+	if saved.Password != "newpassword1" || saved.Role != enums.Free {
+		t.Errorf("Expected new password and unchanged role, got %+v", saved)
+	}
+	if saved.Email != "alice@example.com" || !saved.EmailVerified {
+		t.Error("Expected untouched fields to survive the patch")
+	}
+	if saved.Profile == nil || saved.Profile.DisplayName != "Alice B" || saved.Profile.Location != "" || saved.Profile.Timezone != "America/Recife" {
+		t.Errorf("Expected merged profile, got %+v", saved.Profile)
+	}
+}
+
+func TestUserService_PatchUser_SelfRoleForbidden(t *testing.T) {
+	stored := patchTestUser()
+	var saved models.User
+	service := newPatchTestService(stored, &saved)
+
+	_, err := service.PatchUser(context.Background(), models.UserPatchActor{Username: "alice"}, stored.ID.Hex(), map[string]any{"role": "PREMIUM"})
+
+	var forbidden *models.ForbiddenError
+	if !errors.As(err, &forbidden) || len(forbidden.Fields) != 1 || forbidden.Fields[0] != "role" {
+		t.Errorf("Expected forbidden role patch, got %v", err)
+	}
+	if saved.Username != "" {
+		t.Error("Expected nothing to be saved")
+	}
+}
+
+func TestUserService_PatchUser_OtherUserForbidden(t *testing.T) {
+	stored := patchTestUser()
+	var saved models.User
+	service := newPatchTestService(stored, &saved)
+
+	_, err := service.PatchUser(context.Background(), models.UserPatchActor{Username: "mallory"}, stored.ID.Hex(), map[string]any{"password": "newpassword1"})
+
+	var forbidden *models.ForbiddenError
+	if !errors.As(err, &forbidden) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+}
+
+func TestUserService_PatchUser_AdminChangesRole(t *testing.T) {
+	stored := patchTestUser()
+	var saved models.User
+	service := newPatchTestService(stored, &saved)
+
+	user, err := service.PatchUser(context.Background(), models.UserPatchActor{Admin: true}, stored.ID.Hex(), map[string]any{"role": "PREMIUM"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Role != enums.Premium || saved.Role != enums.Premium {
+		t.Errorf("Expected role PREMIUM, got %s", saved.Role)
+	}
+	if saved.Password != "password123" || saved.Profile == nil {
+		t.Error("Expected password and profile to be kept")
+	}
+}
+
+func TestUserService_PatchUser_WritesOnlyPatchedFields(t *testing.T) {
+	stored := patchTestUser()
+	var written []string
+	mockRepo := &mockUserRepository{
+		findByIDFunc: func(ctx context.Context, id string) (models.User, bool, error) {
+			return stored, true, nil
+		},
+		updateFieldsFunc: func(ctx context.Context, id string, user models.User, fields ...string) error {
+			written = fields
+			return nil
+		},
+	}
+	service := NewUserService(mockRepo, &mockTransactionManager{}, models.DefaultPasswordPolicy())
+
+	_, err := service.PatchUser(context.Background(), models.UserPatchActor{Admin: true}, stored.ID.Hex(), map[string]any{
+		"role":    "PREMIUM",
+		"profile": map[string]any{"displayName": "Al"},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(written) != 2 || written[0] != "profile" || written[1] != "role" {
+		t.Errorf("Expected only profile and role to be written, got %v", written)
+	}
+}
+
+func TestUserService_PatchUser_ListsOffendingFields(t *testing.T) {
+	stored := patchTestUser()
+	var saved models.User
+	service := newPatchTestService(stored, &saved)
+
+	_, err := service.PatchUser(context.Background(), models.UserPatchActor{Admin: true}, stored.ID.Hex(), map[string]any{
+		"username": "bob",
+		"password": "short",
+		"role":     "GOLD",
+		"nickname": "al",
+		"profile":  map[string]any{"timezone": "Mars/Olympus", "hobby": "chess"},
+	})
+
+	var validation *models.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	got := map[string]bool{}
+	for _, fieldErr := range validation.Fields {
+		got[fieldErr.Field] = true
+	}
+	for _, field := range []string{"username", "password", "role", "nickname", "profile.hobby"} {
+		if !got[field] {
+			t.Errorf("Expected %q to be reported, got %+v", field, validation.Fields)
+		}
+	}
+	if saved.Username != "" {
+		t.Error("Expected nothing to be saved")
+	}
+}
+
+func TestUserService_PatchUser_NullProfileRemovesIt(t *testing.T) {
+	stored := patchTestUser()
+	var saved models.User
+	service := newPatchTestService(stored, &saved)
+
+	if _, err := service.PatchUser(context.Background(), models.UserPatchActor{Username: "alice"}, stored.ID.Hex(), map[string]any{"profile": nil}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Profile != nil {
+		t.Errorf("Expected profile to be removed, got %+v", saved.Profile)
+	}
+}
+
+func TestUserService_PatchUser_NotFound(t *testing.T) {
+	var saved models.User
+	service := newPatchTestService(patchTestUser(), &saved)
+
+	_, err := service.PatchUser(context.Background(), models.UserPatchActor{Admin: true}, primitive.NewObjectID().Hex(), map[string]any{})
+
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
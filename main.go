@@ -4,8 +4,10 @@ import (
 	"context"
 	"jboard-go-crud/internal/config"
 	"jboard-go-crud/internal/controllers"
+	"jboard-go-crud/internal/migrations"
 	"jboard-go-crud/internal/repositories"
 	"jboard-go-crud/internal/routers"
+	"jboard-go-crud/internal/scheduler"
 	"jboard-go-crud/internal/services"
 	"log"
 	"net/http"
@@ -49,30 +51,128 @@ func main() {
 		dbName, jobCollName, userCollName)
 
 	// 3) Initialize repositories and services
+	userRepo := repositories.NewUserRepository(client, dbName, userCollName)
+
+	skillTaxonomyRepo := repositories.NewSkillTaxonomyRepository(client, dbName, "skill_taxonomy")
+
+	skillRepo := repositories.NewSkillRepository(client, dbName, "skills")
+	savedSearchRepo := repositories.NewSavedSearchRepository(client, dbName, "saved_searches")
+	jobAlertRepo := repositories.NewJobAlertRepository(client, dbName, "job_alerts")
+	bookmarkRepo := repositories.NewBookmarkRepository(client, dbName, "bookmarks")
+	applicationRepo := repositories.NewApplicationRepository(client, dbName, "applications")
+	jobAlertQueueRepo := repositories.NewJobAlertQueueRepository(client, dbName, "job_alert_queue")
+
+	appMailer := config.LoadMailer()
+
 	jobRepo := repositories.NewJobRepository(client, dbName, jobCollName)
-	jobService := services.NewJobService(jobRepo)
+
+	// New jobs are queued as they are ingested and matched against saved searches and skills
+	// by the job-alert-match task.
+	alertService := services.NewAlertService(jobAlertRepo, jobAlertQueueRepo, jobRepo, savedSearchRepo, skillRepo, userRepo, skillTaxonomyRepo,
+		config.LoadAlertChannels(appMailer), config.LoadAlertPolicy())
+
+	jobService := services.NewJobService(jobRepo, userRepo, skillTaxonomyRepo, alertService)
 	jobHandler := controllers.NewJobHandler(jobService)
 
-	userRepo := repositories.NewUserRepository(client, dbName, userCollName)
-	userService := services.NewUserService(userRepo)
-	userHandler := controllers.NewUserHandler(userService)
+	txManager := repositories.NewTransactionManager(client)
 
-	skillRepo := repositories.NewSkillRepository(client, dbName, "skills")
-	skillService := services.NewSkillService(skillRepo)
+	skillService := services.NewSkillService(skillRepo, skillTaxonomyRepo, jobRepo, userRepo, txManager)
 	skillHandler := controllers.NewSkillHandler(skillService)
 
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(client, dbName, "login_attempts")
+	loginAuditRepo := repositories.NewLoginAuditRepository(client, dbName, "login_audit")
+	userTokenRepo := repositories.NewUserTokenRepository(client, dbName, "user_tokens")
+
+	// Every repository holding user-owned documents must be listed here so that
+	// account deletion, renames and data exports cover it.
+	userService := services.NewUserService(userRepo, txManager, config.LoadPasswordPolicy(), skillRepo, loginAuditRepo, loginAttemptRepo, userTokenRepo, savedSearchRepo, jobAlertRepo, bookmarkRepo, applicationRepo)
+	userHandler := controllers.NewUserHandler(userService)
+
+	authService := services.NewAuthService(userRepo, loginAttemptRepo, loginAuditRepo, config.LoadLockoutPolicy())
+	authHandler := controllers.NewAuthHandler(authService)
+
+	accountService := services.NewAccountService(userRepo, userTokenRepo, loginAttemptRepo, appMailer, config.LoadPasswordPolicy(), config.LoadTokenPolicy())
+	accountHandler := controllers.NewAccountHandler(accountService)
+
+	migrationRunner := migrations.NewRunner(
+		migrations.NewDuplicateUsernamesReport(userRepo, skillRepo),
+		migrations.NewUsernameIndexes(userRepo, skillRepo),
+		migrations.NewLegacySkills(skillRepo),
+		migrations.NewSkillTaxonomySeed(skillTaxonomyRepo),
+		migrations.NewAmbiguousSkillAliases(skillTaxonomyRepo),
+	)
+	if err := migrationRunner.Run(context.Background()); err != nil {
+		log.Printf("ERROR: Some migrations failed: %v", err)
+	}
+
+	matchService := services.NewMatchService(userRepo, skillRepo, skillTaxonomyRepo, jobRepo)
+	matchHandler := controllers.NewMatchHandler(matchService)
+
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, userRepo, jobService, config.LoadSavedSearchPolicy())
+	savedSearchHandler := controllers.NewSavedSearchHandler(savedSearchService)
+
+	bookmarkService := services.NewBookmarkService(bookmarkRepo, userRepo, jobRepo)
+	bookmarkHandler := controllers.NewBookmarkHandler(bookmarkService)
+
+	applicationService := services.NewApplicationService(applicationRepo, userRepo, jobRepo)
+	applicationHandler := controllers.NewApplicationHandler(applicationService)
+
+	marketSnapshotRepo := repositories.NewMarketSnapshotRepository(client, dbName, "market_snapshots")
+	insightsService := services.NewInsightsService(marketSnapshotRepo, jobRepo, userRepo, config.LoadSalaryPolicy())
+	insightsHandler := controllers.NewInsightsHandler(insightsService)
+
+	subscriptionService := services.NewSubscriptionService(userRepo)
+	subscriptionHandler := controllers.NewSubscriptionHandler(subscriptionService, os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+
 	// 4) Initialize routers
 	jobRouter := routers.NewJobsController(jobHandler)
-	userRouter := routers.NewUsersController(userHandler)
+	userRouter := routers.NewUsersController(userHandler, matchHandler, savedSearchHandler, bookmarkHandler, applicationHandler)
 	skillRouter := routers.NewSkillsController(skillHandler)
+	subscriptionRouter := routers.NewSubscriptionsController(subscriptionHandler)
+	insightsRouter := routers.NewInsightsController(insightsHandler)
+	adminRouter := routers.NewAdminController(userHandler, authHandler, skillHandler, jobHandler, insightsHandler)
+	authRouter := routers.NewAuthController(authHandler, accountHandler)
 
 	// 5) Create main router and mount sub-routers
 	mainRouter := mux.NewRouter()
 	mainRouter.PathPrefix("/v1/jobs").Handler(jobRouter)
 	mainRouter.PathPrefix("/v1/users").Handler(userRouter)
 	mainRouter.PathPrefix("/v1/skills").Handler(skillRouter)
-
-	// 6) HTTP Server
+	mainRouter.PathPrefix("/v1/subscriptions").Handler(subscriptionRouter)
+	mainRouter.PathPrefix("/v1/webhooks").Handler(subscriptionRouter)
+	mainRouter.PathPrefix("/v1/insights").Handler(insightsRouter)
+	mainRouter.PathPrefix("/v1/admin").Handler(adminRouter)
+	mainRouter.PathPrefix("/v1/auth").Handler(authRouter)
+
+	// 6) Scheduled jobs
+	intervals := config.LoadSchedulerIntervals()
+	jobScheduler := scheduler.NewScheduler()
+	jobScheduler.Every("subscription-downgrade", intervals.SubscriptionCheck, func(ctx context.Context) error {
+		_, err := subscriptionService.DowngradeExpired(ctx)
+		return err
+	})
+	// Picks up skill catalog edits and merges for jobs tagged before them.
+	jobScheduler.Every("job-skill-retag", intervals.JobSkillRetag, func(ctx context.Context) error {
+		_, err := jobService.RetagJobs(ctx)
+		return err
+	})
+	// Matches the jobs queued since the last run against saved searches and skills.
+	jobScheduler.Every("job-alert-match", intervals.AlertMatch, func(ctx context.Context) error {
+		_, err := alertService.MatchQueued(ctx)
+		return err
+	})
+	// Sends PREMIUM alerts right away; FREE alerts wait for the daily digest hour.
+	jobScheduler.Every("job-alert-dispatch", intervals.AlertDispatch, func(ctx context.Context) error {
+		_, err := alertService.DispatchDue(ctx)
+		return err
+	})
+	// Checks more often than daily so a restart does not skip a day's market snapshot.
+	jobScheduler.Every("market-snapshot", intervals.MarketSnapshotCheck, func(ctx context.Context) error {
+		_, err := insightsService.EnsureDailySnapshot(ctx)
+		return err
+	})
+
+	// 7) HTTP Server
 	srv := &http.Server{
 		Addr:              ":8080",
 		Handler:           mainRouter,
@@ -97,6 +197,7 @@ func main() {
 	defer cancel()
 
 	_ = srv.Shutdown(ctx)
+	jobScheduler.Stop()
 	config.CloseConnection()
 
 	log.Printf("Application stopped")